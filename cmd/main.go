@@ -3,13 +3,17 @@ package main
 import (
 	"context"
 	"dndcc/internal"
+	"dndcc/internal/character"
 	"dndcc/internal/controllers"
 	"dndcc/internal/database"
+	"dndcc/internal/dice"
 	"dndcc/internal/middleware"
 	"dndcc/internal/models"
 	"dndcc/internal/repositories"
 	"dndcc/internal/services"
+	"io/fs"
 	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
 
@@ -29,6 +33,17 @@ func main() {
 		panic(err)
 	}
 
+	var contentDir fs.FS
+	if config.Rules.ContentDir != "" {
+		contentDir = os.DirFS(config.Rules.ContentDir)
+	}
+	rules := map[character.RulesetVersion]*character.Registry{}
+	for _, version := range character.RulesetVersions {
+		if rules[version], err = character.LoadRuleset(version, contentDir); err != nil {
+			panic(err)
+		}
+	}
+
 	logger := grove.NewDefaultLogger("ccapi-auth")
 	authConfig, err := grove.LoadAuthenticatorConfigFromEnv()
 	if err != nil {
@@ -49,9 +64,54 @@ func main() {
 	sessionRepo := repositories.NewSessionRepository(db)
 	sessionService := services.NewSessionService(sessionRepo)
 
+	roller := dice.NewRoller(nil)
+
+	homebrewRepo := repositories.NewHomebrewRepository(db)
+	homebrewService := services.NewHomebrewService(homebrewRepo, rules)
+	if err := homebrewService.LoadRules(); err != nil {
+		panic(err)
+	}
+
 	characterRepo := repositories.NewCharacterRepository(db)
 	characterService := services.NewCharacterService(characterRepo)
 
+	llmService := services.NewLLMService(config.LLM)
+	personalityService := services.NewPersonalityService(llmService, roller)
+	draftService := services.NewCharacterDraftService(llmService, characterService, homebrewService)
+
+	spellRepo := repositories.NewSpellRepository(db)
+	spellService := services.NewSpellService(spellRepo, characterRepo)
+
+	itemRepo := repositories.NewItemRepository(db)
+	itemService := services.NewItemService(itemRepo)
+
+	abilityScoreRepo := repositories.NewAbilityScoreRepository(db)
+	abilityScoreService := services.NewAbilityScoreService(abilityScoreRepo, roller)
+
+	levelRepo := repositories.NewLevelRepository(db)
+	levelService := services.NewLevelService(levelRepo, characterRepo, roller)
+
+	featureRepo := repositories.NewFeatureRepository(db)
+	featureService := services.NewFeatureService(featureRepo, characterRepo)
+
+	featRepo := repositories.NewFeatRepository(db)
+	featService := services.NewFeatService(featRepo, characterRepo)
+
+	proficiencyRepo := repositories.NewProficiencyRepository(db)
+	proficiencyService := services.NewProficiencyService(proficiencyRepo, characterRepo)
+
+	restRepo := repositories.NewRestRepository(db)
+	restService := services.NewRestService(restRepo, characterRepo, roller)
+
+	healthRepo := repositories.NewHealthRepository(db)
+	healthService := services.NewHealthService(healthRepo, characterRepo)
+
+	conditionRepo := repositories.NewConditionRepository(db)
+	conditionService := services.NewConditionService(conditionRepo, characterRepo, roller)
+
+	rollRepo := repositories.NewRollRepository(db)
+	rollService := services.NewRollService(rollRepo, characterRepo, roller)
+
 	authWithRefreshMiddleware := middleware.
 		NewAuthWithRefreshMiddleware(logger, *authenticator, sessionService, authService).
 		WithRouteException("/").WithRouteException("/auth/login").WithRouteException("/auth/register").WithRouteException("/auth/validate")
@@ -60,7 +120,19 @@ func main() {
 		WithMiddleware(authWithRefreshMiddleware.Middleware).
 		WithController(controllers.NewAuthController(authService, sessionService, logger)).
 		WithController(controllers.NewHomeController(logger, authenticator)).
-		WithController(controllers.NewCharacterController(logger, characterService))
+		WithController(controllers.NewCharacterController(logger, characterService, homebrewService, llmService, personalityService, draftService)).
+		WithController(controllers.NewAbilityScoreController(logger, abilityScoreService)).
+		WithController(controllers.NewSpellController(logger, spellService, characterService)).
+		WithController(controllers.NewItemController(logger, itemService, characterService, homebrewService)).
+		WithController(controllers.NewLevelController(logger, levelService, characterService)).
+		WithController(controllers.NewFeatureController(logger, featureService, characterService)).
+		WithController(controllers.NewFeatController(logger, featService, characterService)).
+		WithController(controllers.NewProficiencyController(logger, proficiencyService, characterService)).
+		WithController(controllers.NewRestController(logger, restService, characterService)).
+		WithController(controllers.NewHealthController(logger, healthService, characterService)).
+		WithController(controllers.NewConditionController(logger, conditionService, characterService)).
+		WithController(controllers.NewRollController(logger, rollService)).
+		WithController(controllers.NewHomebrewController(logger, homebrewService))
 	app.
 		WithScope("/", authScope).
 		WithRoute("/health", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS character_race_stat_choices (
+    character_id INTEGER NOT NULL,
+    choice_index INTEGER NOT NULL,
+    stat TEXT NOT NULL,
+    PRIMARY KEY (character_id, choice_index),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS character_spells (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    level INTEGER NOT NULL DEFAULT 0,
+    school TEXT NOT NULL,
+    prepared INTEGER NOT NULL DEFAULT 0,
+    description TEXT NOT NULL DEFAULT '',
+
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS character_spell_slots (
+    character_id INTEGER NOT NULL,
+    slot_level INTEGER NOT NULL,
+    pact INTEGER NOT NULL DEFAULT 0,
+    expended INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (character_id, slot_level, pact),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS character_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    item_type TEXT NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1,
+    weight REAL NOT NULL DEFAULT 0,
+    equipped INTEGER NOT NULL DEFAULT 0,
+    armor_category TEXT NOT NULL DEFAULT '',
+    armor_class INTEGER NOT NULL DEFAULT 0,
+    strength_requirement INTEGER NOT NULL DEFAULT 0,
+    stealth_disadvantage INTEGER NOT NULL DEFAULT 0,
+    weapon_category TEXT NOT NULL DEFAULT '',
+    damage TEXT NOT NULL DEFAULT '',
+    damage_type TEXT NOT NULL DEFAULT '',
+    versatile_damage TEXT NOT NULL DEFAULT '',
+    ranged INTEGER NOT NULL DEFAULT 0,
+
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS character_item_properties (
+    item_id INTEGER NOT NULL,
+    property TEXT NOT NULL,
+    PRIMARY KEY (item_id, property),
+    FOREIGN KEY (item_id) REFERENCES character_items(id) ON DELETE CASCADE
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS character_rolls (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    character_id INTEGER NOT NULL,
+    label TEXT NOT NULL DEFAULT '',
+    expression TEXT NOT NULL,
+    breakdown TEXT NOT NULL,
+    total INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_character_rolls_character_id ON character_rolls (character_id, id);
@@ -0,0 +1,16 @@
+ALTER TABLE characters ADD COLUMN ability_score_method TEXT NOT NULL DEFAULT 'Manual';
+
+CREATE TABLE IF NOT EXISTS ability_score_rolls (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_id INTEGER NOT NULL,
+    character_id INTEGER,
+    roll_index INTEGER NOT NULL,
+    breakdown TEXT NOT NULL,
+    total INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (owner_id) REFERENCES auth(id) ON DELETE CASCADE,
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_ability_score_rolls_owner_id ON ability_score_rolls (owner_id, character_id);
@@ -0,0 +1,34 @@
+ALTER TABLE characters ADD COLUMN experience INTEGER NOT NULL DEFAULT 0;
+
+UPDATE characters SET experience = CASE
+    WHEN level >= 20 THEN 355000
+    WHEN level = 19 THEN 305000
+    WHEN level = 18 THEN 265000
+    WHEN level = 17 THEN 225000
+    WHEN level = 16 THEN 195000
+    WHEN level = 15 THEN 165000
+    WHEN level = 14 THEN 140000
+    WHEN level = 13 THEN 120000
+    WHEN level = 12 THEN 100000
+    WHEN level = 11 THEN 85000
+    WHEN level = 10 THEN 64000
+    WHEN level = 9 THEN 48000
+    WHEN level = 8 THEN 34000
+    WHEN level = 7 THEN 23000
+    WHEN level = 6 THEN 14000
+    WHEN level = 5 THEN 6500
+    WHEN level = 4 THEN 2700
+    WHEN level = 3 THEN 900
+    WHEN level = 2 THEN 300
+    ELSE 0
+END;
+
+CREATE TABLE IF NOT EXISTS character_hit_points (
+    character_id INTEGER NOT NULL,
+    level INTEGER NOT NULL,
+    class TEXT NOT NULL,
+    hit_die_roll INTEGER NOT NULL,
+    rolled BOOLEAN NOT NULL DEFAULT 0,
+    PRIMARY KEY (character_id, level),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
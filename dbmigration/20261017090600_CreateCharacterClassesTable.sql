@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS character_classes (
+    character_id INTEGER NOT NULL,
+    class_index INTEGER NOT NULL,
+    class TEXT NOT NULL,
+    level INTEGER NOT NULL,
+    PRIMARY KEY (character_id, class_index),
+    UNIQUE (character_id, class),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+-- Existing characters become single-class characters. characters.class stays as the starting class.
+INSERT INTO character_classes (character_id, class_index, class, level)
+SELECT id, 0, class, level FROM characters;
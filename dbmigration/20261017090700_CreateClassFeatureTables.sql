@@ -0,0 +1,9 @@
+ALTER TABLE character_classes ADD COLUMN subclass TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS character_feature_uses (
+    character_id INTEGER NOT NULL,
+    feature TEXT NOT NULL,
+    expended INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (character_id, feature),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS character_hit_dice (
+    character_id INTEGER NOT NULL,
+    die INTEGER NOT NULL,
+    expended INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (character_id, die),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
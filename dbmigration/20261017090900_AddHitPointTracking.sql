@@ -0,0 +1,3 @@
+ALTER TABLE characters ADD COLUMN temporary_health_points INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN max_health_points_override INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN death_save_failures INTEGER NOT NULL DEFAULT 0;
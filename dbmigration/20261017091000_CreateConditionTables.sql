@@ -0,0 +1,10 @@
+ALTER TABLE characters ADD COLUMN death_save_successes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE characters ADD COLUMN exhaustion_level INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS character_conditions (
+    character_id INTEGER NOT NULL,
+    condition TEXT NOT NULL,
+    rounds INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (character_id, condition),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
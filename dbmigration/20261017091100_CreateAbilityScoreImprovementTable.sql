@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS character_ability_score_improvements (
+    character_id INTEGER NOT NULL,
+    class TEXT NOT NULL,
+    level INTEGER NOT NULL,
+    feat TEXT NOT NULL DEFAULT '',
+    first_stat TEXT NOT NULL DEFAULT '',
+    second_stat TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (character_id, class, level),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS character_proficiencies_new (
+    character_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL,
+    level INTEGER NOT NULL DEFAULT 2,
+    source TEXT NOT NULL,
+    PRIMARY KEY (character_id, kind, name, level, source),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+INSERT INTO character_proficiencies_new (character_id, kind, name, level, source)
+SELECT character_id, 'Skill', proficiency, 2, 'Background' FROM character_proficiencies;
+
+DROP TABLE character_proficiencies;
+
+ALTER TABLE character_proficiencies_new RENAME TO character_proficiencies;
@@ -0,0 +1 @@
+ALTER TABLE characters ADD COLUMN draconic_ancestry TEXT NOT NULL DEFAULT '';
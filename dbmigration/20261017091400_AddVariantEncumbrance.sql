@@ -0,0 +1 @@
+ALTER TABLE characters ADD COLUMN variant_encumbrance INTEGER NOT NULL DEFAULT 0;
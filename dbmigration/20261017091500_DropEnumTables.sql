@@ -0,0 +1,3 @@
+-- Skills and classes come from the rules registry content packs, so the seeded enum tables are no longer read.
+DROP TABLE IF EXISTS skills;
+DROP TABLE IF EXISTS classes;
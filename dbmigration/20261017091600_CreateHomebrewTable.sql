@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS homebrew (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    name TEXT NOT NULL,
+    content TEXT NOT NULL, -- YAML for one race, subrace, background, class or item
+    shared INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (owner_id, kind, name),
+    FOREIGN KEY (owner_id) REFERENCES auth(id) ON DELETE CASCADE
+);
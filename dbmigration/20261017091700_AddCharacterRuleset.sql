@@ -0,0 +1 @@
+ALTER TABLE characters ADD COLUMN ruleset TEXT NOT NULL DEFAULT '2014';
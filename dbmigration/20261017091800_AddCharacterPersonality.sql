@@ -0,0 +1,6 @@
+ALTER TABLE characters ADD COLUMN personality_traits TEXT NOT NULL DEFAULT '';
+ALTER TABLE characters ADD COLUMN ideals TEXT NOT NULL DEFAULT '';
+ALTER TABLE characters ADD COLUMN bonds TEXT NOT NULL DEFAULT '';
+ALTER TABLE characters ADD COLUMN flaws TEXT NOT NULL DEFAULT '';
+ALTER TABLE characters ADD COLUMN appearance TEXT NOT NULL DEFAULT '';
+ALTER TABLE characters ADD COLUMN alignment TEXT NOT NULL DEFAULT '';
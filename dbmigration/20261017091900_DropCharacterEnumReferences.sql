@@ -0,0 +1,59 @@
+-- The classes table was dropped with the other enum tables and races never existed, so characters is
+-- rebuilt without the foreign keys to them. Classes and races are checked against the rules registry,
+-- which includes homebrew.
+CREATE TABLE IF NOT EXISTS characters_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    bio TEXT NOT NULL DEFAULT '',
+    background TEXT NOT NULL,
+    class TEXT NOT NULL,
+    level INTEGER NOT NULL,
+    race_type TEXT NOT NULL,
+    subrace_type TEXT,
+    race_move_speed INTEGER NOT NULL DEFAULT 0,
+    strength INTEGER NOT NULL,
+    dexterity INTEGER NOT NULL,
+    constitution INTEGER NOT NULL,
+    intelligence INTEGER NOT NULL,
+    wisdom INTEGER NOT NULL,
+    charisma INTEGER NOT NULL,
+    current_health_points INTEGER NOT NULL,
+    ability_score_method TEXT NOT NULL DEFAULT 'Manual',
+    experience INTEGER NOT NULL DEFAULT 0,
+    temporary_health_points INTEGER NOT NULL DEFAULT 0,
+    max_health_points_override INTEGER NOT NULL DEFAULT 0,
+    death_save_failures INTEGER NOT NULL DEFAULT 0,
+    death_save_successes INTEGER NOT NULL DEFAULT 0,
+    exhaustion_level INTEGER NOT NULL DEFAULT 0,
+    draconic_ancestry TEXT NOT NULL DEFAULT '',
+    variant_encumbrance INTEGER NOT NULL DEFAULT 0,
+    ruleset TEXT NOT NULL DEFAULT '2014',
+    personality_traits TEXT NOT NULL DEFAULT '',
+    ideals TEXT NOT NULL DEFAULT '',
+    bonds TEXT NOT NULL DEFAULT '',
+    flaws TEXT NOT NULL DEFAULT '',
+    appearance TEXT NOT NULL DEFAULT '',
+    alignment TEXT NOT NULL DEFAULT '',
+
+    FOREIGN KEY (owner_id) REFERENCES auth(id) ON DELETE CASCADE
+);
+
+INSERT INTO characters_new (
+    id, owner_id, name, bio, background, class, level, race_type, subrace_type, race_move_speed,
+    strength, dexterity, constitution, intelligence, wisdom, charisma, current_health_points,
+    ability_score_method, experience, temporary_health_points, max_health_points_override,
+    death_save_failures, death_save_successes, exhaustion_level, draconic_ancestry, variant_encumbrance,
+    ruleset, personality_traits, ideals, bonds, flaws, appearance, alignment
+)
+SELECT
+    id, owner_id, name, bio, background, class, level, race_type, subrace_type, race_move_speed,
+    strength, dexterity, constitution, intelligence, wisdom, charisma, current_health_points,
+    ability_score_method, experience, temporary_health_points, max_health_points_override,
+    death_save_failures, death_save_successes, exhaustion_level, draconic_ancestry, variant_encumbrance,
+    ruleset, personality_traits, ideals, bonds, flaws, appearance, alignment
+FROM characters;
+
+DROP TABLE characters;
+
+ALTER TABLE characters_new RENAME TO characters;
@@ -0,0 +1,21 @@
+-- Ability score rolls outlive the character they were used for, so deleting a Rolled character
+-- can't be used to roll again. The rolls are rebuilt without the cascade from characters.
+CREATE TABLE IF NOT EXISTS ability_score_rolls_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_id INTEGER NOT NULL,
+    character_id INTEGER,
+    roll_index INTEGER NOT NULL,
+    breakdown TEXT NOT NULL,
+    total INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (owner_id) REFERENCES auth(id) ON DELETE CASCADE
+);
+
+INSERT INTO ability_score_rolls_new (id, owner_id, character_id, roll_index, breakdown, total, created_at)
+SELECT id, owner_id, character_id, roll_index, breakdown, total, created_at FROM ability_score_rolls;
+
+DROP TABLE ability_score_rolls;
+ALTER TABLE ability_score_rolls_new RENAME TO ability_score_rolls;
+
+CREATE INDEX IF NOT EXISTS idx_ability_score_rolls_owner_id ON ability_score_rolls (owner_id, character_id);
@@ -0,0 +1,148 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrUndefinedAbilityScoreMethod = errors.New("attempted to use undefined ability score method")
+	ErrAbilityScoreOutOfRange      = errors.New("ability score is out of range")
+	ErrPointBuyScoreOutOfRange     = errors.New("point buy scores must be between 8 and 15")
+	ErrPointBuyOverBudget          = errors.New("point buy total exceeds the budget")
+	ErrStandardArrayMismatch       = errors.New("ability scores must use each standard array value exactly once")
+	ErrAbilityScoresNotRolled      = errors.New("ability scores have not been rolled yet")
+	ErrRolledScoresMismatch        = errors.New("ability scores must use each rolled value exactly once")
+)
+
+type AbilityScoreMethod string
+
+const (
+	// AbilityScoreMethodManual covers characters created before generation methods were tracked.
+	AbilityScoreMethodManual        AbilityScoreMethod = "Manual"
+	AbilityScoreMethodPointBuy      AbilityScoreMethod = "Point Buy"
+	AbilityScoreMethodStandardArray AbilityScoreMethod = "Standard Array"
+	AbilityScoreMethodRolled        AbilityScoreMethod = "Rolled"
+)
+
+// AbilityScoreMethods lists the methods a new character can be created with.
+var AbilityScoreMethods = []AbilityScoreMethod{
+	AbilityScoreMethodPointBuy,
+	AbilityScoreMethodStandardArray,
+	AbilityScoreMethodRolled,
+}
+
+const (
+	PointBuyBudget   = 27
+	MinAbilityScore  = 1
+	MaxAbilityScore  = 30
+	AbilityRollCount = 6
+	// AbilityRollExpression is rolled once per ability score for the rolled method.
+	AbilityRollExpression = "4d6kh3"
+)
+
+// StandardArray holds the scores assigned with the standard array method.
+var StandardArray = []int{15, 14, 13, 12, 10, 8}
+
+var pointBuyCosts = map[int]int{
+	8:  0,
+	9:  1,
+	10: 2,
+	11: 3,
+	12: 4,
+	13: 5,
+	14: 7,
+	15: 9,
+}
+
+func (m AbilityScoreMethod) IsValid() bool {
+	switch m {
+	case AbilityScoreMethodManual, AbilityScoreMethodPointBuy, AbilityScoreMethodStandardArray, AbilityScoreMethodRolled:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetPointBuyCost returns how many points a score costs under point buy.
+func GetPointBuyCost(score int) (int, error) {
+	cost, ok := pointBuyCosts[score]
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrPointBuyScoreOutOfRange, score)
+	}
+	return cost, nil
+}
+
+// GetScores returns the six ability scores in sheet order.
+func (s *StatBlock) GetScores() []int {
+	scores := make([]int, len(StatNames))
+	for i, stat := range StatNames {
+		scores[i] = s.GetScore(stat)
+	}
+	return scores
+}
+
+// GetPointBuyTotal returns the points spent on the stat block under point buy.
+func (s *StatBlock) GetPointBuyTotal() (int, error) {
+	total := 0
+	for _, stat := range StatNames {
+		cost, err := GetPointBuyCost(s.GetScore(stat))
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", stat, err)
+		}
+		total += cost
+	}
+	return total, nil
+}
+
+// ValidateScores checks that the base scores could have been produced by the method.
+// rolls holds the recorded 4d6-drop-lowest totals and is only used by the rolled method.
+func (m AbilityScoreMethod) ValidateScores(s *StatBlock, rolls []int) error {
+	for _, stat := range StatNames {
+		if score := s.GetScore(stat); score < MinAbilityScore || score > MaxAbilityScore {
+			return fmt.Errorf("%w: %s is %d", ErrAbilityScoreOutOfRange, stat, score)
+		}
+	}
+
+	switch m {
+	case AbilityScoreMethodManual:
+		return nil
+	case AbilityScoreMethodPointBuy:
+		total, err := s.GetPointBuyTotal()
+		if err != nil {
+			return err
+		}
+		if total > PointBuyBudget {
+			return fmt.Errorf("%w: spent %d of %d", ErrPointBuyOverBudget, total, PointBuyBudget)
+		}
+		return nil
+	case AbilityScoreMethodStandardArray:
+		if !sameScores(s.GetScores(), StandardArray) {
+			return ErrStandardArrayMismatch
+		}
+		return nil
+	case AbilityScoreMethodRolled:
+		if len(rolls) != AbilityRollCount {
+			return ErrAbilityScoresNotRolled
+		}
+		if !sameScores(s.GetScores(), rolls) {
+			return ErrRolledScoresMismatch
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUndefinedAbilityScoreMethod, m)
+	}
+}
+
+// sameScores reports whether both lists hold the same values, ignoring order.
+func sameScores(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := slices.Clone(a)
+	sortedB := slices.Clone(b)
+	slices.Sort(sortedA)
+	slices.Sort(sortedB)
+	return slices.Equal(sortedA, sortedB)
+}
@@ -0,0 +1,58 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"testing"
+)
+
+func TestGetPointBuyTotal(t *testing.T) {
+	block := &character.StatBlock{Strength: 15, Dexterity: 15, Constitution: 15, Intelligence: 8, Wisdom: 8, Charisma: 8}
+	total, err := block.GetPointBuyTotal()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 27 {
+		t.Fatalf("got incorrect point buy total: %d; want 27", total)
+	}
+
+	block.Strength = 16
+	if _, err := block.GetPointBuyTotal(); !errors.Is(err, character.ErrPointBuyScoreOutOfRange) {
+		t.Fatalf("got %v; want %v", err, character.ErrPointBuyScoreOutOfRange)
+	}
+}
+
+func TestValidateScores(t *testing.T) {
+	standard := &character.StatBlock{Strength: 8, Dexterity: 14, Constitution: 13, Intelligence: 12, Wisdom: 10, Charisma: 15}
+	rolled := []int{17, 9, 13, 12, 10, 8}
+
+	tests := []struct {
+		Name     string
+		Method   character.AbilityScoreMethod
+		Block    *character.StatBlock
+		Rolls    []int
+		Expected error
+	}{
+		{"point buy within budget", character.AbilityScoreMethodPointBuy, &character.StatBlock{Strength: 15, Dexterity: 14, Constitution: 13, Intelligence: 12, Wisdom: 10, Charisma: 8}, nil, nil},
+		{"point buy over budget", character.AbilityScoreMethodPointBuy, &character.StatBlock{Strength: 15, Dexterity: 15, Constitution: 15, Intelligence: 15, Wisdom: 8, Charisma: 8}, nil, character.ErrPointBuyOverBudget},
+		{"point buy out of range", character.AbilityScoreMethodPointBuy, &character.StatBlock{Strength: 7, Dexterity: 8, Constitution: 8, Intelligence: 8, Wisdom: 8, Charisma: 8}, nil, character.ErrPointBuyScoreOutOfRange},
+		{"standard array any order", character.AbilityScoreMethodStandardArray, standard, nil, nil},
+		{"standard array duplicate", character.AbilityScoreMethodStandardArray, &character.StatBlock{Strength: 15, Dexterity: 15, Constitution: 13, Intelligence: 12, Wisdom: 10, Charisma: 8}, nil, character.ErrStandardArrayMismatch},
+		{"rolled matches", character.AbilityScoreMethodRolled, &character.StatBlock{Strength: 8, Dexterity: 17, Constitution: 13, Intelligence: 12, Wisdom: 10, Charisma: 9}, rolled, nil},
+		{"rolled mismatch", character.AbilityScoreMethodRolled, standard, rolled, character.ErrRolledScoresMismatch},
+		{"rolled without rolls", character.AbilityScoreMethodRolled, standard, nil, character.ErrAbilityScoresNotRolled},
+		{"manual", character.AbilityScoreMethodManual, &character.StatBlock{Strength: 20, Dexterity: 3, Constitution: 18, Intelligence: 3, Wisdom: 3, Charisma: 3}, nil, nil},
+		{"manual out of range", character.AbilityScoreMethodManual, &character.StatBlock{Strength: 31, Dexterity: 3, Constitution: 18, Intelligence: 3, Wisdom: 3, Charisma: 3}, nil, character.ErrAbilityScoreOutOfRange},
+		{"undefined method", character.AbilityScoreMethod("Dice Bag"), standard, nil, character.ErrUndefinedAbilityScoreMethod},
+	}
+
+	for _, test := range tests {
+		err := test.Method.ValidateScores(test.Block, test.Rolls)
+		if test.Expected == nil && err != nil {
+			t.Fatalf("%s got unexpected error: %v", test.Name, err)
+		}
+		if test.Expected != nil && !errors.Is(err, test.Expected) {
+			t.Fatalf("%s got %v; want %v", test.Name, err, test.Expected)
+		}
+	}
+}
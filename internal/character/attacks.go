@@ -0,0 +1,127 @@
+package character
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// unarmedStrikeDamage is the flat damage of an unarmed strike before the Strength modifier.
+const unarmedStrikeDamage = 1
+
+type Attack struct {
+	Name            string
+	Ability         StatName
+	Proficient      bool
+	AttackBonus     int
+	Damage          string
+	VersatileDamage string
+	DamageType      string
+	Ranged          bool
+	Properties      []WeaponProperty
+}
+
+// GetWeaponCategoryProficiencies returns the weapon categories the class is proficient with.
+func (d ClassDefinition) GetWeaponCategoryProficiencies() []WeaponCategory {
+	return append([]WeaponCategory{}, d.Proficiencies.WeaponCategories...)
+}
+
+// GetWeaponProficiencies returns individual weapons the class is proficient with outside of its categories.
+func (d ClassDefinition) GetWeaponProficiencies() []string {
+	return append([]string{}, d.Proficiencies.Weapons...)
+}
+
+// IsProficientWithWeapon checks the weapon against the class categories and individually named weapons.
+func (d ClassDefinition) IsProficientWithWeapon(item Item) bool {
+	if slices.Contains(d.GetWeaponCategoryProficiencies(), item.Weapon.Category) {
+		return true
+	}
+	return isNamedWeapon(d.GetWeaponProficiencies(), item)
+}
+
+func isNamedWeapon(names []string, item Item) bool {
+	for _, name := range names {
+		if strings.EqualFold(name, strings.TrimSpace(item.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// getAttackAbility picks the ability a weapon attacks with: Dexterity for ranged weapons,
+// the better of Strength and Dexterity for finesse weapons, and Strength otherwise.
+func (c *Character) getAttackAbility(weapon WeaponStats) StatName {
+	if weapon.HasProperty(PropertyFinesse) {
+		if c.GetAbilityScore(StatDexterity) > c.GetAbilityScore(StatStrength) {
+			return StatDexterity
+		}
+		return StatStrength
+	}
+	if weapon.Ranged {
+		return StatDexterity
+	}
+	return StatStrength
+}
+
+// GetWeaponAttack computes the attack bonus and damage expressions for a weapon.
+func (c *Character) GetWeaponAttack(item Item) Attack {
+	ability := c.getAttackAbility(item.Weapon)
+	modifier := c.GetAbilityScore(ability)
+	proficient := c.IsProficientWithWeapon(item)
+
+	attackBonus := modifier
+	if proficient {
+		attackBonus += c.GetProficiencyBonus()
+	}
+
+	attack := Attack{
+		Name:        item.Name,
+		Ability:     ability,
+		Proficient:  proficient,
+		AttackBonus: attackBonus,
+		Damage:      formatDamage(item.Weapon.Damage, modifier),
+		DamageType:  item.Weapon.DamageType,
+		Ranged:      item.Weapon.Ranged,
+		Properties:  item.Weapon.Properties,
+	}
+	if item.Weapon.HasProperty(PropertyVersatile) && item.Weapon.VersatileDamage != "" && !item.Weapon.HasProperty(PropertyTwoHanded) {
+		attack.VersatileDamage = formatDamage(item.Weapon.VersatileDamage, modifier)
+	}
+	return attack
+}
+
+// GetAttacks returns an attack for every equipped weapon followed by an unarmed strike.
+func (c *Character) GetAttacks() []Attack {
+	output := []Attack{}
+	for _, item := range c.GetEquippedItems(ItemWeapon) {
+		output = append(output, c.GetWeaponAttack(item))
+	}
+
+	strength := c.GetAbilityScore(StatStrength)
+	output = append(output, Attack{
+		Name:        "Unarmed Strike",
+		Ability:     StatStrength,
+		Proficient:  true,
+		AttackBonus: strength + c.GetProficiencyBonus(),
+		Damage:      strconv.Itoa(max(unarmedStrikeDamage+strength, 0)),
+		DamageType:  "bludgeoning",
+	})
+	return output
+}
+
+// formatDamage appends the ability modifier to a damage expression, e.g. "1d8" and 3 becomes "1d8+3".
+func formatDamage(damage string, modifier int) string {
+	damage = strings.TrimSpace(damage)
+	if damage == "" {
+		damage = "0"
+	}
+	switch {
+	case modifier > 0:
+		return fmt.Sprintf("%s+%d", damage, modifier)
+	case modifier < 0:
+		return fmt.Sprintf("%s%d", damage, modifier)
+	default:
+		return damage
+	}
+}
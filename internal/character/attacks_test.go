@@ -0,0 +1,58 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"testing"
+)
+
+func TestGetWeaponAttack(t *testing.T) {
+	rapier := character.Item{Name: "Rapier", Type: character.ItemWeapon, Weapon: character.WeaponStats{
+		Category: character.WeaponMartial, Damage: "1d8", DamageType: "piercing",
+		Properties: []character.WeaponProperty{character.PropertyFinesse},
+	}}
+	longsword := character.Item{Name: "Longsword", Type: character.ItemWeapon, Weapon: character.WeaponStats{
+		Category: character.WeaponMartial, Damage: "1d8", VersatileDamage: "1d10", DamageType: "slashing",
+		Properties: []character.WeaponProperty{character.PropertyVersatile},
+	}}
+	longbow := character.Item{Name: "Longbow", Type: character.ItemWeapon, Weapon: character.WeaponStats{
+		Category: character.WeaponMartial, Damage: "1d8", DamageType: "piercing", Ranged: true,
+		Properties: []character.WeaponProperty{character.PropertyAmmunition, character.PropertyHeavy, character.PropertyTwoHanded},
+	}}
+	greataxe := character.Item{Name: "Greataxe", Type: character.ItemWeapon, Weapon: character.WeaponStats{
+		Category: character.WeaponMartial, Damage: "1d12", DamageType: "slashing",
+		Properties: []character.WeaponProperty{character.PropertyHeavy, character.PropertyTwoHanded},
+	}}
+
+	tests := []struct {
+		Name            string
+		Class           character.ClassName
+		Weapon          character.Item
+		AttackBonus     int
+		Damage          string
+		VersatileDamage string
+	}{
+		{"finesse uses dexterity", character.ClassFighter, rapier, 6, "1d8+4", ""},
+		{"rogue is proficient with rapiers", character.ClassRogue, rapier, 6, "1d8+4", ""},
+		{"wizard is not proficient with rapiers", character.ClassWizard, rapier, 4, "1d8+4", ""},
+		{"versatile uses strength", character.ClassFighter, longsword, 1, "1d8-1", "1d10-1"},
+		{"ranged uses dexterity", character.ClassRanger, longbow, 6, "1d8+4", ""},
+		{"two-handed", character.ClassBarbarian, greataxe, 1, "1d12-1", ""},
+	}
+
+	for _, test := range tests {
+		char := character.NewCharacter().SetClass(test.Class)
+		char.StatBlock = &character.StatBlock{Strength: 8, Dexterity: 18}
+		char.Race = character.Race{Type: character.RaceGnome, Subrace: character.SubraceNone}
+
+		attack := char.GetWeaponAttack(test.Weapon)
+		if attack.AttackBonus != test.AttackBonus {
+			t.Fatalf("%s got incorrect attack bonus: %d; want %d", test.Name, attack.AttackBonus, test.AttackBonus)
+		}
+		if attack.Damage != test.Damage {
+			t.Fatalf("%s got incorrect damage: %s; want %s", test.Name, attack.Damage, test.Damage)
+		}
+		if attack.VersatileDamage != test.VersatileDamage {
+			t.Fatalf("%s got incorrect versatile damage: %s; want %s", test.Name, attack.VersatileDamage, test.VersatileDamage)
+		}
+	}
+}
@@ -29,48 +29,42 @@ const (
 type Background struct {
 	Name          BackgroundName `yaml:"name"`
 	Proficiencies []SkillName    `yaml:"proficiencies"`
+	// AbilityChoices are the three ability scores a 2024 background raises by 1. One score can be
+	// picked twice.
+	AbilityChoices []StatName `yaml:"ability-choices,omitempty"`
+	// Ruleset is the version of the character the background belongs to, set by SetRuleset.
+	Ruleset RulesetVersion `yaml:"-"`
+	// OwnerId is the user who owns the character, set by SetOwner.
+	OwnerId int `yaml:"-"`
+}
+
+func (b *Background) getDefinition() (BackgroundDefinition, bool) {
+	return RulesForOwner(b.Ruleset, b.OwnerId).Background(b.Name)
+}
+
+// IsStandard reports whether the background is one of the rules backgrounds, which come with
+// their own skills. Any other name is a custom background that chooses its skills.
+func (b *Background) IsStandard() bool {
+	_, ok := b.getDefinition()
+	return ok
 }
 
 func (b *Background) GetProficiencies() []SkillName {
-	output := b.Name.getProficiencies()
-	if output == nil && b.Proficiencies != nil {
+	definition, ok := b.getDefinition()
+	if !ok && b.Proficiencies != nil {
 		return b.Proficiencies
 	}
-	if output == nil {
-		output = []SkillName{}
-	}
-	return output
+	return append([]SkillName{}, definition.Skills...)
 }
 
-func (b BackgroundName) getProficiencies() []SkillName {
-	switch b {
-	case BackgroundAcolyte:
-		return []SkillName{SkillInsight, SkillReligion}
-	case BackgroundCharlatan:
-		return []SkillName{SkillDeception, SkillSleightOfHand}
-	case BackgroundCriminal:
-		return []SkillName{SkillDeception, SkillStealth}
-	case BackgroundEntertainer:
-		return []SkillName{SkillAcrobatics, SkillPerformance}
-	case BackgroundFolkHero:
-		return []SkillName{SkillAnimalHandling, SkillSurvival}
-	case BackgroundGuildArtisan:
-		return []SkillName{SkillInsight, SkillPersuasion}
-	case BackgroundHermit:
-		return []SkillName{SkillMedicine, SkillReligion}
-	case BackgroundNoble:
-		return []SkillName{SkillHistory, SkillPersuasion}
-	case BackgroundOutlander:
-		return []SkillName{SkillAthletics, SkillSurvival}
-	case BackgroundSage:
-		return []SkillName{SkillArcana, SkillHistory}
-	case BackgroundSailor:
-		return []SkillName{SkillAthletics, SkillPerception}
-	case BackgroundSoldier:
-		return []SkillName{SkillAthletics, SkillIntimidation}
-	case BackgroundUrchin:
-		return []SkillName{SkillSleightOfHand, SkillStealth}
-	default:
-		return nil
-	}
+// GetAbilities returns the ability scores a 2024 background's increases can go to.
+func (b *Background) GetAbilities() []StatName {
+	definition, _ := b.getDefinition()
+	return append([]StatName{}, definition.Abilities...)
+}
+
+// GetFeat returns the origin feat a 2024 background grants, or an empty name.
+func (b *Background) GetFeat() FeatName {
+	definition, _ := b.getDefinition()
+	return definition.Feat
 }
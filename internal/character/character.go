@@ -1,26 +1,52 @@
 package character
 
 import (
+	"fmt"
 	"math"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Character struct {
-	*StatBlock          `yaml:"stats"`
-	Class               ClassName  `yaml:"class"`
-	Race                Race       `yaml:"race"`
-	Name                string     `yaml:"name"`
-	Level               int        `yaml:"level"`
-	Background          Background `yaml:"background"`
-	Bio                 string     `yaml:"bio"`
-	CurrentHealthPoints int        `yaml:"current_hit_points"`
+	*StatBlock               `yaml:"stats"`
+	Classes                  []ClassLevel              `yaml:"classes"`
+	Race                     Race                      `yaml:"race"`
+	Name                     string                    `yaml:"name"`
+	Level                    int                       `yaml:"level"`
+	Experience               int                       `yaml:"experience"`
+	HitPointHistory          []LevelHitPoints          `yaml:"hit-point-history"`
+	Background               Background                `yaml:"background"`
+	Bio                      string                    `yaml:"bio"`
+	Personality              Personality               `yaml:"personality"`
+	CurrentHealthPoints      int                       `yaml:"current_hit_points"`
+	TemporaryHitPoints       int                       `yaml:"temporary-hit-points"`
+	MaxHitPointsOverride     int                       `yaml:"max-hit-points-override"`
+	DeathSaveSuccesses       int                       `yaml:"death-save-successes"`
+	DeathSaveFailures        int                       `yaml:"death-save-failures"`
+	Conditions               []ActiveCondition         `yaml:"conditions"`
+	ExhaustionLevel          int                       `yaml:"exhaustion-level"`
+	AbilityScoreImprovements []AbilityScoreImprovement `yaml:"ability-score-improvements"`
+	Proficiencies            []Proficiency             `yaml:"proficiencies"`
+	Spells                   []KnownSpell              `yaml:"spells"`
+	ExpendedSpellSlots       [9]int                    `yaml:"expended-spell-slots"`
+	ExpendedPactSlots        int                       `yaml:"expended-pact-slots"`
+	ExpendedFeatureUses      map[string]int            `yaml:"expended-feature-uses"`
+	ExpendedHitDice          map[HitDie]int            `yaml:"expended-hit-dice"`
+	Inventory                []Item                    `yaml:"inventory"`
+	// VariantEncumbrance turns on the variant rule that slows characters carrying heavy loads.
+	VariantEncumbrance bool `yaml:"variant-encumbrance"`
+	// Ruleset is the version of the rules the character is built with. Characters without one use
+	// the 2014 rules.
+	Ruleset RulesetVersion `yaml:"ruleset"`
+	// OwnerId is the user the character belongs to, whose private homebrew it can use. It is set
+	// by SetOwner; characters without one only see the shared rules.
+	OwnerId int `yaml:"-"`
 }
 
 func NewCharacter() *Character {
 	return &Character{
 		StatBlock: &StatBlock{},
-		Class:     ClassBarbarian,
+		Classes:   []ClassLevel{{Class: ClassBarbarian, Level: 1}},
 		Race: Race{
 			Type:         RaceHuman,
 			Subrace:      SubraceNone,
@@ -33,8 +59,16 @@ func NewCharacter() *Character {
 			Name:          BackgroundAcolyte,
 			Proficiencies: []SkillName{},
 		},
-		Bio:                 "",
-		CurrentHealthPoints: 0,
+		Bio:                      "",
+		CurrentHealthPoints:      0,
+		HitPointHistory:          []LevelHitPoints{},
+		Conditions:               []ActiveCondition{},
+		AbilityScoreImprovements: []AbilityScoreImprovement{},
+		Proficiencies:            []Proficiency{},
+		Spells:                   []KnownSpell{},
+		ExpendedFeatureUses:      map[string]int{},
+		ExpendedHitDice:          map[HitDie]int{},
+		Inventory:                []Item{},
 	}
 }
 
@@ -43,19 +77,50 @@ func CharacterFromYaml(data []byte) (*Character, error) {
 	if err := yaml.Unmarshal(data, &character); err != nil {
 		return nil, err
 	}
+	character.SetRuleset(character.Ruleset)
+	if err := character.validateContent(); err != nil {
+		return nil, err
+	}
 	if character.CurrentHealthPoints == 0 {
 		character.CurrentHealthPoints = character.GetMaxHealthPoints()
 	}
 	return &character, nil
 }
 
+// validateContent checks the ruleset, race, classes and background skills of a loaded character
+// against the rules registry.
+func (c *Character) validateContent() error {
+	if c.Ruleset != "" && !c.Ruleset.IsValid() {
+		return fmt.Errorf("%w: %s", ErrUndefinedRuleset, c.Ruleset)
+	}
+	if c.Race.Type != "" && !c.Race.IsValid() {
+		return fmt.Errorf("%w: %s", ErrUndefinedRace, c.Race.Type)
+	}
+	for _, classLevel := range c.Classes {
+		if !c.IsDefinedClass(classLevel.Class) {
+			return fmt.Errorf("%w: %s", ErrUndefinedClass, classLevel.Class)
+		}
+	}
+	for _, skill := range c.Background.Proficiencies {
+		if !c.IsDefinedSkill(skill) {
+			return fmt.Errorf("%w: %s", ErrUndefinedSkill, skill)
+		}
+	}
+	return nil
+}
+
+// SetLevel sets the total level. A single-class character's class level follows it.
 func (c *Character) SetLevel(level int) *Character {
 	c.Level = level
+	if len(c.Classes) == 1 {
+		c.Classes[0].Level = level
+	}
 	return c
 }
 
+// SetClass replaces the class list with a single class at the character's level.
 func (c *Character) SetClass(class ClassName) *Character {
-	c.Class = class
+	c.Classes = []ClassLevel{{Class: class, Level: c.Level}}
 	return c
 }
 
@@ -68,61 +133,76 @@ func (c *Character) GetProficiencyBonus() int {
 	return int(math.Floor(float64(c.Level-1)/float64(4))) + 2
 }
 
-func (c *Character) GetSavingThrow(stat StatName) int {
-	savingThrow := c.StatBlock.GetAbilityScore(stat)
-	proficiencies := c.Class.GetSavingThrowsProficiencies()
-	for _, prof := range proficiencies {
-		if prof == stat {
-			savingThrow += c.GetProficiencyBonus()
-			break
+// GetAbilityBonuses returns every increase applied on top of the base stat block: the increases of
+// the character's origin, their race or background depending on the ruleset, followed by Ability
+// Score Improvements and feats.
+func (c *Character) GetAbilityBonuses() []AbilityBonus {
+	origin := c.GetRuleset().GetOriginAbilityBonuses(c)
+	return append(origin, c.getImprovementBonuses(origin)...)
+}
+
+// GetBaseScore returns the ability score as rolled or bought, before any bonuses.
+func (c *Character) GetBaseScore(stat StatName) int {
+	return c.StatBlock.GetScore(stat)
+}
+
+// GetEffectiveScore returns the base ability score with all bonuses applied.
+func (c *Character) GetEffectiveScore(stat StatName) int {
+	score := c.GetBaseScore(stat)
+	for _, bonus := range c.GetAbilityBonuses() {
+		if bonus.Stat == stat {
+			score += bonus.Amount
 		}
 	}
-	return savingThrow
+	return score
 }
 
-func (c *Character) GetSkill(skill SkillName) int {
-	var bonus int
-	switch skill {
-	case SkillAthletics:
-		bonus = c.StatBlock.GetAbilityScore(StatStrength)
-	case SkillAcrobatics, SkillSleightOfHand, SkillStealth:
-		bonus = c.StatBlock.GetAbilityScore(StatDexterity)
-	case SkillArcana, SkillHistory, SkillInvestigation, SkillNature, SkillReligion:
-		bonus = c.StatBlock.GetAbilityScore(StatIntelligence)
-	case SkillAnimalHandling, SkillInsight, SkillMedicine, SkillPerception, SkillSurvival:
-		bonus = c.StatBlock.GetAbilityScore(StatWisdom)
-	case SkillDeception, SkillIntimidation, SkillPerformance, SkillPersuasion:
-		bonus = c.StatBlock.GetAbilityScore(StatCharisma)
-	default:
-		return 0
+// GetEffectiveStatBlock returns a copy of the stat block with all bonuses applied.
+func (c *Character) GetEffectiveStatBlock() *StatBlock {
+	output := &StatBlock{}
+	for _, stat := range StatNames {
+		output.SetStat(stat, c.GetEffectiveScore(stat))
 	}
+	return output
+}
 
-	for _, proficiency := range c.Background.Proficiencies {
-		if proficiency == skill {
-			bonus += c.GetProficiencyBonus()
-			break
-		}
+// GetAbilityScore returns the ability modifier calculated from the effective score.
+func (c *Character) GetAbilityScore(stat StatName) int {
+	if !stat.IsValid() || stat == StatYourChoice {
+		return 0
 	}
-
-	return bonus
+	return GetModifier(c.GetEffectiveScore(stat))
 }
 
-func (c *Character) GetMaxHealthPoints() int {
-	hitDie := c.Class.GetHitDie()
-	constitution := c.GetAbilityScore(StatConstitution)
-	return int(hitDie)*c.Level + constitution
+func (c *Character) GetSavingThrow(stat StatName) int {
+	savingThrow := c.GetAbilityScore(stat)
+	if c.HasSavingThrowProficiency(stat) {
+		savingThrow += c.GetProficiencyBonus()
+	}
+	return savingThrow
 }
 
-func (c *Character) GetArmorClass() int {
-	dex := c.GetAbilityScore(StatDexterity)
-	return 10 + dex
+func (c *Character) GetSkill(skill SkillName) int {
+	ability := c.GetSkillAbility(skill)
+	if ability == "" {
+		return 0
+	}
+	return c.GetAbilityScore(ability) + c.GetSkillProficiency(skill).GetBonus(c.GetProficiencyBonus())
 }
 
 func (c *Character) GetInitiative() int {
 	dex := c.GetAbilityScore(StatDexterity)
-	return dex
+	return dex + c.getFeatInitiative()
 }
 
+// GetMoveSpeed returns the race's speed after feats, encumbrance, conditions and exhaustion are applied.
 func (c *Character) GetMoveSpeed() int {
-	return c.Race.GetMoveSpeed()
+	if c.ExhaustionLevel >= 5 || c.hasAnyCondition(immobileConditions...) {
+		return 0
+	}
+	speed := max(c.Race.GetMoveSpeed()+c.getFeatSpeed()-c.getEncumbranceSpeedPenalty(), 0)
+	if c.ExhaustionLevel >= 2 {
+		speed /= 2
+	}
+	return speed
 }
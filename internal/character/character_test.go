@@ -0,0 +1,42 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"testing"
+)
+
+func TestEffectiveScores(t *testing.T) {
+	char := character.NewCharacter()
+	char.StatBlock = &character.StatBlock{
+		Strength:     15,
+		Dexterity:    14,
+		Constitution: 13,
+		Intelligence: 12,
+		Wisdom:       10,
+		Charisma:     8,
+	}
+	char.Race = character.Race{Type: character.RaceDwarf, Subrace: character.SubraceHillDwarf}
+	char.Level = 1
+
+	if score := char.GetBaseScore(character.StatConstitution); score != 13 {
+		t.Fatalf("got incorrect base constitution: %d; want 13", score)
+	}
+	if score := char.GetEffectiveScore(character.StatConstitution); score != 15 {
+		t.Fatalf("got incorrect effective constitution: %d; want 15", score)
+	}
+	if modifier := char.GetAbilityScore(character.StatWisdom); modifier != 0 {
+		t.Fatalf("got incorrect wisdom modifier: %d; want 0", modifier)
+	}
+	if modifier := char.StatBlock.GetAbilityScore(character.StatConstitution); modifier != 1 {
+		t.Fatalf("got incorrect base constitution modifier: %d; want 1", modifier)
+	}
+	if save := char.GetSavingThrow(character.StatConstitution); save != 4 {
+		t.Fatalf("got incorrect constitution saving throw: %d; want 4", save)
+	}
+	if skill := char.GetSkill(character.SkillPerception); skill != 0 {
+		t.Fatalf("got incorrect perception: %d; want 0", skill)
+	}
+	if hp := char.GetMaxHealthPoints(); hp != 14 {
+		t.Fatalf("got incorrect max health points: %d; want 14", hp)
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-
-	"gopkg.in/yaml.v3"
 )
 
 type HitDie int
@@ -47,15 +45,17 @@ func NewClassName(name string) (ClassName, error) {
 	return class, nil
 }
 
+// IsValid reports whether the 2014 rules define the class. A character's classes are checked
+// against its own ruleset with Character.IsDefinedClass.
 func (c ClassName) IsValid() bool {
-	switch c {
-	case ClassBarbarian, ClassBard, ClassCleric, ClassDruid, ClassFighter,
-		ClassMonk, ClassPaladin, ClassRanger, ClassRogue, ClassSorcerer,
-		ClassWarlock, ClassWizard, ClassCommoner:
-		return true
-	default:
-		return false
-	}
+	_, ok := Rules().Class(c)
+	return ok
+}
+
+// IsDefinedClass reports whether the character's ruleset defines the class.
+func (c *Character) IsDefinedClass(class ClassName) bool {
+	_, ok := c.rules().Class(class)
+	return ok
 }
 
 func (c *ClassName) UnmarshalJSON(data []byte) error {
@@ -71,61 +71,63 @@ func (c *ClassName) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (c *ClassName) UnmarshalYAML(value *yaml.Node) error {
-	var classString string
-	if err := value.Decode(&classString); err != nil {
-		return fmt.Errorf("failed to unmarshal class name: %w", err)
-	}
-	class := ClassName(classString)
-	if !class.IsValid() {
-		return fmt.Errorf("invalid class name: %s", classString)
-	}
-	*c = class
-	return nil
+func (d ClassDefinition) GetSavingThrowsProficiencies() []StatName {
+	return append([]StatName{}, d.SavingThrows...)
 }
 
-func (c ClassName) GetSavingThrowsProficiencies() []StatName {
-	switch c {
-	case ClassBarbarian:
-		return []StatName{StatStrength, StatConstitution}
-	case ClassBard:
-		return []StatName{StatDexterity, StatCharisma}
-	case ClassCleric:
-		return []StatName{StatWisdom, StatCharisma}
-	case ClassDruid:
-		return []StatName{StatIntelligence, StatWisdom}
-	case ClassFighter:
-		return []StatName{StatStrength, StatConstitution}
-	case ClassMonk:
-		return []StatName{StatStrength, StatDexterity}
-	case ClassPaladin:
-		return []StatName{StatWisdom, StatCharisma}
-	case ClassRanger:
-		return []StatName{StatStrength, StatDexterity}
-	case ClassRogue:
-		return []StatName{StatDexterity, StatIntelligence}
-	case ClassSorcerer:
-		return []StatName{StatConstitution, StatCharisma}
-	case ClassWarlock:
-		return []StatName{StatWisdom, StatCharisma}
-	case ClassWizard:
-		return []StatName{StatIntelligence, StatWisdom}
-	default:
-		return []StatName{}
-	}
+// SkillChoices is how many skills a class picks at 1st level and the skills it picks from.
+type SkillChoices struct {
+	Count   int         `yaml:"count"`
+	Options []SkillName `yaml:"options"`
+}
+
+// ClassProficiencies are the armor, weapons and tools a class grants without a choice.
+type ClassProficiencies struct {
+	Armor            []string         `yaml:"armor,omitempty"`
+	WeaponCategories []WeaponCategory `yaml:"weapon-categories,omitempty"`
+	Weapons          []string         `yaml:"weapons,omitempty"`
+	Tools            []string         `yaml:"tools,omitempty"`
+}
+
+// ClassDefinition is the rules entry for a class: its hit die, proficiencies, spellcasting, features
+// and the subclasses it can take.
+type ClassDefinition struct {
+	Name   ClassName `yaml:"name"`
+	HitDie HitDie    `yaml:"hit-die"`
+	// NonPlayer marks classes like the Commoner that can't be multiclassed into.
+	NonPlayer     bool               `yaml:"non-player,omitempty"`
+	SavingThrows  []StatName         `yaml:"saving-throws,omitempty"`
+	SkillChoices  SkillChoices       `yaml:"skill-choices,omitempty"`
+	Proficiencies ClassProficiencies `yaml:"proficiencies,omitempty"`
+	// MulticlassProficiencies replace Proficiencies when the class isn't the starting class.
+	MulticlassProficiencies ClassProficiencies     `yaml:"multiclass-proficiencies,omitempty"`
+	MulticlassPrerequisite  MulticlassPrerequisite `yaml:"multiclass-prerequisite,omitempty"`
+	CasterType              CasterType             `yaml:"caster-type,omitempty"`
+	SpellcastingAbility     StatName               `yaml:"spellcasting-ability,omitempty"`
+	PreparesSpells          bool                   `yaml:"prepares-spells,omitempty"`
+	// AbilityScoreImprovements are the class levels that grant an ability score improvement.
+	AbilityScoreImprovements []int `yaml:"ability-score-improvements,omitempty"`
+	// Expertise is how many expertise choices the class has, keyed by the class level it applies from.
+	Expertise map[int]int `yaml:"expertise,omitempty"`
+	// SubclassLevel is the class level the subclass is chosen at.
+	SubclassLevel int `yaml:"subclass-level,omitempty"`
+	// SubclassTitle is what the class calls its subclasses, e.g. "Primal Path".
+	SubclassTitle string `yaml:"subclass-title,omitempty"`
+	// SubclassFeatureLevels are the class levels after SubclassLevel that every subclass grants a feature at.
+	SubclassFeatureLevels []int          `yaml:"subclass-feature-levels,omitempty"`
+	Features              []ClassFeature `yaml:"features,omitempty"`
+	Subclasses            []Subclass     `yaml:"subclasses,omitempty"`
+}
+
+func (d ClassDefinition) GetSkillChoices() SkillChoices {
+	choices := d.SkillChoices
+	return SkillChoices{choices.Count, append([]SkillName{}, choices.Options...)}
 }
 
-func (c ClassName) GetHitDie() HitDie {
-	switch c {
-	case ClassBarbarian:
-		return HitDieD12
-	case ClassFighter, ClassPaladin, ClassRanger:
-		return HitDieD10
-	case ClassBard, ClassCleric, ClassDruid, ClassMonk, ClassRogue, ClassWarlock:
-		return HitDieD8
-	case ClassSorcerer, ClassWizard, ClassCommoner:
-		return HitDieD6
-	default:
+func (d ClassDefinition) GetHitDie() HitDie {
+	hitDie := d.HitDie
+	if hitDie == 0 {
 		return HitDieD6 // Default to D6 for unknown classes
 	}
+	return hitDie
 }
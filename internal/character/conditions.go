@@ -0,0 +1,257 @@
+package character
+
+import (
+	"dndcc/internal/dice"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrUndefinedCondition       = errors.New("attempted to use undefined condition")
+	ErrConditionNotActive       = errors.New("the character does not have that condition")
+	ErrInvalidConditionDuration = errors.New("condition duration must not be negative")
+	ErrInvalidExhaustionLevel   = errors.New("exhaustion level is out of range")
+	ErrNotDying                 = errors.New("only a dying character makes death saving throws")
+	ErrInvalidDeathSaveRoll     = errors.New("death saving throw roll is out of range")
+)
+
+// MaxExhaustionLevel is the exhaustion level at which a character dies.
+const MaxExhaustionLevel = 6
+
+type ConditionName string
+
+const (
+	ConditionBlinded       ConditionName = "Blinded"
+	ConditionCharmed       ConditionName = "Charmed"
+	ConditionDeafened      ConditionName = "Deafened"
+	ConditionFrightened    ConditionName = "Frightened"
+	ConditionGrappled      ConditionName = "Grappled"
+	ConditionIncapacitated ConditionName = "Incapacitated"
+	ConditionInvisible     ConditionName = "Invisible"
+	ConditionParalyzed     ConditionName = "Paralyzed"
+	ConditionPetrified     ConditionName = "Petrified"
+	ConditionPoisoned      ConditionName = "Poisoned"
+	ConditionProne         ConditionName = "Prone"
+	ConditionRestrained    ConditionName = "Restrained"
+	ConditionStunned       ConditionName = "Stunned"
+	ConditionUnconscious   ConditionName = "Unconscious"
+)
+
+var ConditionNames = []ConditionName{
+	ConditionBlinded, ConditionCharmed, ConditionDeafened, ConditionFrightened, ConditionGrappled,
+	ConditionIncapacitated, ConditionInvisible, ConditionParalyzed, ConditionPetrified, ConditionPoisoned,
+	ConditionProne, ConditionRestrained, ConditionStunned, ConditionUnconscious,
+}
+
+func (c ConditionName) IsValid() bool {
+	return slices.Contains(ConditionNames, c)
+}
+
+var conditionDescriptions = map[ConditionName]string{
+	ConditionBlinded:       "Can't see and automatically fails checks that require sight. Attack rolls have disadvantage.",
+	ConditionCharmed:       "Can't attack the charmer, who has advantage on social checks against the character.",
+	ConditionDeafened:      "Can't hear and automatically fails checks that require hearing.",
+	ConditionFrightened:    "Disadvantage on ability checks and attack rolls while the source of fear is in sight.",
+	ConditionGrappled:      "Speed becomes 0.",
+	ConditionIncapacitated: "Can't take actions or reactions.",
+	ConditionInvisible:     "Impossible to see without special senses. Attack rolls have advantage.",
+	ConditionParalyzed:     "Incapacitated and can't move or speak. Automatically fails Strength and Dexterity saving throws.",
+	ConditionPetrified:     "Transformed into stone, incapacitated and can't move or speak.",
+	ConditionPoisoned:      "Disadvantage on attack rolls and ability checks.",
+	ConditionProne:         "Can only crawl. Attack rolls have disadvantage.",
+	ConditionRestrained:    "Speed becomes 0. Disadvantage on attack rolls and Dexterity saving throws.",
+	ConditionStunned:       "Incapacitated, can't move and can speak only falteringly.",
+	ConditionUnconscious:   "Incapacitated, can't move or speak and is unaware of its surroundings.",
+}
+
+func (c ConditionName) GetDescription() string {
+	return conditionDescriptions[c]
+}
+
+// impliedConditions are the conditions that include the effects of another condition.
+var impliedConditions = map[ConditionName][]ConditionName{
+	ConditionParalyzed:   {ConditionIncapacitated},
+	ConditionPetrified:   {ConditionIncapacitated},
+	ConditionStunned:     {ConditionIncapacitated},
+	ConditionUnconscious: {ConditionIncapacitated, ConditionProne},
+}
+
+// immobileConditions reduce the character's speed to 0.
+var immobileConditions = []ConditionName{
+	ConditionGrappled, ConditionParalyzed, ConditionPetrified, ConditionRestrained, ConditionStunned, ConditionUnconscious,
+}
+
+var exhaustionEffects = []string{
+	"Disadvantage on ability checks",
+	"Speed halved",
+	"Disadvantage on attack rolls and saving throws",
+	"Hit point maximum halved",
+	"Speed reduced to 0",
+	"Death",
+}
+
+// ActiveCondition is a condition affecting the character. Rounds is how many rounds it has left,
+// or 0 if it lasts until removed.
+type ActiveCondition struct {
+	Name   ConditionName `yaml:"name"`
+	Rounds int           `yaml:"rounds,omitempty"`
+}
+
+func (a ActiveCondition) GetDescription() string {
+	return a.Name.GetDescription()
+}
+
+// GetExhaustionEffects lists the effects of the character's exhaustion level. Each level includes
+// the effects of the levels below it.
+func (c *Character) GetExhaustionEffects() []string {
+	return exhaustionEffects[:min(max(c.ExhaustionLevel, 0), MaxExhaustionLevel)]
+}
+
+// SetExhaustionLevel sets the exhaustion level between 0 and 6. Current hit points are lowered to
+// fit the halved maximum from level 4.
+func (c *Character) SetExhaustionLevel(level int) error {
+	if level < 0 || level > MaxExhaustionLevel {
+		return fmt.Errorf("%w: %d", ErrInvalidExhaustionLevel, level)
+	}
+	c.ExhaustionLevel = level
+	c.CurrentHealthPoints = min(c.CurrentHealthPoints, c.GetMaxHealthPoints())
+	return nil
+}
+
+// HasCondition reports whether the character has the condition, either directly or as part of
+// another condition, such as the incapacitation that comes with being stunned.
+func (c *Character) HasCondition(name ConditionName) bool {
+	for _, condition := range c.Conditions {
+		if condition.Name == name || slices.Contains(impliedConditions[condition.Name], name) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAvailableConditions lists the conditions that can still be applied to the character.
+func (c *Character) GetAvailableConditions() []ConditionName {
+	available := []ConditionName{}
+	for _, name := range ConditionNames {
+		if !slices.ContainsFunc(c.Conditions, func(condition ActiveCondition) bool { return condition.Name == name }) {
+			available = append(available, name)
+		}
+	}
+	return available
+}
+
+func (c *Character) hasAnyCondition(names ...ConditionName) bool {
+	return slices.ContainsFunc(names, c.HasCondition)
+}
+
+// AddCondition applies the condition for a number of rounds, or until removed when rounds is 0.
+// Applying a condition the character already has replaces its duration.
+func (c *Character) AddCondition(name ConditionName, rounds int) error {
+	if !name.IsValid() {
+		return fmt.Errorf("%w: %s", ErrUndefinedCondition, name)
+	}
+	if rounds < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidConditionDuration, rounds)
+	}
+	index := slices.IndexFunc(c.Conditions, func(condition ActiveCondition) bool { return condition.Name == name })
+	if index != -1 {
+		c.Conditions[index].Rounds = rounds
+		return nil
+	}
+	c.Conditions = append(c.Conditions, ActiveCondition{Name: name, Rounds: rounds})
+	return nil
+}
+
+func (c *Character) RemoveCondition(name ConditionName) error {
+	index := slices.IndexFunc(c.Conditions, func(condition ActiveCondition) bool { return condition.Name == name })
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrConditionNotActive, name)
+	}
+	c.Conditions = slices.Delete(c.Conditions, index, index+1)
+	return nil
+}
+
+// EndRound counts down the conditions with a duration and removes the ones that run out,
+// returning their names.
+func (c *Character) EndRound() []ConditionName {
+	expired := []ConditionName{}
+	remaining := []ActiveCondition{}
+	for _, condition := range c.Conditions {
+		if condition.Rounds > 0 {
+			condition.Rounds--
+			if condition.Rounds == 0 {
+				expired = append(expired, condition.Name)
+				continue
+			}
+		}
+		remaining = append(remaining, condition)
+	}
+	c.Conditions = remaining
+	return expired
+}
+
+// GetAbilityCheckMode returns whether the character's ability checks, including skill checks, are
+// rolled with advantage or disadvantage.
+func (c *Character) GetAbilityCheckMode() dice.RollMode {
+	disadvantage := c.ExhaustionLevel >= 1 || c.hasAnyCondition(ConditionPoisoned, ConditionFrightened)
+	return getRollMode(false, disadvantage)
+}
+
+// GetAttackRollMode returns whether the character's attack rolls are made with advantage or disadvantage.
+func (c *Character) GetAttackRollMode() dice.RollMode {
+	advantage := c.HasCondition(ConditionInvisible)
+	disadvantage := c.ExhaustionLevel >= 3 || c.hasAnyCondition(
+		ConditionBlinded, ConditionFrightened, ConditionPoisoned, ConditionProne, ConditionRestrained,
+	)
+	return getRollMode(advantage, disadvantage)
+}
+
+// GetSavingThrowMode returns whether the character's saving throws for the stat are made with
+// advantage or disadvantage. Death saving throws use an empty stat.
+func (c *Character) GetSavingThrowMode(stat StatName) dice.RollMode {
+	disadvantage := c.ExhaustionLevel >= 3 || stat == StatDexterity && c.HasCondition(ConditionRestrained)
+	return getRollMode(false, disadvantage)
+}
+
+// getRollMode combines sources of advantage and disadvantage, which cancel each other out.
+func getRollMode(advantage, disadvantage bool) dice.RollMode {
+	switch {
+	case advantage && !disadvantage:
+		return dice.RollAdvantage
+	case disadvantage && !advantage:
+		return dice.RollDisadvantage
+	default:
+		return dice.RollNormal
+	}
+}
+
+// IsStable reports whether a character at 0 hit points has succeeded on three death saving throws.
+func (c *Character) IsStable() bool {
+	return c.CurrentHealthPoints == 0 && c.DeathSaveSuccesses >= MaxDeathSaves && !c.IsDead()
+}
+
+// RollDeathSave records the d20 result of a death saving throw. A 10 or higher is a success and
+// three successes make the character stable. A 1 counts as two failures and a 20 brings the
+// character back with 1 hit point.
+func (c *Character) RollDeathSave(roll int) error {
+	if !c.IsDying() {
+		return ErrNotDying
+	}
+	if roll < 1 || roll > 20 {
+		return fmt.Errorf("%w: %d", ErrInvalidDeathSaveRoll, roll)
+	}
+
+	switch {
+	case roll == 20:
+		c.CurrentHealthPoints = 1
+		c.resetDeathSaves()
+	case roll == 1:
+		c.DeathSaveFailures = min(c.DeathSaveFailures+2, MaxDeathSaves)
+	case roll >= 10:
+		c.DeathSaveSuccesses++
+	default:
+		c.DeathSaveFailures++
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/dice"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestConditionRollModes(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	if mode := char.GetAbilityCheckMode(); mode != dice.RollNormal {
+		t.Fatalf("got ability check mode %q without conditions; want normal", mode)
+	}
+
+	if err := char.AddCondition(character.ConditionPoisoned, 0); err != nil {
+		t.Fatalf("unexpected error adding condition: %v", err)
+	}
+	if mode := char.GetAbilityCheckMode(); mode != dice.RollDisadvantage {
+		t.Fatalf("got ability check mode %q while poisoned; want %q", mode, dice.RollDisadvantage)
+	}
+	if mode := char.GetAttackRollMode(); mode != dice.RollDisadvantage {
+		t.Fatalf("got attack roll mode %q while poisoned; want %q", mode, dice.RollDisadvantage)
+	}
+	if mode := char.GetSavingThrowMode(character.StatDexterity); mode != dice.RollNormal {
+		t.Fatalf("got saving throw mode %q while poisoned; want normal", mode)
+	}
+
+	if err := char.AddCondition(character.ConditionInvisible, 0); err != nil {
+		t.Fatalf("unexpected error adding condition: %v", err)
+	}
+	if mode := char.GetAttackRollMode(); mode != dice.RollNormal {
+		t.Fatalf("expected advantage and disadvantage to cancel out, got %q", mode)
+	}
+
+	if err := char.AddCondition(character.ConditionRestrained, 0); err != nil {
+		t.Fatalf("unexpected error adding condition: %v", err)
+	}
+	if mode := char.GetSavingThrowMode(character.StatDexterity); mode != dice.RollDisadvantage {
+		t.Fatalf("got Dexterity saving throw mode %q while restrained; want %q", mode, dice.RollDisadvantage)
+	}
+	if mode := char.GetSavingThrowMode(character.StatWisdom); mode != dice.RollNormal {
+		t.Fatalf("got Wisdom saving throw mode %q while restrained; want normal", mode)
+	}
+}
+
+func TestConditionSpeed(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	speed := char.GetMoveSpeed()
+
+	if err := char.AddCondition(character.ConditionUnconscious, 0); err != nil {
+		t.Fatalf("unexpected error adding condition: %v", err)
+	}
+	if char.GetMoveSpeed() != 0 {
+		t.Fatalf("got speed %d while unconscious; want 0", char.GetMoveSpeed())
+	}
+	if !char.HasCondition(character.ConditionIncapacitated) || !char.HasCondition(character.ConditionProne) {
+		t.Fatal("expected unconscious to include incapacitated and prone")
+	}
+	if err := char.RemoveCondition(character.ConditionUnconscious); err != nil {
+		t.Fatalf("unexpected error removing condition: %v", err)
+	}
+	if err := char.RemoveCondition(character.ConditionUnconscious); !errors.Is(err, character.ErrConditionNotActive) {
+		t.Fatalf("got %v; want %v", err, character.ErrConditionNotActive)
+	}
+	if char.GetMoveSpeed() != speed {
+		t.Fatalf("got speed %d after removing condition; want %d", char.GetMoveSpeed(), speed)
+	}
+}
+
+func TestConditionDuration(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	if err := char.AddCondition(character.ConditionFrightened, 2); err != nil {
+		t.Fatalf("unexpected error adding condition: %v", err)
+	}
+	if err := char.AddCondition(character.ConditionProne, 0); err != nil {
+		t.Fatalf("unexpected error adding condition: %v", err)
+	}
+	if err := char.AddCondition("Sleepy", 0); !errors.Is(err, character.ErrUndefinedCondition) {
+		t.Fatalf("got %v; want %v", err, character.ErrUndefinedCondition)
+	}
+	if err := char.AddCondition(character.ConditionBlinded, -1); !errors.Is(err, character.ErrInvalidConditionDuration) {
+		t.Fatalf("got %v; want %v", err, character.ErrInvalidConditionDuration)
+	}
+
+	if expired := char.EndRound(); len(expired) != 0 {
+		t.Fatalf("did not expect conditions to expire after one round: %v", expired)
+	}
+	if expired := char.EndRound(); !slices.Equal(expired, []character.ConditionName{character.ConditionFrightened}) {
+		t.Fatalf("expected frightened to expire after two rounds, got %v", expired)
+	}
+	if !char.HasCondition(character.ConditionProne) || char.HasCondition(character.ConditionFrightened) {
+		t.Fatalf("got incorrect conditions after rounds ended: %v", char.Conditions)
+	}
+}
+
+func TestExhaustion(t *testing.T) {
+	char := newHitPointCharacter()
+	speed := char.GetMoveSpeed()
+
+	tests := []struct {
+		level     int
+		speed     int
+		maxHP     int
+		checkMode dice.RollMode
+		saveMode  dice.RollMode
+	}{
+		{1, speed, 28, dice.RollDisadvantage, dice.RollNormal},
+		{2, speed / 2, 28, dice.RollDisadvantage, dice.RollNormal},
+		{3, speed / 2, 28, dice.RollDisadvantage, dice.RollDisadvantage},
+		{4, speed / 2, 14, dice.RollDisadvantage, dice.RollDisadvantage},
+		{5, 0, 14, dice.RollDisadvantage, dice.RollDisadvantage},
+	}
+	for _, test := range tests {
+		if err := char.SetExhaustionLevel(test.level); err != nil {
+			t.Fatalf("unexpected error setting exhaustion: %v", err)
+		}
+		if char.GetMoveSpeed() != test.speed || char.GetMaxHealthPoints() != test.maxHP {
+			t.Fatalf("got speed %d and %d max hp at exhaustion %d; want %d and %d",
+				char.GetMoveSpeed(), char.GetMaxHealthPoints(), test.level, test.speed, test.maxHP)
+		}
+		if char.GetAbilityCheckMode() != test.checkMode || char.GetSavingThrowMode(character.StatWisdom) != test.saveMode {
+			t.Fatalf("got incorrect roll modes at exhaustion %d", test.level)
+		}
+	}
+	if char.CurrentHealthPoints != 14 {
+		t.Fatalf("expected current hit points to be lowered to the halved maximum, got %d", char.CurrentHealthPoints)
+	}
+
+	if err := char.LongRest(); err != nil {
+		t.Fatalf("unexpected error taking a long rest: %v", err)
+	}
+	if char.ExhaustionLevel != 4 {
+		t.Fatalf("expected a long rest to remove one level of exhaustion, got %d", char.ExhaustionLevel)
+	}
+
+	if err := char.SetExhaustionLevel(7); !errors.Is(err, character.ErrInvalidExhaustionLevel) {
+		t.Fatalf("got %v; want %v", err, character.ErrInvalidExhaustionLevel)
+	}
+	if err := char.SetExhaustionLevel(character.MaxExhaustionLevel); err != nil {
+		t.Fatalf("unexpected error setting exhaustion: %v", err)
+	}
+	if !char.IsDead() {
+		t.Fatal("expected exhaustion level 6 to kill the character")
+	}
+}
+
+func TestRollDeathSave(t *testing.T) {
+	char := newHitPointCharacter()
+	if err := char.RollDeathSave(10); !errors.Is(err, character.ErrNotDying) {
+		t.Fatalf("got %v; want %v", err, character.ErrNotDying)
+	}
+
+	char.CurrentHealthPoints = 0
+	if err := char.RollDeathSave(21); !errors.Is(err, character.ErrInvalidDeathSaveRoll) {
+		t.Fatalf("got %v; want %v", err, character.ErrInvalidDeathSaveRoll)
+	}
+	for _, roll := range []int{10, 1, 15, 12} {
+		if err := char.RollDeathSave(roll); err != nil {
+			t.Fatalf("unexpected error rolling death save: %v", err)
+		}
+	}
+	if !char.IsStable() || char.IsDying() || char.DeathSaveFailures != 2 {
+		t.Fatalf("expected three successes to stabilise the character, got %d successes and %d failures",
+			char.DeathSaveSuccesses, char.DeathSaveFailures)
+	}
+	if err := char.RollDeathSave(10); !errors.Is(err, character.ErrNotDying) {
+		t.Fatalf("got %v; want %v", err, character.ErrNotDying)
+	}
+
+	if _, err := char.TakeDamage(1, false); err != nil {
+		t.Fatalf("unexpected error taking damage: %v", err)
+	}
+	if char.IsStable() || !char.IsDead() {
+		t.Fatalf("expected damage while stable to be a third failure, got %d failures", char.DeathSaveFailures)
+	}
+
+	char = newHitPointCharacter()
+	char.CurrentHealthPoints = 0
+	char.DeathSaveFailures = 2
+	if err := char.RollDeathSave(20); err != nil {
+		t.Fatalf("unexpected error rolling death save: %v", err)
+	}
+	if char.CurrentHealthPoints != 1 || char.DeathSaveFailures != 0 {
+		t.Fatalf("expected a natural 20 to restore 1 hit point, got %d hp and %d failures", char.CurrentHealthPoints, char.DeathSaveFailures)
+	}
+}
@@ -0,0 +1,93 @@
+package character
+
+type Encumbrance string
+
+const (
+	EncumbranceNone  Encumbrance = "Unencumbered"
+	EncumbranceLight Encumbrance = "Encumbered"
+	EncumbranceHeavy Encumbrance = "Heavily Encumbered"
+)
+
+const (
+	// carryingCapacityPerStrength is the weight in pounds a character can carry per point of Strength.
+	carryingCapacityPerStrength = 15
+	// encumberedPerStrength and heavilyEncumberedPerStrength are the variant encumbrance thresholds
+	// per point of Strength.
+	encumberedPerStrength         = 5
+	heavilyEncumberedPerStrength  = 10
+	encumberedSpeedPenalty        = 10
+	heavilyEncumberedSpeedPenalty = 20
+)
+
+// getCarryingMultiplier scales carrying capacity for creatures smaller or larger than Medium.
+func (s Size) getCarryingMultiplier() float64 {
+	switch s {
+	case SizeTiny:
+		return 0.5
+	case SizeLarge:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func (c *Character) getStrengthWeight(perStrength int) float64 {
+	return float64(c.GetEffectiveScore(StatStrength)*perStrength) * c.Race.GetSize().getCarryingMultiplier()
+}
+
+// GetCarryingCapacity returns the weight in pounds the character can carry.
+func (c *Character) GetCarryingCapacity() float64 {
+	return c.getStrengthWeight(carryingCapacityPerStrength)
+}
+
+// GetPushDragLiftCapacity returns the weight in pounds the character can push, drag or lift.
+func (c *Character) GetPushDragLiftCapacity() float64 {
+	return c.GetCarryingCapacity() * 2
+}
+
+// GetEncumbranceThresholds returns the carried weight above which the character is encumbered and
+// heavily encumbered under the variant rule.
+func (c *Character) GetEncumbranceThresholds() (float64, float64) {
+	return c.getStrengthWeight(encumberedPerStrength), c.getStrengthWeight(heavilyEncumberedPerStrength)
+}
+
+// GetCarriedWeight returns the total weight of the character's inventory.
+func (c *Character) GetCarriedWeight() float64 {
+	total := 0.0
+	for _, item := range c.Inventory {
+		total += item.Weight * float64(max(item.Quantity, 1))
+	}
+	return total
+}
+
+func (c *Character) IsOverCarryingCapacity() bool {
+	return c.GetCarriedWeight() > c.GetCarryingCapacity()
+}
+
+// GetEncumbrance returns how encumbered the character is under the variant rule, or
+// EncumbranceNone when the character doesn't use it.
+func (c *Character) GetEncumbrance() Encumbrance {
+	if !c.VariantEncumbrance {
+		return EncumbranceNone
+	}
+	encumbered, heavilyEncumbered := c.GetEncumbranceThresholds()
+	switch weight := c.GetCarriedWeight(); {
+	case weight > heavilyEncumbered:
+		return EncumbranceHeavy
+	case weight > encumbered:
+		return EncumbranceLight
+	default:
+		return EncumbranceNone
+	}
+}
+
+func (c *Character) getEncumbranceSpeedPenalty() int {
+	switch c.GetEncumbrance() {
+	case EncumbranceHeavy:
+		return heavilyEncumberedSpeedPenalty
+	case EncumbranceLight:
+		return encumberedSpeedPenalty
+	default:
+		return 0
+	}
+}
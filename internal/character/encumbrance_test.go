@@ -0,0 +1,56 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"testing"
+)
+
+func TestCarryingCapacity(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+
+	// Strength 14 + 2 from the Half-Orc race.
+	if char.GetCarryingCapacity() != 240 || char.GetPushDragLiftCapacity() != 480 {
+		t.Fatalf("got carrying capacity %v and push capacity %v; want 240 and 480", char.GetCarryingCapacity(), char.GetPushDragLiftCapacity())
+	}
+	if encumbered, heavilyEncumbered := char.GetEncumbranceThresholds(); encumbered != 80 || heavilyEncumbered != 160 {
+		t.Fatalf("got encumbrance thresholds %v and %v; want 80 and 160", encumbered, heavilyEncumbered)
+	}
+
+	char.Race = character.Race{Type: character.RaceGnome, Subrace: character.SubraceNone}
+	if char.GetCarryingCapacity() != 210 {
+		t.Fatalf("got carrying capacity %v for a small gnome; want 210", char.GetCarryingCapacity())
+	}
+}
+
+func TestVariantEncumbrance(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	speed := char.GetMoveSpeed()
+	char.Inventory = []character.Item{{Name: "Crate", Type: character.ItemGear, Quantity: 2, Weight: 50}}
+
+	tests := []struct {
+		variant     bool
+		weight      float64
+		encumbrance character.Encumbrance
+		speed       int
+	}{
+		{false, 50, character.EncumbranceNone, speed},
+		{true, 30, character.EncumbranceNone, speed},
+		{true, 50, character.EncumbranceLight, speed - 10},
+		{true, 85, character.EncumbranceHeavy, speed - 20},
+	}
+	for _, test := range tests {
+		char.VariantEncumbrance = test.variant
+		char.Inventory[0].Weight = test.weight
+		if encumbrance := char.GetEncumbrance(); encumbrance != test.encumbrance {
+			t.Fatalf("got %s carrying %v; want %s", encumbrance, char.GetCarriedWeight(), test.encumbrance)
+		}
+		if char.GetMoveSpeed() != test.speed {
+			t.Fatalf("got speed %d carrying %v; want %d", char.GetMoveSpeed(), char.GetCarriedWeight(), test.speed)
+		}
+	}
+
+	char.Inventory[0].Weight = 125
+	if !char.IsOverCarryingCapacity() {
+		t.Fatalf("expected carrying %v to be over capacity", char.GetCarriedWeight())
+	}
+}
@@ -0,0 +1,211 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrUndefinedItemType       = errors.New("attempted to use undefined item type")
+	ErrUndefinedArmorCategory  = errors.New("attempted to use undefined armor category")
+	ErrUndefinedWeaponCategory = errors.New("attempted to use undefined weapon category")
+	ErrUndefinedWeaponProperty = errors.New("attempted to use undefined weapon property")
+)
+
+type ItemType string
+
+const (
+	ItemGear   ItemType = "Gear"
+	ItemWeapon ItemType = "Weapon"
+	ItemArmor  ItemType = "Armor"
+	ItemShield ItemType = "Shield"
+)
+
+var ItemTypes = []ItemType{ItemGear, ItemWeapon, ItemArmor, ItemShield}
+
+func (i ItemType) IsValid() bool {
+	return slices.Contains(ItemTypes, i)
+}
+
+type ArmorCategory string
+
+const (
+	ArmorLight  ArmorCategory = "Light"
+	ArmorMedium ArmorCategory = "Medium"
+	ArmorHeavy  ArmorCategory = "Heavy"
+)
+
+var ArmorCategories = []ArmorCategory{ArmorLight, ArmorMedium, ArmorHeavy}
+
+func (a ArmorCategory) IsValid() bool {
+	return slices.Contains(ArmorCategories, a)
+}
+
+type WeaponCategory string
+
+const (
+	WeaponSimple  WeaponCategory = "Simple"
+	WeaponMartial WeaponCategory = "Martial"
+)
+
+var WeaponCategories = []WeaponCategory{WeaponSimple, WeaponMartial}
+
+func (w WeaponCategory) IsValid() bool {
+	return slices.Contains(WeaponCategories, w)
+}
+
+type WeaponProperty string
+
+const (
+	PropertyAmmunition WeaponProperty = "Ammunition"
+	PropertyFinesse    WeaponProperty = "Finesse"
+	PropertyHeavy      WeaponProperty = "Heavy"
+	PropertyLight      WeaponProperty = "Light"
+	PropertyLoading    WeaponProperty = "Loading"
+	PropertyReach      WeaponProperty = "Reach"
+	PropertyThrown     WeaponProperty = "Thrown"
+	PropertyTwoHanded  WeaponProperty = "Two-Handed"
+	PropertyVersatile  WeaponProperty = "Versatile"
+)
+
+var WeaponProperties = []WeaponProperty{
+	PropertyAmmunition,
+	PropertyFinesse,
+	PropertyHeavy,
+	PropertyLight,
+	PropertyLoading,
+	PropertyReach,
+	PropertyThrown,
+	PropertyTwoHanded,
+	PropertyVersatile,
+}
+
+func (w WeaponProperty) IsValid() bool {
+	return slices.Contains(WeaponProperties, w)
+}
+
+// mediumArmorDexterityCap is the most Dexterity modifier medium armor allows.
+const mediumArmorDexterityCap = 2
+
+// defaultShieldBonus is the Armor Class a shield adds when the item does not specify one.
+const defaultShieldBonus = 2
+
+type ArmorStats struct {
+	Category            ArmorCategory `yaml:"category"`
+	ArmorClass          int           `yaml:"armor-class"` // Base AC for armor, bonus for shields
+	StrengthRequirement int           `yaml:"strength-requirement"`
+	StealthDisadvantage bool          `yaml:"stealth-disadvantage"`
+}
+
+type WeaponStats struct {
+	Category        WeaponCategory   `yaml:"category"`
+	Damage          string           `yaml:"damage"`
+	DamageType      string           `yaml:"damage-type"`
+	VersatileDamage string           `yaml:"versatile-damage"`
+	Ranged          bool             `yaml:"ranged"`
+	Properties      []WeaponProperty `yaml:"properties"`
+}
+
+func (w *WeaponStats) HasProperty(property WeaponProperty) bool {
+	return slices.Contains(w.Properties, property)
+}
+
+type Item struct {
+	ID       int         `yaml:"-"`
+	Name     string      `yaml:"name"`
+	Type     ItemType    `yaml:"type"`
+	Quantity int         `yaml:"quantity"`
+	Weight   float64     `yaml:"weight"`
+	Equipped bool        `yaml:"equipped"`
+	Armor    ArmorStats  `yaml:"armor"`
+	Weapon   WeaponStats `yaml:"weapon"`
+}
+
+func (i *Item) Validate() error {
+	if !i.Type.IsValid() {
+		return fmt.Errorf("%w: %s", ErrUndefinedItemType, i.Type)
+	}
+	switch i.Type {
+	case ItemArmor:
+		if !i.Armor.Category.IsValid() {
+			return fmt.Errorf("%w: %s", ErrUndefinedArmorCategory, i.Armor.Category)
+		}
+	case ItemWeapon:
+		if !i.Weapon.Category.IsValid() {
+			return fmt.Errorf("%w: %s", ErrUndefinedWeaponCategory, i.Weapon.Category)
+		}
+		for _, property := range i.Weapon.Properties {
+			if !property.IsValid() {
+				return fmt.Errorf("%w: %s", ErrUndefinedWeaponProperty, property)
+			}
+		}
+	}
+	return nil
+}
+
+// getArmorClass returns the AC the armor grants to a character with the given Dexterity modifier.
+func (i *Item) getArmorClass(dexterity int) int {
+	switch i.Armor.Category {
+	case ArmorLight:
+		return i.Armor.ArmorClass + dexterity
+	case ArmorMedium:
+		return i.Armor.ArmorClass + min(dexterity, mediumArmorDexterityCap)
+	default:
+		return i.Armor.ArmorClass
+	}
+}
+
+func (i *Item) getShieldBonus() int {
+	if i.Armor.ArmorClass == 0 {
+		return defaultShieldBonus
+	}
+	return i.Armor.ArmorClass
+}
+
+// GetEquippedItems returns all equipped items of the given type.
+func (c *Character) GetEquippedItems(itemType ItemType) []Item {
+	output := []Item{}
+	for _, item := range c.Inventory {
+		if item.Equipped && item.Type == itemType {
+			output = append(output, item)
+		}
+	}
+	return output
+}
+
+// GetArmorClass follows the armor rules: worn armor sets the base AC, otherwise
+// Barbarian and Monk unarmored defense apply, and an equipped shield adds its bonus.
+func (c *Character) GetArmorClass() int {
+	dex := c.GetAbilityScore(StatDexterity)
+	shields := c.GetEquippedItems(ItemShield)
+
+	armorClass := 10 + dex
+	if armor := c.GetEquippedItems(ItemArmor); len(armor) > 0 {
+		armorClass = armor[0].getArmorClass(dex)
+		for _, item := range armor[1:] {
+			armorClass = max(armorClass, item.getArmorClass(dex))
+		}
+	} else if c.HasClass(ClassBarbarian) {
+		armorClass += c.GetAbilityScore(StatConstitution)
+	} else if c.HasClass(ClassMonk) && len(shields) == 0 {
+		armorClass += c.GetAbilityScore(StatWisdom)
+	}
+
+	shieldBonus := 0
+	for _, shield := range shields {
+		shieldBonus = max(shieldBonus, shield.getShieldBonus())
+	}
+
+	return armorClass + shieldBonus
+}
+
+// HasStealthDisadvantage reports whether equipped armor imposes disadvantage on Stealth checks.
+func (c *Character) HasStealthDisadvantage() bool {
+	for _, armor := range c.GetEquippedItems(ItemArmor) {
+		if armor.Armor.StealthDisadvantage {
+			return true
+		}
+	}
+	return false
+}
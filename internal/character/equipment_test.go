@@ -0,0 +1,43 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"testing"
+)
+
+func TestGetArmorClass(t *testing.T) {
+	leather := character.Item{Name: "Leather", Type: character.ItemArmor, Equipped: true, Armor: character.ArmorStats{Category: character.ArmorLight, ArmorClass: 11}}
+	halfPlate := character.Item{Name: "Half Plate", Type: character.ItemArmor, Equipped: true, Armor: character.ArmorStats{Category: character.ArmorMedium, ArmorClass: 15}}
+	plate := character.Item{Name: "Plate", Type: character.ItemArmor, Equipped: true, Armor: character.ArmorStats{Category: character.ArmorHeavy, ArmorClass: 18}}
+	shield := character.Item{Name: "Shield", Type: character.ItemShield, Equipped: true}
+	unequipped := character.Item{Name: "Plate", Type: character.ItemArmor, Equipped: false, Armor: character.ArmorStats{Category: character.ArmorHeavy, ArmorClass: 18}}
+
+	tests := []struct {
+		Name      string
+		Class     character.ClassName
+		Inventory []character.Item
+		Expected  int
+	}{
+		{"unarmored", character.ClassFighter, nil, 14},
+		{"unequipped armor", character.ClassFighter, []character.Item{unequipped}, 14},
+		{"light armor", character.ClassFighter, []character.Item{leather}, 15},
+		{"medium armor caps dexterity", character.ClassFighter, []character.Item{halfPlate}, 17},
+		{"heavy armor ignores dexterity", character.ClassFighter, []character.Item{plate}, 18},
+		{"shield", character.ClassFighter, []character.Item{plate, shield}, 20},
+		{"barbarian unarmored defense", character.ClassBarbarian, []character.Item{shield}, 18},
+		{"barbarian in armor", character.ClassBarbarian, []character.Item{leather}, 15},
+		{"monk unarmored defense", character.ClassMonk, nil, 15},
+		{"monk loses unarmored defense with a shield", character.ClassMonk, []character.Item{shield}, 16},
+	}
+
+	for _, test := range tests {
+		char := character.NewCharacter().SetClass(test.Class)
+		char.StatBlock = &character.StatBlock{Dexterity: 18, Constitution: 14, Wisdom: 12}
+		char.Race = character.Race{Type: character.RaceHalfOrc, Subrace: character.SubraceNone}
+		char.Inventory = test.Inventory
+
+		if ac := char.GetArmorClass(); ac != test.Expected {
+			t.Fatalf("%s got incorrect armor class: %d; want %d", test.Name, ac, test.Expected)
+		}
+	}
+}
@@ -0,0 +1,472 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUndefinedFeat                    = errors.New("attempted to use undefined feat")
+	ErrFeatTaken                        = errors.New("the character already has that feat")
+	ErrFeatPrerequisite                 = errors.New("feat prerequisites are not met")
+	ErrNoAbilityScoreImprovement        = errors.New("the class does not grant an ability score improvement at that level")
+	ErrAbilityScoreImprovementChosen    = errors.New("an ability score improvement was already chosen for that level")
+	ErrAbilityScoreImprovementNotChosen = errors.New("no ability score improvement was chosen for that level")
+	ErrInvalidAbilityScoreIncrease      = errors.New("an ability score increase must raise two scores by 1 or one score by 2")
+	ErrAbilityScoreCap                  = errors.New("ability score improvements can't raise a score above 20")
+)
+
+// AbilityScoreCap is the highest an ability score improvement or feat can raise a score.
+const AbilityScoreCap = 20
+
+// abilityScoreIncreaseStats is the number of +1 increases an ability score improvement grants.
+const abilityScoreIncreaseStats = 2
+
+type FeatName string
+
+const (
+	FeatActor             FeatName = "Actor"
+	FeatAlert             FeatName = "Alert"
+	FeatAthlete           FeatName = "Athlete"
+	FeatDefensiveDuelist  FeatName = "Defensive Duelist"
+	FeatDurable           FeatName = "Durable"
+	FeatElementalAdept    FeatName = "Elemental Adept"
+	FeatGrappler          FeatName = "Grappler"
+	FeatGreatWeaponMaster FeatName = "Great Weapon Master"
+	FeatInspiringLeader   FeatName = "Inspiring Leader"
+	FeatKeenMind          FeatName = "Keen Mind"
+	FeatLightlyArmored    FeatName = "Lightly Armored"
+	FeatMobile            FeatName = "Mobile"
+	FeatObservant         FeatName = "Observant"
+	FeatResilient         FeatName = "Resilient"
+	FeatRitualCaster      FeatName = "Ritual Caster"
+	FeatSentinel          FeatName = "Sentinel"
+	FeatSharpshooter      FeatName = "Sharpshooter"
+	FeatTough             FeatName = "Tough"
+	FeatWarCaster         FeatName = "War Caster"
+
+	FeatMagicInitiate  FeatName = "Magic Initiate"
+	FeatSavageAttacker FeatName = "Savage Attacker"
+	FeatSkilled        FeatName = "Skilled"
+)
+
+// FeatPrerequisite is what a character needs before taking a feat. When AnyOf is set only one of
+// the stats has to meet the minimum.
+type FeatPrerequisite struct {
+	Stats        []StatName
+	Minimum      int
+	AnyOf        bool
+	Spellcasting bool
+}
+
+func (p FeatPrerequisite) String() string {
+	parts := make([]string, len(p.Stats))
+	for i, stat := range p.Stats {
+		parts[i] = fmt.Sprintf("%s %d", stat, p.Minimum)
+	}
+	output := strings.Join(parts, " and ")
+	if p.AnyOf {
+		output = strings.Join(parts, " or ")
+	}
+	if p.Spellcasting {
+		output = strings.TrimPrefix(output+", the ability to cast at least one spell", ", ")
+	}
+	return output
+}
+
+// Feat is a catalog entry for a feat. A feat with IncreaseOptions raises one of those scores by 1,
+// chosen when it is taken, and Resilient feats also grant proficiency in that score's saving throws.
+// ArmorProficiencies lists the armor the feat trains the character with, and PassiveBonus is added
+// to the passive score of each skill.
+type Feat struct {
+	Name                   FeatName
+	Description            string
+	Prerequisite           FeatPrerequisite
+	IncreaseOptions        []StatName
+	SavingThrowProficiency bool
+	Initiative             int
+	Speed                  int
+	HitPointsPerLevel      int
+	ArmorProficiencies     []string
+	PassiveBonus           map[SkillName]int
+}
+
+var FeatNames = []FeatName{
+	FeatActor, FeatAlert, FeatAthlete, FeatDefensiveDuelist, FeatDurable, FeatElementalAdept, FeatGrappler,
+	FeatGreatWeaponMaster, FeatInspiringLeader, FeatKeenMind, FeatLightlyArmored, FeatMobile, FeatObservant,
+	FeatResilient, FeatRitualCaster, FeatSentinel, FeatSharpshooter, FeatTough, FeatWarCaster,
+}
+
+// OriginFeatNames are the 2024 origin feats a background grants. Alert is also a 2014 feat; the rest
+// can only be taken by 2024 characters.
+var OriginFeatNames = []FeatName{FeatAlert, FeatMagicInitiate, FeatSavageAttacker, FeatSkilled}
+
+var feats = map[FeatName]Feat{
+	FeatActor: {
+		Description:     "Advantage on Deception and Performance checks when passing yourself off as someone else, and you can mimic speech.",
+		IncreaseOptions: []StatName{StatCharisma},
+	},
+	FeatAlert: {
+		Description: "+5 to initiative, you can't be surprised while conscious and hidden attackers don't gain advantage against you.",
+		Initiative:  5,
+	},
+	FeatAthlete: {
+		Description:     "Standing up from prone and climbing cost less movement, and running jumps need only a 5 foot run-up.",
+		IncreaseOptions: []StatName{StatStrength, StatDexterity},
+	},
+	FeatDefensiveDuelist: {
+		Description:  "When wielding a finesse weapon, use your reaction to add your proficiency bonus to your AC against a melee attack.",
+		Prerequisite: FeatPrerequisite{Stats: []StatName{StatDexterity}, Minimum: 13},
+	},
+	FeatDurable: {
+		Description:     "When you roll a hit die to regain hit points, you regain at least twice your Constitution modifier.",
+		IncreaseOptions: []StatName{StatConstitution},
+	},
+	FeatElementalAdept: {
+		Description:  "Spells you cast ignore resistance to a chosen damage type, and 1s on their damage dice count as 2s.",
+		Prerequisite: FeatPrerequisite{Spellcasting: true},
+	},
+	FeatGrappler: {
+		Description:  "Advantage on attack rolls against a creature you are grappling, and you can try to pin it.",
+		Prerequisite: FeatPrerequisite{Stats: []StatName{StatStrength}, Minimum: 13},
+	},
+	FeatGreatWeaponMaster: {
+		Description: "Make a bonus action attack after a critical hit or kill, and trade -5 to hit for +10 damage with heavy weapons.",
+	},
+	FeatInspiringLeader: {
+		Description:  "Spend 10 minutes inspiring up to six creatures to grant temporary hit points equal to your level plus your Charisma modifier.",
+		Prerequisite: FeatPrerequisite{Stats: []StatName{StatCharisma}, Minimum: 13},
+	},
+	FeatKeenMind: {
+		Description:     "You always know which way is north, the hours until sunrise or sunset, and recall anything from the past month.",
+		IncreaseOptions: []StatName{StatIntelligence},
+	},
+	FeatLightlyArmored: {
+		Description:        "You gain proficiency with light armor.",
+		IncreaseOptions:    []StatName{StatStrength, StatDexterity},
+		ArmorProficiencies: []string{string(ArmorLight)},
+	},
+	FeatMobile: {
+		Description: "+10 speed, difficult terrain doesn't slow your Dash, and creatures you attack can't make opportunity attacks against you.",
+		Speed:       10,
+	},
+	FeatObservant: {
+		Description:     "+5 to passive Perception and Investigation, and you can read lips.",
+		IncreaseOptions: []StatName{StatIntelligence, StatWisdom},
+		PassiveBonus:    map[SkillName]int{SkillPerception: 5, SkillInvestigation: 5},
+	},
+	FeatResilient: {
+		Description:            "You gain proficiency in saving throws using the chosen ability.",
+		IncreaseOptions:        []StatName{StatStrength, StatDexterity, StatConstitution, StatIntelligence, StatWisdom, StatCharisma},
+		SavingThrowProficiency: true,
+	},
+	FeatRitualCaster: {
+		Description:  "You gain a ritual book with two 1st-level ritual spells and can cast them as rituals.",
+		Prerequisite: FeatPrerequisite{Stats: []StatName{StatIntelligence, StatWisdom}, Minimum: 13, AnyOf: true},
+	},
+	FeatSentinel: {
+		Description: "Opportunity attacks reduce a creature's speed to 0, and Disengage doesn't avoid them.",
+	},
+	FeatSharpshooter: {
+		Description: "Ranged attacks ignore long range disadvantage and cover, and you can trade -5 to hit for +10 damage.",
+	},
+	FeatTough: {
+		Description:       "Your hit point maximum increases by 2 for every level you have.",
+		HitPointsPerLevel: 2,
+	},
+	FeatWarCaster: {
+		Description:  "Advantage on concentration saving throws, cast spells with your hands full and as opportunity attacks.",
+		Prerequisite: FeatPrerequisite{Spellcasting: true},
+	},
+	FeatMagicInitiate: {
+		Description: "Learn two cantrips and a level 1 spell from the Cleric, Druid or Wizard list, and cast the spell once per long rest without a slot.",
+	},
+	FeatSavageAttacker: {
+		Description: "Once per turn when you hit with a weapon, roll its damage dice twice and use either roll.",
+	},
+	FeatSkilled: {
+		Description: "You gain proficiency in any combination of three skills or tools of your choice.",
+	},
+}
+
+func (f FeatName) IsValid() bool {
+	_, ok := feats[f]
+	return ok
+}
+
+func (f FeatName) GetFeat() Feat {
+	feat := feats[f]
+	feat.Name = f
+	return feat
+}
+
+// AbilityScoreImprovement is the choice made at a class level that grants an Ability Score
+// Improvement. Without a feat, Stats lists two scores to raise by 1 each, and the same score twice
+// raises it by 2. With a feat, Stats holds the score the feat raises when it gives a choice.
+type AbilityScoreImprovement struct {
+	Class ClassName  `yaml:"class"`
+	Level int        `yaml:"level"`
+	Feat  FeatName   `yaml:"feat,omitempty"`
+	Stats []StatName `yaml:"stats,omitempty"`
+}
+
+func (a AbilityScoreImprovement) getSource() string {
+	if a.Feat != "" {
+		return string(a.Feat)
+	}
+	return fmt.Sprintf("Ability Score Improvement (%s %d)", a.Class, a.Level)
+}
+
+// AbilityScoreImprovementSlot is a class level that grants an Ability Score Improvement, with the
+// choice made for it if there is one.
+type AbilityScoreImprovementSlot struct {
+	Class  ClassName
+	Level  int
+	Choice *AbilityScoreImprovement
+}
+
+// GetAbilityScoreImprovementSlots lists every Ability Score Improvement the character's class levels grant.
+func (c *Character) GetAbilityScoreImprovementSlots() []AbilityScoreImprovementSlot {
+	slots := []AbilityScoreImprovementSlot{}
+	for _, classLevel := range c.Classes {
+		for level := 1; level <= classLevel.Level; level++ {
+			if !c.GetClassDefinition(classLevel.Class).HasAbilityScoreImprovement(level) {
+				continue
+			}
+			slot := AbilityScoreImprovementSlot{Class: classLevel.Class, Level: level}
+			if index := c.findAbilityScoreImprovement(classLevel.Class, level); index != -1 {
+				slot.Choice = &c.AbilityScoreImprovements[index]
+			}
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// getActiveAbilityScoreImprovements returns the choices made for class levels the character still has.
+func (c *Character) getActiveAbilityScoreImprovements() []AbilityScoreImprovement {
+	active := []AbilityScoreImprovement{}
+	for _, slot := range c.GetAbilityScoreImprovementSlots() {
+		if slot.Choice != nil {
+			active = append(active, *slot.Choice)
+		}
+	}
+	return active
+}
+
+// GetFeats returns the origin feat the character's background grants, if any, followed by the
+// feats they have taken.
+func (c *Character) GetFeats() []Feat {
+	output := []Feat{}
+	for _, name := range c.GetRuleset().GetOriginFeats(c) {
+		output = append(output, name.GetFeat())
+	}
+	for _, choice := range c.getActiveAbilityScoreImprovements() {
+		if choice.Feat != "" {
+			output = append(output, choice.Feat.GetFeat())
+		}
+	}
+	return output
+}
+
+func (c *Character) HasFeat(name FeatName) bool {
+	return slices.ContainsFunc(c.GetFeats(), func(feat Feat) bool { return feat.Name == name })
+}
+
+// GetAvailableFeats lists the feats of the character's ruleset they haven't taken and meet the
+// prerequisites for.
+func (c *Character) GetAvailableFeats() []Feat {
+	output := []Feat{}
+	for _, name := range c.GetRuleset().GetFeatNames() {
+		if !c.HasFeat(name) && c.meetsFeatPrerequisite(name.GetFeat().Prerequisite) {
+			output = append(output, name.GetFeat())
+		}
+	}
+	return output
+}
+
+func (c *Character) meetsFeatPrerequisite(prerequisite FeatPrerequisite) bool {
+	if prerequisite.Spellcasting && !c.IsSpellcaster() {
+		return false
+	}
+	if len(prerequisite.Stats) == 0 {
+		return true
+	}
+	met := 0
+	for _, stat := range prerequisite.Stats {
+		if c.GetEffectiveScore(stat) >= prerequisite.Minimum {
+			met++
+		}
+	}
+	if prerequisite.AnyOf {
+		return met > 0
+	}
+	return met == len(prerequisite.Stats)
+}
+
+// ChooseAbilityScoreImprovement records the choice for one of the character's Ability Score
+// Improvements. Increases can't raise a score above 20, and feats can only be taken once and
+// when their prerequisites are met.
+func (c *Character) ChooseAbilityScoreImprovement(choice AbilityScoreImprovement) error {
+	classLevel := c.GetClassLevel(choice.Class)
+	if classLevel == 0 {
+		return fmt.Errorf("%w: %s", ErrClassNotTaken, choice.Class)
+	}
+	if choice.Level > classLevel || !c.GetClassDefinition(choice.Class).HasAbilityScoreImprovement(choice.Level) {
+		return fmt.Errorf("%w: %s %d", ErrNoAbilityScoreImprovement, choice.Class, choice.Level)
+	}
+	if c.findAbilityScoreImprovement(choice.Class, choice.Level) != -1 {
+		return fmt.Errorf("%w: %s %d", ErrAbilityScoreImprovementChosen, choice.Class, choice.Level)
+	}
+
+	if choice.Feat == "" {
+		if err := c.validateAbilityScoreIncrease(choice.Stats); err != nil {
+			return err
+		}
+	} else {
+		stats, err := c.validateFeat(choice.Feat, choice.Stats)
+		if err != nil {
+			return err
+		}
+		choice.Stats = stats
+	}
+
+	c.AbilityScoreImprovements = append(c.AbilityScoreImprovements, choice)
+	return nil
+}
+
+// RemoveAbilityScoreImprovement clears the choice made at a class level so it can be chosen again.
+func (c *Character) RemoveAbilityScoreImprovement(class ClassName, level int) error {
+	index := c.findAbilityScoreImprovement(class, level)
+	if index == -1 {
+		return fmt.Errorf("%w: %s %d", ErrAbilityScoreImprovementNotChosen, class, level)
+	}
+	c.AbilityScoreImprovements = slices.Delete(c.AbilityScoreImprovements, index, index+1)
+	return nil
+}
+
+func (c *Character) findAbilityScoreImprovement(class ClassName, level int) int {
+	return slices.IndexFunc(c.AbilityScoreImprovements, func(choice AbilityScoreImprovement) bool {
+		return choice.Class == class && choice.Level == level
+	})
+}
+
+func (c *Character) validateAbilityScoreIncrease(stats []StatName) error {
+	if len(stats) != abilityScoreIncreaseStats {
+		return fmt.Errorf("%w: got %d scores", ErrInvalidAbilityScoreIncrease, len(stats))
+	}
+	increases := make(map[StatName]int)
+	for _, stat := range stats {
+		if !stat.IsValid() || stat == StatYourChoice {
+			return fmt.Errorf("%w: %s", ErrUndefinedStat, stat)
+		}
+		increases[stat]++
+	}
+	for stat, amount := range increases {
+		if score := c.GetEffectiveScore(stat); score+amount > AbilityScoreCap {
+			return fmt.Errorf("%w: %s is already %d", ErrAbilityScoreCap, stat, score)
+		}
+	}
+	return nil
+}
+
+// validateFeat checks the feat can be taken and returns the score it raises, if any.
+func (c *Character) validateFeat(name FeatName, stats []StatName) ([]StatName, error) {
+	if !slices.Contains(c.GetRuleset().GetFeatNames(), name) {
+		return nil, fmt.Errorf("%w: %s", ErrUndefinedFeat, name)
+	}
+	if c.HasFeat(name) {
+		return nil, fmt.Errorf("%w: %s", ErrFeatTaken, name)
+	}
+	feat := name.GetFeat()
+	if !c.meetsFeatPrerequisite(feat.Prerequisite) {
+		return nil, fmt.Errorf("%w: %s needs %s", ErrFeatPrerequisite, name, feat.Prerequisite)
+	}
+
+	switch {
+	case len(feat.IncreaseOptions) == 0:
+		return nil, nil
+	case len(feat.IncreaseOptions) == 1:
+		return feat.IncreaseOptions, nil
+	case len(stats) == 0 || !slices.Contains(feat.IncreaseOptions, stats[0]):
+		return nil, fmt.Errorf("%w: %s raises one of %v", ErrInvalidAbilityScoreIncrease, name, feat.IncreaseOptions)
+	default:
+		return stats[:1], nil
+	}
+}
+
+// getImprovementBonuses resolves the character's Ability Score Improvements and feats into
+// bonuses on top of the base and racial scores, stopping each score at 20.
+func (c *Character) getImprovementBonuses(racial []AbilityBonus) []AbilityBonus {
+	scores := make(map[StatName]int)
+	for _, stat := range StatNames {
+		scores[stat] = c.GetBaseScore(stat)
+	}
+	for _, bonus := range racial {
+		scores[bonus.Stat] += bonus.Amount
+	}
+
+	output := []AbilityBonus{}
+	for _, choice := range c.getActiveAbilityScoreImprovements() {
+		for _, stat := range choice.Stats {
+			if scores[stat] >= AbilityScoreCap {
+				continue
+			}
+			scores[stat]++
+			if last := len(output) - 1; last >= 0 && output[last].Source == choice.getSource() && output[last].Stat == stat {
+				output[last].Amount++
+				continue
+			}
+			output = append(output, AbilityBonus{Source: choice.getSource(), Stat: stat, Amount: 1})
+		}
+	}
+	return output
+}
+
+// HasSavingThrowProficiency reports whether the character is proficient in saving throws for the
+// stat, from their starting class or a feat.
+func (c *Character) HasSavingThrowProficiency(stat StatName) bool {
+	if slices.Contains(c.GetClassDefinition(c.GetStartingClass()).GetSavingThrowsProficiencies(), stat) {
+		return true
+	}
+	for _, choice := range c.getActiveAbilityScoreImprovements() {
+		if choice.Feat != "" && choice.Feat.GetFeat().SavingThrowProficiency && slices.Contains(choice.Stats, stat) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Character) getFeatInitiative() int {
+	total := 0
+	for _, feat := range c.GetFeats() {
+		total += feat.Initiative
+	}
+	return total
+}
+
+func (c *Character) getFeatSpeed() int {
+	total := 0
+	for _, feat := range c.GetFeats() {
+		total += feat.Speed
+	}
+	return total
+}
+
+func (c *Character) getFeatPassiveBonus(skill SkillName) int {
+	total := 0
+	for _, feat := range c.GetFeats() {
+		total += feat.PassiveBonus[skill]
+	}
+	return total
+}
+
+func (c *Character) getFeatHitPoints() int {
+	total := 0
+	for _, feat := range c.GetFeats() {
+		total += feat.HitPointsPerLevel * c.Level
+	}
+	return total
+}
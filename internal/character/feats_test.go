@@ -0,0 +1,132 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"testing"
+)
+
+func TestAbilityScoreImprovementSlots(t *testing.T) {
+	char := newMulticlassCharacter(
+		character.ClassLevel{Class: character.ClassFighter, Level: 6},
+		character.ClassLevel{Class: character.ClassRogue, Level: 10},
+	)
+
+	slots := char.GetAbilityScoreImprovementSlots()
+	want := []character.AbilityScoreImprovementSlot{
+		{Class: character.ClassFighter, Level: 4},
+		{Class: character.ClassFighter, Level: 6},
+		{Class: character.ClassRogue, Level: 4},
+		{Class: character.ClassRogue, Level: 8},
+		{Class: character.ClassRogue, Level: 10},
+	}
+	if len(slots) != len(want) {
+		t.Fatalf("got %d slots; want %d: %+v", len(slots), len(want), slots)
+	}
+	for i := range want {
+		if slots[i].Class != want[i].Class || slots[i].Level != want[i].Level {
+			t.Fatalf("got slot %s %d; want %s %d", slots[i].Class, slots[i].Level, want[i].Class, want[i].Level)
+		}
+	}
+}
+
+func TestChooseAbilityScoreImprovement(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 8})
+
+	tests := []struct {
+		name   string
+		choice character.AbilityScoreImprovement
+		err    error
+	}{
+		{"not an improvement level", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 5, Stats: []character.StatName{character.StatStrength, character.StatDexterity}}, character.ErrNoAbilityScoreImprovement},
+		{"level not reached", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 12, Stats: []character.StatName{character.StatStrength, character.StatDexterity}}, character.ErrNoAbilityScoreImprovement},
+		{"class not taken", character.AbilityScoreImprovement{Class: character.ClassRogue, Level: 4, Stats: []character.StatName{character.StatStrength, character.StatDexterity}}, character.ErrClassNotTaken},
+		{"one increase", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 4, Stats: []character.StatName{character.StatStrength}}, character.ErrInvalidAbilityScoreIncrease},
+		{"increase", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 4, Stats: []character.StatName{character.StatStrength, character.StatStrength}}, nil},
+		{"already chosen", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 4, Stats: []character.StatName{character.StatWisdom, character.StatWisdom}}, character.ErrAbilityScoreImprovementChosen},
+		{"undefined feat", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 6, Feat: "Lucky Socks"}, character.ErrUndefinedFeat},
+		{"feat prerequisite", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 6, Feat: character.FeatWarCaster}, character.ErrFeatPrerequisite},
+		{"feat without choice", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 6, Feat: character.FeatResilient}, character.ErrInvalidAbilityScoreIncrease},
+		{"feat", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 6, Feat: character.FeatResilient, Stats: []character.StatName{character.StatWisdom, character.StatCharisma}}, nil},
+		{"feat taken twice", character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 8, Feat: character.FeatResilient, Stats: []character.StatName{character.StatCharisma}}, character.ErrFeatTaken},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := char.ChooseAbilityScoreImprovement(test.choice); !errors.Is(err, test.err) {
+				t.Fatalf("got %v; want %v", err, test.err)
+			}
+		})
+	}
+
+	// Strength 14 + 2 from the Half-Orc race + 2 from the improvement.
+	if char.GetEffectiveScore(character.StatStrength) != 18 {
+		t.Fatalf("got Strength %d; want 18", char.GetEffectiveScore(character.StatStrength))
+	}
+	if char.GetEffectiveScore(character.StatWisdom) != 11 || !char.HasSavingThrowProficiency(character.StatWisdom) {
+		t.Fatal("expected Resilient to raise Wisdom and grant proficiency in Wisdom saving throws")
+	}
+	if char.GetSavingThrow(character.StatWisdom) != 3 {
+		t.Fatalf("got Wisdom saving throw %d; want 3", char.GetSavingThrow(character.StatWisdom))
+	}
+
+	if err := char.RemoveAbilityScoreImprovement(character.ClassFighter, 6); err != nil {
+		t.Fatalf("unexpected error removing choice: %v", err)
+	}
+	if err := char.RemoveAbilityScoreImprovement(character.ClassFighter, 6); !errors.Is(err, character.ErrAbilityScoreImprovementNotChosen) {
+		t.Fatalf("got %v; want %v", err, character.ErrAbilityScoreImprovementNotChosen)
+	}
+	if char.HasFeat(character.FeatResilient) || char.HasSavingThrowProficiency(character.StatWisdom) {
+		t.Fatal("expected Resilient to be removed")
+	}
+}
+
+func TestAbilityScoreCap(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 8})
+	char.StatBlock.Strength = 17
+
+	// Strength is 19 with the racial bonus, so only one more point fits.
+	err := char.ChooseAbilityScoreImprovement(character.AbilityScoreImprovement{
+		Class: character.ClassFighter, Level: 4, Stats: []character.StatName{character.StatStrength, character.StatStrength},
+	})
+	if !errors.Is(err, character.ErrAbilityScoreCap) {
+		t.Fatalf("got %v; want %v", err, character.ErrAbilityScoreCap)
+	}
+	err = char.ChooseAbilityScoreImprovement(character.AbilityScoreImprovement{
+		Class: character.ClassFighter, Level: 4, Stats: []character.StatName{character.StatStrength, character.StatConstitution},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error choosing improvement: %v", err)
+	}
+
+	err = char.ChooseAbilityScoreImprovement(character.AbilityScoreImprovement{
+		Class: character.ClassFighter, Level: 6, Feat: character.FeatAthlete, Stats: []character.StatName{character.StatStrength},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error choosing feat: %v", err)
+	}
+	if char.GetEffectiveScore(character.StatStrength) != character.AbilityScoreCap {
+		t.Fatalf("expected a feat not to raise Strength past 20, got %d", char.GetEffectiveScore(character.StatStrength))
+	}
+}
+
+func TestFeatBonuses(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 8})
+	initiative, speed, hitPoints := char.GetInitiative(), char.GetMoveSpeed(), char.GetMaxHealthPoints()
+
+	for level, feat := range map[int]character.FeatName{4: character.FeatAlert, 6: character.FeatMobile, 8: character.FeatTough} {
+		err := char.ChooseAbilityScoreImprovement(character.AbilityScoreImprovement{Class: character.ClassFighter, Level: level, Feat: feat})
+		if err != nil {
+			t.Fatalf("unexpected error choosing %s: %v", feat, err)
+		}
+	}
+	if char.GetInitiative() != initiative+5 || char.GetMoveSpeed() != speed+10 || char.GetMaxHealthPoints() != hitPoints+16 {
+		t.Fatalf("got initiative %d, speed %d and %d hit points from feats", char.GetInitiative(), char.GetMoveSpeed(), char.GetMaxHealthPoints())
+	}
+
+	// Improvements from class levels the character no longer has stop applying.
+	char.Classes[0].Level = 5
+	char.Level = 5
+	if char.HasFeat(character.FeatMobile) || !char.HasFeat(character.FeatAlert) {
+		t.Fatalf("got incorrect feats after losing levels: %v", char.GetFeats())
+	}
+}
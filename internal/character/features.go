@@ -0,0 +1,361 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrUndefinedFeature       = errors.New("the character does not have that feature")
+	ErrFeatureNotLimited      = errors.New("the feature does not have limited uses")
+	ErrNoFeatureUsesRemaining = errors.New("no uses of the feature remain")
+	ErrNoFeatureUsesExpended  = errors.New("no uses of the feature have been expended")
+	ErrUndefinedSubclass      = errors.New("attempted to use undefined subclass")
+	ErrSubclassLevel          = errors.New("the class is not high enough level to choose a subclass")
+	ErrSubclassChosen         = errors.New("a subclass has already been chosen for the class")
+	ErrClassNotTaken          = errors.New("the character has no levels in that class")
+)
+
+// UnlimitedUses marks a feature that can be used any number of times from a class level on.
+const UnlimitedUses = -1
+
+type Recharge string
+
+const (
+	RechargeShortRest Recharge = "Short Rest"
+	RechargeLongRest  Recharge = "Long Rest"
+)
+
+// FeatureUses describes how many times a feature can be used before it recharges.
+// The total is the ByLevel entry for the highest class level reached, plus PerLevel uses for
+// every class level and the modifier of Stat when set, and never less than Minimum.
+type FeatureUses struct {
+	ByLevel  map[int]int `yaml:"by-level,omitempty"`
+	PerLevel int         `yaml:"per-level,omitempty"`
+	Stat     StatName    `yaml:"stat,omitempty"`
+	Bonus    int         `yaml:"bonus,omitempty"`
+	Minimum  int         `yaml:"minimum,omitempty"`
+	Recharge Recharge    `yaml:"recharge,omitempty"`
+	// ShortRestLevel is the class level from which the feature also recharges on a short rest.
+	ShortRestLevel int `yaml:"short-rest-level,omitempty"`
+}
+
+// ClassFeature is a feature gained at a class level. Scaling holds the value shown next to the
+// name, keyed by the class level it applies from, e.g. the Sneak Attack dice.
+type ClassFeature struct {
+	Name        string         `yaml:"name"`
+	Level       int            `yaml:"level"`
+	Description string         `yaml:"description"`
+	Scaling     map[int]string `yaml:"scaling,omitempty"`
+	Uses        *FeatureUses   `yaml:"uses,omitempty"`
+}
+
+type SubclassName string
+
+type Subclass struct {
+	Name     SubclassName   `yaml:"name"`
+	Features []ClassFeature `yaml:"features"`
+}
+
+// ActiveFeature is a class feature or racial trait the character has, with its current value and uses.
+type ActiveFeature struct {
+	Name        string
+	Class       ClassName
+	Subclass    SubclassName
+	Race        RaceName
+	Subrace     SubraceName
+	Description string
+	Value       string
+	MaxUses     int
+	Expended    int
+	Recharge    Recharge
+}
+
+func (f ActiveFeature) IsLimited() bool {
+	return f.MaxUses > 0
+}
+
+func (f ActiveFeature) IsUnlimited() bool {
+	return f.MaxUses == UnlimitedUses
+}
+
+func (f ActiveFeature) Remaining() int {
+	return max(f.MaxUses-f.Expended, 0)
+}
+
+// SubclassChoice lists the subclasses a class can choose from once it reaches its subclass level.
+type SubclassChoice struct {
+	Class   ClassName
+	Title   string
+	Options []SubclassName
+}
+
+// GetDefinition returns the class as the 2014 rules define it. A character's classes resolve
+// through its own ruleset with Character.GetClassDefinition.
+func (c ClassName) GetDefinition() ClassDefinition {
+	definition, _ := Rules().Class(c)
+	return definition
+}
+
+// GetClassDefinition returns the class as the character's ruleset defines it, or an empty
+// definition for a class the ruleset doesn't have.
+func (c *Character) GetClassDefinition(class ClassName) ClassDefinition {
+	definition, _ := c.rules().Class(class)
+	return definition
+}
+
+func (d ClassDefinition) GetSubclasses() []SubclassName {
+	names := make([]SubclassName, len(d.Subclasses))
+	for i, subclass := range d.Subclasses {
+		names[i] = subclass.Name
+	}
+	return names
+}
+
+func (d ClassDefinition) getSubclass(name SubclassName) (Subclass, bool) {
+	for _, subclass := range d.Subclasses {
+		if subclass.Name == name {
+			return subclass, true
+		}
+	}
+	return Subclass{}, false
+}
+
+// GetLevelFeatures returns the names of the features the class gains or improves at the level.
+// Without a subclass, subclass features are named after the class's subclass title.
+func (d ClassDefinition) GetLevelFeatures(subclass SubclassName, level int) []string {
+	features := []string{}
+	for _, feature := range d.Features {
+		if feature.gainedOrImprovedAt(level) {
+			features = append(features, feature.displayName(level))
+		}
+	}
+	if chosen, ok := d.getSubclass(subclass); ok {
+		for _, feature := range chosen.Features {
+			if feature.gainedOrImprovedAt(level) {
+				features = append(features, feature.displayName(level))
+			}
+		}
+	} else if slices.Contains(d.SubclassFeatureLevels, level) {
+		features = append(features, d.SubclassTitle+" Feature")
+	}
+	return features
+}
+
+func (f ClassFeature) gainedOrImprovedAt(level int) bool {
+	if f.Level == level {
+		return true
+	}
+	if level < f.Level {
+		return false
+	}
+	if _, ok := f.Scaling[level]; ok {
+		return true
+	}
+	if f.Uses != nil {
+		if _, ok := f.Uses.ByLevel[level]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (f ClassFeature) displayName(level int) string {
+	if value := f.getValue(level); value != "" {
+		return fmt.Sprintf("%s (%s)", f.Name, value)
+	}
+	return f.Name
+}
+
+func (f ClassFeature) getValue(level int) string {
+	return getByLevel(f.Scaling, level, "")
+}
+
+// getByLevel returns the entry for the highest level key not above the level.
+func getByLevel[T any](table map[int]T, level int, fallback T) T {
+	best := 0
+	output := fallback
+	for key, value := range table {
+		if key <= level && key > best {
+			best = key
+			output = value
+		}
+	}
+	return output
+}
+
+// getMaxUses works out the uses of a feature for a class level, or 0 if it isn't limited.
+func (c *Character) getMaxUses(uses *FeatureUses, level int) int {
+	if uses == nil {
+		return 0
+	}
+	total := getByLevel(uses.ByLevel, level, 0)
+	if total == UnlimitedUses {
+		return UnlimitedUses
+	}
+	total += uses.PerLevel*level + uses.Bonus
+	if uses.Stat != "" {
+		total += c.GetAbilityScore(uses.Stat)
+	}
+	return max(total, uses.Minimum)
+}
+
+func (u *FeatureUses) getRecharge(level int) Recharge {
+	if u.ShortRestLevel > 0 && level >= u.ShortRestLevel {
+		return RechargeShortRest
+	}
+	return u.Recharge
+}
+
+// GetSubclass returns the subclass chosen for the class, or an empty name if there isn't one.
+func (c *Character) GetSubclass(class ClassName) SubclassName {
+	for _, classLevel := range c.Classes {
+		if classLevel.Class == class {
+			return classLevel.Subclass
+		}
+	}
+	return ""
+}
+
+// GetSubclassChoices returns the classes that have reached their subclass level without choosing one.
+func (c *Character) GetSubclassChoices() []SubclassChoice {
+	choices := []SubclassChoice{}
+	for _, classLevel := range c.Classes {
+		definition := c.GetClassDefinition(classLevel.Class)
+		if classLevel.Subclass != "" || len(definition.Subclasses) == 0 || classLevel.Level < c.GetRuleset().GetSubclassLevel(definition) {
+			continue
+		}
+		choices = append(choices, SubclassChoice{
+			Class:   classLevel.Class,
+			Title:   definition.SubclassTitle,
+			Options: definition.GetSubclasses(),
+		})
+	}
+	return choices
+}
+
+// SetSubclass chooses the subclass for one of the character's classes. The choice can only be
+// made once the class reaches its subclass level, and can't be changed afterwards.
+func (c *Character) SetSubclass(class ClassName, subclass SubclassName) error {
+	index := slices.IndexFunc(c.Classes, func(classLevel ClassLevel) bool { return classLevel.Class == class })
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrClassNotTaken, class)
+	}
+	definition := c.GetClassDefinition(class)
+	if _, ok := definition.getSubclass(subclass); !ok {
+		return fmt.Errorf("%w: %s for %s", ErrUndefinedSubclass, subclass, class)
+	}
+	if c.Classes[index].Subclass != "" {
+		return fmt.Errorf("%w: %s", ErrSubclassChosen, c.Classes[index].Subclass)
+	}
+	if level := c.GetRuleset().GetSubclassLevel(definition); c.Classes[index].Level < level {
+		return fmt.Errorf("%w: %s needs level %d", ErrSubclassLevel, class, level)
+	}
+	c.Classes[index].Subclass = subclass
+	return nil
+}
+
+// GetActiveFeatures returns every class and subclass feature the character has at their current
+// class levels, followed by their racial traits at their character level. A feature granted by
+// more than one class is only listed once, from the class it was taken in first.
+func (c *Character) GetActiveFeatures() []ActiveFeature {
+	features := []ActiveFeature{}
+	seen := make(map[string]bool)
+	add := func(feature ClassFeature, source ActiveFeature, level int) {
+		if feature.Level > level || seen[feature.Name] {
+			return
+		}
+		seen[feature.Name] = true
+		active := source
+		active.Name = feature.Name
+		active.Description = feature.Description
+		active.Value = feature.getValue(level)
+		active.MaxUses = c.getMaxUses(feature.Uses, level)
+		if active.IsLimited() {
+			active.Expended = min(c.ExpendedFeatureUses[feature.Name], active.MaxUses)
+			active.Recharge = feature.Uses.getRecharge(level)
+		}
+		features = append(features, active)
+	}
+
+	for _, classLevel := range c.Classes {
+		definition := c.GetClassDefinition(classLevel.Class)
+		for _, feature := range definition.Features {
+			add(feature, ActiveFeature{Class: classLevel.Class}, classLevel.Level)
+		}
+		if subclass, ok := definition.getSubclass(classLevel.Subclass); ok {
+			for _, feature := range subclass.Features {
+				add(feature, ActiveFeature{Class: classLevel.Class, Subclass: subclass.Name}, classLevel.Level)
+			}
+		}
+	}
+	if race, ok := c.Race.rules().Race(c.Race.Type); ok {
+		for _, trait := range race.Traits {
+			add(trait, ActiveFeature{Race: c.Race.Type}, c.Level)
+		}
+	}
+	if subrace, ok := c.Race.rules().Subrace(c.Race.Subrace); ok {
+		for _, trait := range subrace.Traits {
+			add(trait, ActiveFeature{Race: c.Race.Type, Subrace: c.Race.Subrace}, c.Level)
+		}
+	}
+	for _, trait := range c.Race.getInnateSpellTraits() {
+		add(trait, ActiveFeature{Race: c.Race.Type}, c.Level)
+	}
+	return features
+}
+
+// GetActiveFeature returns the named feature if the character has it.
+func (c *Character) GetActiveFeature(name string) (ActiveFeature, bool) {
+	for _, feature := range c.GetActiveFeatures() {
+		if feature.Name == name {
+			return feature, true
+		}
+	}
+	return ActiveFeature{}, false
+}
+
+// UseFeature spends one use of a limited-use feature.
+func (c *Character) UseFeature(name string) error {
+	feature, err := c.getLimitedFeature(name)
+	if err != nil {
+		return err
+	}
+	if feature.Remaining() == 0 {
+		return fmt.Errorf("%w: %s", ErrNoFeatureUsesRemaining, name)
+	}
+	c.setExpendedFeatureUses(name, feature.Expended+1)
+	return nil
+}
+
+// RestoreFeature regains one expended use of a limited-use feature.
+func (c *Character) RestoreFeature(name string) error {
+	feature, err := c.getLimitedFeature(name)
+	if err != nil {
+		return err
+	}
+	if feature.Expended == 0 {
+		return fmt.Errorf("%w: %s", ErrNoFeatureUsesExpended, name)
+	}
+	c.setExpendedFeatureUses(name, feature.Expended-1)
+	return nil
+}
+
+func (c *Character) getLimitedFeature(name string) (ActiveFeature, error) {
+	feature, ok := c.GetActiveFeature(name)
+	if !ok {
+		return ActiveFeature{}, fmt.Errorf("%w: %s", ErrUndefinedFeature, name)
+	}
+	if !feature.IsLimited() {
+		return ActiveFeature{}, fmt.Errorf("%w: %s", ErrFeatureNotLimited, name)
+	}
+	return feature, nil
+}
+
+func (c *Character) setExpendedFeatureUses(name string, expended int) {
+	if c.ExpendedFeatureUses == nil {
+		c.ExpendedFeatureUses = make(map[string]int)
+	}
+	c.ExpendedFeatureUses[name] = expended
+}
@@ -0,0 +1,131 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFeatureUses(t *testing.T) {
+	tests := []struct {
+		name    string
+		class   character.ClassLevel
+		feature string
+		uses    int
+		value   string
+	}{
+		{"Rage at level 1", character.ClassLevel{Class: character.ClassBarbarian, Level: 1}, "Rage", 2, "+2"},
+		{"Rage at level 9", character.ClassLevel{Class: character.ClassBarbarian, Level: 9}, "Rage", 4, "+3"},
+		{"Rage at level 20", character.ClassLevel{Class: character.ClassBarbarian, Level: 20}, "Rage", character.UnlimitedUses, "+4"},
+		{"Ki per level", character.ClassLevel{Class: character.ClassMonk, Level: 7}, "Ki", 7, ""},
+		{"Lay on Hands pool", character.ClassLevel{Class: character.ClassPaladin, Level: 4}, "Lay on Hands", 20, ""},
+		{"Bardic Inspiration minimum", character.ClassLevel{Class: character.ClassBard, Level: 1}, "Bardic Inspiration", 1, "d6"},
+		{"Sneak Attack dice", character.ClassLevel{Class: character.ClassRogue, Level: 6}, "Sneak Attack", 0, "3d6"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			char := newMulticlassCharacter(test.class)
+			feature, ok := char.GetActiveFeature(test.feature)
+			if !ok {
+				t.Fatalf("expected %s to be active", test.feature)
+			}
+			if feature.MaxUses != test.uses {
+				t.Fatalf("got %d uses; want %d", feature.MaxUses, test.uses)
+			}
+			if feature.Value != test.value {
+				t.Fatalf("got value %q; want %q", feature.Value, test.value)
+			}
+		})
+	}
+}
+
+func TestFeatureRecharge(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassBard, Level: 4})
+	if feature, _ := char.GetActiveFeature("Bardic Inspiration"); feature.Recharge != character.RechargeLongRest {
+		t.Fatalf("got recharge %s; want %s", feature.Recharge, character.RechargeLongRest)
+	}
+	char.Classes[0].Level = 5
+	if feature, _ := char.GetActiveFeature("Bardic Inspiration"); feature.Recharge != character.RechargeShortRest {
+		t.Fatalf("got recharge %s; want %s", feature.Recharge, character.RechargeShortRest)
+	}
+}
+
+func TestUseFeature(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 2})
+
+	if err := char.UseFeature("Action Surge"); err != nil {
+		t.Fatalf("unexpected error using Action Surge: %v", err)
+	}
+	if err := char.UseFeature("Action Surge"); !errors.Is(err, character.ErrNoFeatureUsesRemaining) {
+		t.Fatalf("got %v; want %v", err, character.ErrNoFeatureUsesRemaining)
+	}
+	if err := char.RestoreFeature("Action Surge"); err != nil {
+		t.Fatalf("unexpected error restoring Action Surge: %v", err)
+	}
+	if err := char.RestoreFeature("Action Surge"); !errors.Is(err, character.ErrNoFeatureUsesExpended) {
+		t.Fatalf("got %v; want %v", err, character.ErrNoFeatureUsesExpended)
+	}
+	if err := char.UseFeature("Fighting Style"); !errors.Is(err, character.ErrFeatureNotLimited) {
+		t.Fatalf("got %v; want %v", err, character.ErrFeatureNotLimited)
+	}
+	if err := char.UseFeature("Indomitable"); !errors.Is(err, character.ErrUndefinedFeature) {
+		t.Fatalf("got %v; want %v", err, character.ErrUndefinedFeature)
+	}
+}
+
+func TestSubclasses(t *testing.T) {
+	char := newMulticlassCharacter(
+		character.ClassLevel{Class: character.ClassFighter, Level: 3},
+		character.ClassLevel{Class: character.ClassWizard, Level: 1},
+	)
+
+	choices := char.GetSubclassChoices()
+	if len(choices) != 1 || choices[0].Class != character.ClassFighter {
+		t.Fatalf("got incorrect subclass choices: %v", choices)
+	}
+	if err := char.SetSubclass(character.ClassWizard, "School of Evocation"); !errors.Is(err, character.ErrSubclassLevel) {
+		t.Fatalf("got %v; want %v", err, character.ErrSubclassLevel)
+	}
+	if err := char.SetSubclass(character.ClassFighter, "School of Evocation"); !errors.Is(err, character.ErrUndefinedSubclass) {
+		t.Fatalf("got %v; want %v", err, character.ErrUndefinedSubclass)
+	}
+	if err := char.SetSubclass(character.ClassRogue, "Thief"); !errors.Is(err, character.ErrClassNotTaken) {
+		t.Fatalf("got %v; want %v", err, character.ErrClassNotTaken)
+	}
+	if err := char.SetSubclass(character.ClassFighter, "Champion"); err != nil {
+		t.Fatalf("unexpected error choosing subclass: %v", err)
+	}
+	if err := char.SetSubclass(character.ClassFighter, "Champion"); !errors.Is(err, character.ErrSubclassChosen) {
+		t.Fatalf("got %v; want %v", err, character.ErrSubclassChosen)
+	}
+
+	feature, ok := char.GetActiveFeature("Improved Critical")
+	if !ok || feature.Subclass != "Champion" {
+		t.Fatalf("expected Improved Critical from Champion, got %+v", feature)
+	}
+	if _, ok := char.GetActiveFeature("Remarkable Athlete"); ok {
+		t.Fatal("did not expect a level 7 feature at fighter level 3")
+	}
+}
+
+func TestLevelFeatures(t *testing.T) {
+	features := character.ClassRogue.GetDefinition().GetLevelFeatures("", 3)
+	if !slices.Contains(features, "Roguish Archetype") || !slices.Contains(features, "Sneak Attack (2d6)") {
+		t.Fatalf("got incorrect rogue level 3 features: %v", features)
+	}
+	if features = character.ClassRogue.GetDefinition().GetLevelFeatures("", 9); !slices.Contains(features, "Roguish Archetype Feature") {
+		t.Fatalf("expected a subclass feature at rogue level 9: %v", features)
+	}
+
+	features = character.ClassRogue.GetDefinition().GetLevelFeatures("Thief", 3)
+	if !slices.Contains(features, "Fast Hands") || !slices.Contains(features, "Second-Story Work") {
+		t.Fatalf("got incorrect thief level 3 features: %v", features)
+	}
+
+	features = character.ClassBarbarian.GetDefinition().GetLevelFeatures("", 6)
+	if !slices.Contains(features, "Rage (+2)") {
+		t.Fatalf("expected extra rage use to be listed at barbarian level 6: %v", features)
+	}
+}
@@ -0,0 +1,168 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrInvalidDamage             = errors.New("damage must not be negative")
+	ErrInvalidHealing            = errors.New("healing must not be negative")
+	ErrInvalidTemporaryHitPoints = errors.New("temporary hit points must not be negative")
+	ErrInvalidMaxHitPoints       = errors.New("hit point maximum override must not be negative")
+	ErrCharacterDead             = errors.New("the character is dead")
+	ErrUndefinedDamageType       = errors.New("attempted to use undefined damage type")
+)
+
+type DamageType string
+
+const (
+	DamageAcid        DamageType = "acid"
+	DamageBludgeoning DamageType = "bludgeoning"
+	DamageCold        DamageType = "cold"
+	DamageFire        DamageType = "fire"
+	DamageForce       DamageType = "force"
+	DamageLightning   DamageType = "lightning"
+	DamageNecrotic    DamageType = "necrotic"
+	DamagePiercing    DamageType = "piercing"
+	DamagePoison      DamageType = "poison"
+	DamagePsychic     DamageType = "psychic"
+	DamageRadiant     DamageType = "radiant"
+	DamageSlashing    DamageType = "slashing"
+	DamageThunder     DamageType = "thunder"
+)
+
+var DamageTypes = []DamageType{
+	DamageAcid, DamageBludgeoning, DamageCold, DamageFire, DamageForce, DamageLightning, DamageNecrotic,
+	DamagePiercing, DamagePoison, DamagePsychic, DamageRadiant, DamageSlashing, DamageThunder,
+}
+
+func (d DamageType) IsValid() bool {
+	return slices.Contains(DamageTypes, d)
+}
+
+// MaxDeathSaves is the number of successes or failures that ends a character's death saving throws.
+const MaxDeathSaves = 3
+
+// DamageResult describes how damage was applied.
+type DamageResult struct {
+	Absorbed          int
+	Taken             int
+	DeathSaveFailures int
+	InstantDeath      bool
+	// Resisted is the damage prevented by resistance before the rest was applied.
+	Resisted int
+}
+
+func (c *Character) IsDying() bool {
+	return c.CurrentHealthPoints == 0 && !c.IsDead() && !c.IsStable()
+}
+
+func (c *Character) IsDead() bool {
+	return c.DeathSaveFailures >= MaxDeathSaves || c.ExhaustionLevel >= MaxExhaustionLevel
+}
+
+// TakeDamage applies damage to temporary hit points first and the rest to current hit points.
+// Damage taken at 0 hit points is a failed death saving throw, or two on a critical hit, and
+// damage that reaches the hit point maximum after dropping to 0 kills the character outright.
+func (c *Character) TakeDamage(amount int, critical bool) (DamageResult, error) {
+	result := DamageResult{}
+	if amount < 0 {
+		return result, fmt.Errorf("%w: %d", ErrInvalidDamage, amount)
+	}
+	if c.IsDead() {
+		return result, ErrCharacterDead
+	}
+
+	result.Absorbed = min(c.TemporaryHitPoints, amount)
+	c.TemporaryHitPoints -= result.Absorbed
+	remaining := amount - result.Absorbed
+	if remaining == 0 {
+		return result, nil
+	}
+
+	if c.CurrentHealthPoints == 0 {
+		// A stable character starts making death saving throws again.
+		if c.IsStable() {
+			c.DeathSaveSuccesses = 0
+		}
+		result.DeathSaveFailures = 1
+		if critical {
+			result.DeathSaveFailures = 2
+		}
+		if remaining >= c.GetMaxHealthPoints() {
+			result.InstantDeath = true
+		}
+	} else {
+		result.Taken = min(c.CurrentHealthPoints, remaining)
+		c.CurrentHealthPoints -= result.Taken
+		if remaining-result.Taken >= c.GetMaxHealthPoints() {
+			result.InstantDeath = true
+		}
+	}
+
+	c.DeathSaveFailures = min(c.DeathSaveFailures+result.DeathSaveFailures, MaxDeathSaves)
+	if result.InstantDeath {
+		c.DeathSaveFailures = MaxDeathSaves
+	}
+	return result, nil
+}
+
+// ResistDamage halves damage of a type the character is resistant to, rounding down. Damage
+// without a type is returned unchanged.
+func (c *Character) ResistDamage(amount int, damageType DamageType) (int, error) {
+	if damageType == "" {
+		return amount, nil
+	}
+	if !damageType.IsValid() {
+		return amount, fmt.Errorf("%w: %s", ErrUndefinedDamageType, damageType)
+	}
+	if c.IsResistantTo(damageType) {
+		return amount / 2, nil
+	}
+	return amount, nil
+}
+
+// Heal restores hit points up to the maximum. Healing a character at 0 hit points brings them back
+// to consciousness and clears their death saving throws.
+func (c *Character) Heal(amount int) (int, error) {
+	if amount < 0 {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidHealing, amount)
+	}
+	if c.IsDead() {
+		return 0, ErrCharacterDead
+	}
+
+	before := c.CurrentHealthPoints
+	c.CurrentHealthPoints = min(c.CurrentHealthPoints+amount, c.GetMaxHealthPoints())
+	if c.CurrentHealthPoints > 0 {
+		c.resetDeathSaves()
+	}
+	return c.CurrentHealthPoints - before, nil
+}
+
+// SetTemporaryHitPoints replaces the character's temporary hit points; they don't stack.
+func (c *Character) SetTemporaryHitPoints(amount int) error {
+	if amount < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidTemporaryHitPoints, amount)
+	}
+	c.TemporaryHitPoints = amount
+	return nil
+}
+
+// SetMaxHitPointsOverride replaces the calculated hit point maximum, or clears the override when
+// the value is 0. Current hit points are lowered to fit a smaller maximum.
+func (c *Character) SetMaxHitPointsOverride(value int) error {
+	if value < 0 {
+		return fmt.Errorf("%w: %d", ErrInvalidMaxHitPoints, value)
+	}
+	c.MaxHitPointsOverride = value
+	c.CurrentHealthPoints = min(c.CurrentHealthPoints, c.GetMaxHealthPoints())
+	return nil
+}
+
+func (c *Character) resetDeathSaves() {
+	c.DeathSaveSuccesses = 0
+	c.DeathSaveFailures = 0
+}
@@ -0,0 +1,93 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"testing"
+)
+
+func newHitPointCharacter() *character.Character {
+	// Fighter 3 with Constitution 15 has 10 + 6 + 6 + 3 * 2 = 28 hit points.
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 3})
+	char.CurrentHealthPoints = char.GetMaxHealthPoints()
+	return char
+}
+
+func TestTakeDamage(t *testing.T) {
+	char := newHitPointCharacter()
+	if err := char.SetTemporaryHitPoints(5); err != nil {
+		t.Fatalf("unexpected error setting temporary hit points: %v", err)
+	}
+
+	result, err := char.TakeDamage(8, false)
+	if err != nil {
+		t.Fatalf("unexpected error taking damage: %v", err)
+	}
+	if result.Absorbed != 5 || result.Taken != 3 || char.TemporaryHitPoints != 0 || char.CurrentHealthPoints != 25 {
+		t.Fatalf("temporary hit points did not absorb damage first: %+v, %d hp", result, char.CurrentHealthPoints)
+	}
+
+	if _, err := char.TakeDamage(30, false); err != nil {
+		t.Fatalf("unexpected error taking damage: %v", err)
+	}
+	if !char.IsDying() || char.DeathSaveFailures != 0 {
+		t.Fatalf("expected character to be dying with no failures, got %d hp and %d failures", char.CurrentHealthPoints, char.DeathSaveFailures)
+	}
+
+	if _, err := char.TakeDamage(1, false); err != nil {
+		t.Fatalf("unexpected error taking damage: %v", err)
+	}
+	if _, err := char.TakeDamage(1, true); err != nil {
+		t.Fatalf("unexpected error taking damage: %v", err)
+	}
+	if !char.IsDead() {
+		t.Fatalf("expected three failed death saves, got %d", char.DeathSaveFailures)
+	}
+	if _, err := char.Heal(5); !errors.Is(err, character.ErrCharacterDead) {
+		t.Fatalf("got %v; want %v", err, character.ErrCharacterDead)
+	}
+}
+
+func TestMassiveDamage(t *testing.T) {
+	char := newHitPointCharacter()
+	result, err := char.TakeDamage(56, false)
+	if err != nil {
+		t.Fatalf("unexpected error taking damage: %v", err)
+	}
+	if !result.InstantDeath || !char.IsDead() {
+		t.Fatalf("expected damage of twice the maximum to kill outright: %+v", result)
+	}
+}
+
+func TestHeal(t *testing.T) {
+	char := newHitPointCharacter()
+	char.CurrentHealthPoints = 0
+	char.DeathSaveFailures = 2
+
+	healed, err := char.Heal(50)
+	if err != nil {
+		t.Fatalf("unexpected error healing: %v", err)
+	}
+	if healed != 28 || char.DeathSaveFailures != 0 {
+		t.Fatalf("got %d healed with %d failures; want 28 healed with none", healed, char.DeathSaveFailures)
+	}
+	if _, err := char.Heal(-1); !errors.Is(err, character.ErrInvalidHealing) {
+		t.Fatalf("got %v; want %v", err, character.ErrInvalidHealing)
+	}
+}
+
+func TestMaxHitPointsOverride(t *testing.T) {
+	char := newHitPointCharacter()
+	if err := char.SetMaxHitPointsOverride(20); err != nil {
+		t.Fatalf("unexpected error setting override: %v", err)
+	}
+	if char.GetMaxHealthPoints() != 20 || char.CurrentHealthPoints != 20 {
+		t.Fatalf("got %d / %d; want 20 / 20", char.CurrentHealthPoints, char.GetMaxHealthPoints())
+	}
+	if err := char.SetMaxHitPointsOverride(0); err != nil {
+		t.Fatalf("unexpected error clearing override: %v", err)
+	}
+	if char.GetMaxHealthPoints() != 28 {
+		t.Fatalf("got %d; want the calculated maximum 28", char.GetMaxHealthPoints())
+	}
+}
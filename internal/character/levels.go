@@ -0,0 +1,215 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrMaxLevel              = errors.New("character is already at the maximum level")
+	ErrNotEnoughExperience   = errors.New("character does not have enough experience to level up")
+	ErrInvalidHitDieRoll     = errors.New("hit die roll is out of range")
+	ErrInvalidExperience     = errors.New("experience cannot be negative")
+	ErrHitPointHistoryExists = errors.New("hit points were already recorded for that level")
+)
+
+const MaxLevel = 20
+
+// experienceThresholds holds the experience needed to reach each level, indexed by level - 1.
+var experienceThresholds = [MaxLevel]int{
+	0, 300, 900, 2700, 6500, 14000, 23000, 34000, 48000, 64000,
+	85000, 100000, 120000, 140000, 165000, 195000, 225000, 265000, 305000, 355000,
+}
+
+// GetExperienceForLevel returns the experience needed to reach the level.
+func GetExperienceForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	if level > MaxLevel {
+		level = MaxLevel
+	}
+	return experienceThresholds[level-1]
+}
+
+// GetLevelForExperience returns the highest level the experience total has reached.
+func GetLevelForExperience(experience int) int {
+	level := 1
+	for i, threshold := range experienceThresholds {
+		if experience >= threshold {
+			level = i + 1
+		}
+	}
+	return level
+}
+
+// GetAverage returns the fixed hit point gain used instead of rolling the die.
+func (h HitDie) GetAverage() int {
+	return int(h)/2 + 1
+}
+
+// LevelHitPoints records the hit die result taken for a single level.
+// The Constitution modifier is not stored so that changes to Constitution apply to every level.
+type LevelHitPoints struct {
+	Level      int       `yaml:"level"`
+	Class      ClassName `yaml:"class"`
+	HitDieRoll int       `yaml:"hit-die-roll"`
+	Rolled     bool      `yaml:"rolled"`
+}
+
+// LevelUpSummary describes what a character gains when reaching a level.
+type LevelUpSummary struct {
+	Level                   int
+	Class                   ClassName
+	ClassLevel              int
+	HitDie                  HitDie
+	AverageHitPoints        int
+	Features                []string
+	AbilityScoreImprovement bool
+}
+
+// HasAbilityScoreImprovement reports whether the class grants an Ability Score Improvement at the level.
+func (d ClassDefinition) HasAbilityScoreImprovement(level int) bool {
+	return slices.Contains(d.AbilityScoreImprovements, level)
+}
+
+// GetLevelHitPoints returns the hit points gained at each level up to the character's level.
+// Levels without a recorded roll are filled from the class levels not yet accounted for, in class
+// order, using the maximum hit die at level 1 and the fixed average after.
+func (c *Character) GetLevelHitPoints() []LevelHitPoints {
+	recorded := make(map[int]LevelHitPoints, len(c.HitPointHistory))
+	unrecorded := make(map[ClassName]int, len(c.Classes))
+	for _, classLevel := range c.Classes {
+		unrecorded[classLevel.Class] = classLevel.Level
+	}
+	for _, entry := range c.HitPointHistory {
+		if entry.Level <= c.Level {
+			recorded[entry.Level] = entry
+			unrecorded[entry.Class]--
+		}
+	}
+
+	levels := make([]LevelHitPoints, 0, c.Level)
+	for level := 1; level <= c.Level; level++ {
+		if entry, ok := recorded[level]; ok {
+			levels = append(levels, entry)
+			continue
+		}
+		class := c.GetStartingClass()
+		for _, classLevel := range c.Classes {
+			if unrecorded[classLevel.Class] > 0 {
+				class = classLevel.Class
+				break
+			}
+		}
+		unrecorded[class]--
+
+		hitDie := c.GetClassDefinition(class).GetHitDie()
+		roll := hitDie.GetAverage()
+		if level == 1 {
+			roll = int(hitDie)
+		}
+		levels = append(levels, LevelHitPoints{Level: level, Class: class, HitDieRoll: roll})
+	}
+	return levels
+}
+
+// GetMaxHealthPoints returns the hit point maximum, using the override when one is set.
+func (c *Character) GetMaxHealthPoints() int {
+	maximum := c.GetCalculatedMaxHealthPoints()
+	if c.MaxHitPointsOverride > 0 {
+		maximum = c.MaxHitPointsOverride
+	}
+	if c.ExhaustionLevel >= 4 {
+		maximum = max(maximum/2, 1)
+	}
+	return maximum
+}
+
+// GetCalculatedMaxHealthPoints adds up the hit die result of every level with the Constitution
+// modifier applied per level, plus hit points from feats. Each level always grants at least 1 hit point.
+func (c *Character) GetCalculatedMaxHealthPoints() int {
+	constitution := c.GetAbilityScore(StatConstitution)
+	total := 0
+	for _, level := range c.GetLevelHitPoints() {
+		total += max(level.HitDieRoll+constitution, 1)
+	}
+	return total + c.getFeatHitPoints()
+}
+
+func (c *Character) GetNextLevelExperience() int {
+	return GetExperienceForLevel(c.Level + 1)
+}
+
+func (c *Character) CanLevelUp() bool {
+	return c.Level < MaxLevel && GetLevelForExperience(c.Experience) > c.Level
+}
+
+// AddExperience adds the amount to the character's experience total.
+func (c *Character) AddExperience(amount int) error {
+	if c.Experience+amount < 0 {
+		return ErrInvalidExperience
+	}
+	c.Experience += amount
+	return nil
+}
+
+// GetLevelUpSummary describes what the character gains by taking their next level in the class.
+// An empty class means the starting class.
+func (c *Character) GetLevelUpSummary(class ClassName) LevelUpSummary {
+	if class == "" {
+		class = c.GetStartingClass()
+	}
+	classLevel := c.GetClassLevel(class) + 1
+	definition := c.GetClassDefinition(class)
+	hitDie := definition.GetHitDie()
+	return LevelUpSummary{
+		Level:                   c.Level + 1,
+		Class:                   class,
+		ClassLevel:              classLevel,
+		HitDie:                  hitDie,
+		AverageHitPoints:        hitDie.GetAverage(),
+		Features:                definition.GetLevelFeatures(c.GetSubclass(class), classLevel),
+		AbilityScoreImprovement: definition.HasAbilityScoreImprovement(classLevel),
+	}
+}
+
+// LevelUp advances the character one level in the class using the hit die result, which is either
+// a roll of the class hit die or its fixed average. Taking a new class requires the multiclass
+// prerequisites. The hit points gained are added to current hit points.
+func (c *Character) LevelUp(class ClassName, hitDieRoll int, rolled bool) (LevelHitPoints, error) {
+	if c.Level >= MaxLevel {
+		return LevelHitPoints{}, ErrMaxLevel
+	}
+	if !c.CanLevelUp() {
+		return LevelHitPoints{}, fmt.Errorf("%w: %d of %d", ErrNotEnoughExperience, c.Experience, c.GetNextLevelExperience())
+	}
+	if !c.IsDefinedClass(class) {
+		return LevelHitPoints{}, fmt.Errorf("%w: %s", ErrUndefinedClass, class)
+	}
+	if err := c.CanMulticlassInto(class); err != nil {
+		return LevelHitPoints{}, err
+	}
+	hitDie := c.GetClassDefinition(class).GetHitDie()
+	if hitDieRoll < 1 || hitDieRoll > int(hitDie) {
+		return LevelHitPoints{}, fmt.Errorf("%w: %d on a d%d", ErrInvalidHitDieRoll, hitDieRoll, hitDie)
+	}
+	for _, entry := range c.HitPointHistory {
+		if entry.Level == c.Level+1 {
+			return LevelHitPoints{}, ErrHitPointHistoryExists
+		}
+	}
+
+	entry := LevelHitPoints{
+		Level:      c.Level + 1,
+		Class:      class,
+		HitDieRoll: hitDieRoll,
+		Rolled:     rolled,
+	}
+	previousMax := c.GetMaxHealthPoints()
+	c.HitPointHistory = append(c.HitPointHistory, entry)
+	c.addClassLevel(class)
+	c.CurrentHealthPoints += c.GetMaxHealthPoints() - previousMax
+	return entry, nil
+}
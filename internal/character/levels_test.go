@@ -0,0 +1,84 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"testing"
+)
+
+func TestGetLevelForExperience(t *testing.T) {
+	tests := []struct {
+		Experience int
+		Expected   int
+	}{
+		{0, 1},
+		{299, 1},
+		{300, 2},
+		{6500, 5},
+		{354999, 19},
+		{355000, 20},
+		{1000000, 20},
+	}
+
+	for _, test := range tests {
+		if level := character.GetLevelForExperience(test.Experience); level != test.Expected {
+			t.Fatalf("got incorrect level for %d experience: %d; want %d", test.Experience, level, test.Expected)
+		}
+	}
+}
+
+func TestMaxHealthPoints(t *testing.T) {
+	char := character.NewCharacter().SetClass(character.ClassFighter).SetLevel(3)
+	char.StatBlock = &character.StatBlock{Constitution: 14}
+	char.Race = character.Race{Type: character.RaceHalfOrc, Subrace: character.SubraceNone}
+
+	// 10 + 2 at level 1, then the average of 6 + 2 for each level after.
+	if hp := char.GetMaxHealthPoints(); hp != 28 {
+		t.Fatalf("got incorrect max health points: %d; want 28", hp)
+	}
+
+	char.HitPointHistory = []character.LevelHitPoints{{Level: 2, Class: character.ClassFighter, HitDieRoll: 1, Rolled: true}}
+	if hp := char.GetMaxHealthPoints(); hp != 23 {
+		t.Fatalf("got incorrect max health points with history: %d; want 23", hp)
+	}
+
+	char.StatBlock.Constitution = 4
+	if hp := char.GetMaxHealthPoints(); hp != 11 {
+		t.Fatalf("got incorrect max health points with low constitution: %d; want 11", hp)
+	}
+}
+
+func TestLevelUp(t *testing.T) {
+	char := character.NewCharacter().SetClass(character.ClassRogue).SetLevel(3)
+	char.StatBlock = &character.StatBlock{Constitution: 12}
+	char.Race = character.Race{Type: character.RaceHalfOrc, Subrace: character.SubraceNone}
+	char.Experience = 900
+	char.CurrentHealthPoints = 10
+
+	if _, err := char.LevelUp(character.ClassRogue, 5, false); !errors.Is(err, character.ErrNotEnoughExperience) {
+		t.Fatalf("got %v; want %v", err, character.ErrNotEnoughExperience)
+	}
+
+	char.Experience = 2700
+	summary := char.GetLevelUpSummary("")
+	if summary.Level != 4 || !summary.AbilityScoreImprovement || summary.AverageHitPoints != 5 {
+		t.Fatalf("got incorrect level up summary: %+v", summary)
+	}
+	if _, err := char.LevelUp(character.ClassRogue, 9, true); !errors.Is(err, character.ErrInvalidHitDieRoll) {
+		t.Fatalf("got %v; want %v", err, character.ErrInvalidHitDieRoll)
+	}
+
+	entry, err := char.LevelUp(character.ClassRogue, 7, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.Level != 4 || char.Level != 4 {
+		t.Fatalf("got incorrect level after level up: %d", char.Level)
+	}
+	if char.CurrentHealthPoints != 18 {
+		t.Fatalf("got incorrect current health points: %d; want 18", char.CurrentHealthPoints)
+	}
+	if !character.ClassRogue.GetDefinition().HasAbilityScoreImprovement(10) || character.ClassWizard.GetDefinition().HasAbilityScoreImprovement(10) {
+		t.Fatal("got incorrect ability score improvement levels")
+	}
+}
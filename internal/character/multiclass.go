@@ -0,0 +1,230 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrMulticlassPrerequisite = errors.New("multiclass prerequisites are not met")
+	ErrDuplicateClass         = errors.New("class was listed more than once")
+	ErrInvalidClassLevel      = errors.New("class level is out of range")
+	ErrNoClasses              = errors.New("character must have at least one class")
+)
+
+// multiclassMinimumScore is the ability score a multiclass prerequisite asks for unless the class
+// sets its own minimum.
+const multiclassMinimumScore = 13
+
+// ClassLevel is the number of levels a character has taken in one class.
+type ClassLevel struct {
+	Class    ClassName    `yaml:"class"`
+	Level    int          `yaml:"level"`
+	Subclass SubclassName `yaml:"subclass,omitempty"`
+}
+
+// MulticlassPrerequisite lists the ability scores needed to multiclass into or out of a class.
+// When AnyOf is set only one of the stats has to meet the minimum.
+type MulticlassPrerequisite struct {
+	Stats   []StatName `yaml:"stats,omitempty"`
+	Minimum int        `yaml:"minimum,omitempty"`
+	AnyOf   bool       `yaml:"any-of,omitempty"`
+}
+
+func (p MulticlassPrerequisite) String() string {
+	parts := make([]string, len(p.Stats))
+	for i, stat := range p.Stats {
+		parts[i] = fmt.Sprintf("%s %d", stat, p.Minimum)
+	}
+	if p.AnyOf {
+		return strings.Join(parts, " or ")
+	}
+	return strings.Join(parts, " and ")
+}
+
+func (d ClassDefinition) GetMulticlassPrerequisite() MulticlassPrerequisite {
+	prerequisite := d.MulticlassPrerequisite
+	prerequisite.Stats = slices.Clone(prerequisite.Stats)
+	if prerequisite.Minimum == 0 {
+		prerequisite.Minimum = multiclassMinimumScore
+	}
+	return prerequisite
+}
+
+// MeetsMulticlassPrerequisite checks the class prerequisite against the character's effective scores.
+func (c *Character) MeetsMulticlassPrerequisite(class ClassName) bool {
+	prerequisite := c.GetClassDefinition(class).GetMulticlassPrerequisite()
+	if len(prerequisite.Stats) == 0 {
+		return true
+	}
+	for _, stat := range prerequisite.Stats {
+		meets := c.GetEffectiveScore(stat) >= prerequisite.Minimum
+		if prerequisite.AnyOf && meets {
+			return true
+		}
+		if !prerequisite.AnyOf && !meets {
+			return false
+		}
+	}
+	return !prerequisite.AnyOf
+}
+
+// CanMulticlassInto checks that the character can take a level in a class they don't have yet.
+// Both the new class and every current class need their prerequisites met.
+func (c *Character) CanMulticlassInto(class ClassName) error {
+	if c.HasClass(class) {
+		return nil
+	}
+	for _, current := range append(c.GetClassNames(), class) {
+		if !c.MeetsMulticlassPrerequisite(current) {
+			return fmt.Errorf("%w: %s requires %s", ErrMulticlassPrerequisite, current, c.GetClassDefinition(current).GetMulticlassPrerequisite())
+		}
+	}
+	return nil
+}
+
+// GetMulticlassOptions returns the classes the character can take their next level in.
+func (c *Character) GetMulticlassOptions() []ClassName {
+	options := c.GetClassNames()
+	for _, class := range c.rules().ClassNames() {
+		if c.GetClassDefinition(class).NonPlayer {
+			continue
+		}
+		if !c.HasClass(class) && c.CanMulticlassInto(class) == nil {
+			options = append(options, class)
+		}
+	}
+	return options
+}
+
+// ValidateClasses checks the class list is well formed and that a multiclassed character meets
+// the prerequisites of every class they have.
+func (c *Character) ValidateClasses() error {
+	if len(c.Classes) == 0 {
+		return ErrNoClasses
+	}
+	total := 0
+	seen := make(map[ClassName]bool)
+	for _, classLevel := range c.Classes {
+		if !c.IsDefinedClass(classLevel.Class) {
+			return fmt.Errorf("%w: %s", ErrUndefinedClass, classLevel.Class)
+		}
+		if seen[classLevel.Class] {
+			return fmt.Errorf("%w: %s", ErrDuplicateClass, classLevel.Class)
+		}
+		seen[classLevel.Class] = true
+		if classLevel.Level < 1 {
+			return fmt.Errorf("%w: %s %d", ErrInvalidClassLevel, classLevel.Class, classLevel.Level)
+		}
+		total += classLevel.Level
+	}
+	if total > MaxLevel {
+		return fmt.Errorf("%w: total level %d", ErrInvalidClassLevel, total)
+	}
+	if !c.IsMulticlassed() {
+		return nil
+	}
+	for _, class := range c.GetClassNames() {
+		if !c.MeetsMulticlassPrerequisite(class) {
+			return fmt.Errorf("%w: %s requires %s", ErrMulticlassPrerequisite, class, c.GetClassDefinition(class).GetMulticlassPrerequisite())
+		}
+	}
+	return nil
+}
+
+// GetStartingClass returns the class taken at first level, which decides saving throw proficiencies.
+func (c *Character) GetStartingClass() ClassName {
+	if len(c.Classes) == 0 {
+		return ClassCommoner
+	}
+	return c.Classes[0].Class
+}
+
+func (c *Character) GetClassNames() []ClassName {
+	names := make([]ClassName, len(c.Classes))
+	for i, classLevel := range c.Classes {
+		names[i] = classLevel.Class
+	}
+	return names
+}
+
+func (c *Character) GetClassLevel(class ClassName) int {
+	for _, classLevel := range c.Classes {
+		if classLevel.Class == class {
+			return classLevel.Level
+		}
+	}
+	return 0
+}
+
+func (c *Character) HasClass(class ClassName) bool {
+	return c.GetClassLevel(class) > 0
+}
+
+func (c *Character) IsMulticlassed() bool {
+	return len(c.Classes) > 1
+}
+
+// GetClassSummary describes the class list, e.g. "Fighter 3 / Wizard 2".
+func (c *Character) GetClassSummary() string {
+	parts := make([]string, len(c.Classes))
+	for i, classLevel := range c.Classes {
+		parts[i] = fmt.Sprintf("%s %d", classLevel.Class, classLevel.Level)
+	}
+	return strings.Join(parts, " / ")
+}
+
+// addClassLevel adds one level in the class, appending it to the class list if it is new.
+func (c *Character) addClassLevel(class ClassName) {
+	for i := range c.Classes {
+		if c.Classes[i].Class == class {
+			c.Classes[i].Level++
+			c.Level++
+			return
+		}
+	}
+	c.Classes = append(c.Classes, ClassLevel{Class: class, Level: 1})
+	c.Level++
+}
+
+// HitDicePool is the number of hit dice of one size a character has, and how many are spent.
+type HitDicePool struct {
+	Die      HitDie
+	Count    int
+	Expended int
+}
+
+func (p HitDicePool) Remaining() int {
+	return max(p.Count-p.Expended, 0)
+}
+
+// GetHitDice groups the character's hit dice by size, largest first.
+func (c *Character) GetHitDice() []HitDicePool {
+	var pools []HitDicePool
+	for _, classLevel := range c.Classes {
+		die := c.GetClassDefinition(classLevel.Class).GetHitDie()
+		index := slices.IndexFunc(pools, func(pool HitDicePool) bool { return pool.Die == die })
+		if index == -1 {
+			pools = append(pools, HitDicePool{Die: die, Count: classLevel.Level})
+		} else {
+			pools[index].Count += classLevel.Level
+		}
+	}
+	for i := range pools {
+		pools[i].Expended = min(c.ExpendedHitDice[pools[i].Die], pools[i].Count)
+	}
+	slices.SortFunc(pools, func(a, b HitDicePool) int { return int(b.Die) - int(a.Die) })
+	return pools
+}
+
+// GetMulticlassWeaponCategoryProficiencies returns the weapon categories gained when multiclassing into the class.
+func (d ClassDefinition) GetMulticlassWeaponCategoryProficiencies() []WeaponCategory {
+	return append([]WeaponCategory{}, d.MulticlassProficiencies.WeaponCategories...)
+}
+
+// GetMulticlassWeaponProficiencies returns individual weapons gained when multiclassing into the class.
+func (d ClassDefinition) GetMulticlassWeaponProficiencies() []string {
+	return append([]string{}, d.MulticlassProficiencies.Weapons...)
+}
@@ -0,0 +1,107 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"testing"
+)
+
+func newMulticlassCharacter(classes ...character.ClassLevel) *character.Character {
+	char := character.NewCharacter()
+	char.StatBlock = &character.StatBlock{Strength: 14, Dexterity: 13, Constitution: 14, Intelligence: 13, Wisdom: 10, Charisma: 8}
+	char.Race = character.Race{Type: character.RaceHalfOrc, Subrace: character.SubraceNone}
+	char.Classes = classes
+	char.Level = 0
+	for _, classLevel := range classes {
+		char.Level += classLevel.Level
+	}
+	return char
+}
+
+func TestMulticlassPrerequisites(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 3})
+	char.Experience = 2700
+
+	if err := char.CanMulticlassInto(character.ClassWizard); err != nil {
+		t.Fatalf("unexpected error multiclassing into wizard: %v", err)
+	}
+	if err := char.CanMulticlassInto(character.ClassCleric); !errors.Is(err, character.ErrMulticlassPrerequisite) {
+		t.Fatalf("got %v; want %v", err, character.ErrMulticlassPrerequisite)
+	}
+	if _, err := char.LevelUp(character.ClassMonk, 5, false); !errors.Is(err, character.ErrMulticlassPrerequisite) {
+		t.Fatalf("got %v; want %v", err, character.ErrMulticlassPrerequisite)
+	}
+
+	if _, err := char.LevelUp(character.ClassWizard, 4, false); err != nil {
+		t.Fatalf("unexpected error leveling into wizard: %v", err)
+	}
+	if char.Level != 4 || char.GetClassLevel(character.ClassWizard) != 1 {
+		t.Fatalf("got incorrect levels after multiclassing: %s", char.GetClassSummary())
+	}
+	// Proficiency bonus comes from total level.
+	if bonus := char.GetProficiencyBonus(); bonus != 2 {
+		t.Fatalf("got incorrect proficiency bonus: %d; want 2", bonus)
+	}
+}
+
+func TestMulticlassRules(t *testing.T) {
+	char := newMulticlassCharacter(
+		character.ClassLevel{Class: character.ClassRogue, Level: 3},
+		character.ClassLevel{Class: character.ClassFighter, Level: 2},
+	)
+
+	// Saving throws come from the starting class only.
+	if save := char.GetSavingThrow(character.StatDexterity); save != 4 {
+		t.Fatalf("got incorrect dexterity saving throw: %d; want 4", save)
+	}
+	if save := char.GetSavingThrow(character.StatConstitution); save != 2 {
+		t.Fatalf("got incorrect constitution saving throw: %d; want 2", save)
+	}
+
+	hitDice := char.GetHitDice()
+	if len(hitDice) != 2 || hitDice[0] != (character.HitDicePool{Die: character.HitDieD10, Count: 2}) || hitDice[1] != (character.HitDicePool{Die: character.HitDieD8, Count: 3}) {
+		t.Fatalf("got incorrect hit dice: %+v", hitDice)
+	}
+
+	// 8 at level 1, then 5, 5 as a rogue and 6, 6 as a fighter, plus 2 per level.
+	if hp := char.GetMaxHealthPoints(); hp != 40 {
+		t.Fatalf("got incorrect max health points: %d; want 40", hp)
+	}
+}
+
+func TestMulticlassSpellSlots(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Classes  []character.ClassLevel
+		Expected []character.SpellSlots
+	}{
+		{
+			"wizard and cleric",
+			[]character.ClassLevel{{Class: character.ClassWizard, Level: 3}, {Class: character.ClassCleric, Level: 2}},
+			[]character.SpellSlots{{Level: 1, Maximum: 4}, {Level: 2, Maximum: 3}, {Level: 3, Maximum: 2}},
+		},
+		{
+			"paladin and sorcerer",
+			[]character.ClassLevel{{Class: character.ClassPaladin, Level: 3}, {Class: character.ClassSorcerer, Level: 1}},
+			[]character.SpellSlots{{Level: 1, Maximum: 3}},
+		},
+		{
+			"warlock keeps pact slots separate",
+			[]character.ClassLevel{{Class: character.ClassWarlock, Level: 2}, {Class: character.ClassWizard, Level: 1}},
+			[]character.SpellSlots{{Level: 1, Maximum: 2}, {Level: 1, Maximum: 2, Pact: true}},
+		},
+	}
+
+	for _, test := range tests {
+		char := newMulticlassCharacter(test.Classes...)
+		slots := char.GetSpellSlots()
+		if len(slots) != len(test.Expected) {
+			t.Fatalf("%s got incorrect spell slots: %+v; want %+v", test.Name, slots, test.Expected)
+		}
+		for i := range slots {
+			if slots[i] != test.Expected[i] {
+				t.Fatalf("%s got incorrect spell slots: %+v; want %+v", test.Name, slots, test.Expected)
+			}
+		}
+	}
+}
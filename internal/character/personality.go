@@ -0,0 +1,188 @@
+package character
+
+import (
+	"dndcc/internal/dice"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidAlignment    = errors.New("alignment is invalid")
+	ErrInvalidPersonality  = errors.New("personality is invalid")
+	ErrNoPersonalityTables = errors.New("background has no personality tables to roll on")
+)
+
+type Alignment string
+
+const (
+	AlignmentLawfulGood     Alignment = "Lawful Good"
+	AlignmentNeutralGood    Alignment = "Neutral Good"
+	AlignmentChaoticGood    Alignment = "Chaotic Good"
+	AlignmentLawfulNeutral  Alignment = "Lawful Neutral"
+	AlignmentNeutral        Alignment = "Neutral"
+	AlignmentChaoticNeutral Alignment = "Chaotic Neutral"
+	AlignmentLawfulEvil     Alignment = "Lawful Evil"
+	AlignmentNeutralEvil    Alignment = "Neutral Evil"
+	AlignmentChaoticEvil    Alignment = "Chaotic Evil"
+)
+
+var Alignments = []Alignment{
+	AlignmentLawfulGood,
+	AlignmentNeutralGood,
+	AlignmentChaoticGood,
+	AlignmentLawfulNeutral,
+	AlignmentNeutral,
+	AlignmentChaoticNeutral,
+	AlignmentLawfulEvil,
+	AlignmentNeutralEvil,
+	AlignmentChaoticEvil,
+}
+
+func (a Alignment) IsValid() bool {
+	return slices.Contains(Alignments, a)
+}
+
+// IdealAlignment is the part of an alignment an ideal leans towards, like Lawful or Good. Any ideals
+// fit every alignment.
+type IdealAlignment string
+
+const (
+	IdealLawful  IdealAlignment = "Lawful"
+	IdealChaotic IdealAlignment = "Chaotic"
+	IdealGood    IdealAlignment = "Good"
+	IdealEvil    IdealAlignment = "Evil"
+	IdealNeutral IdealAlignment = "Neutral"
+	IdealAny     IdealAlignment = "Any"
+)
+
+var IdealAlignments = []IdealAlignment{IdealLawful, IdealChaotic, IdealGood, IdealEvil, IdealNeutral, IdealAny}
+
+func (i IdealAlignment) IsValid() bool {
+	return slices.Contains(IdealAlignments, i)
+}
+
+// Allows reports whether a character with the ideal can have the alignment.
+func (i IdealAlignment) Allows(alignment Alignment) bool {
+	if i == IdealAny {
+		return true
+	}
+	return slices.Contains(strings.Fields(string(alignment)), string(i))
+}
+
+type Ideal struct {
+	Text      string         `yaml:"text"`
+	Alignment IdealAlignment `yaml:"alignment"`
+}
+
+// PersonalityTables are the d8 personality trait and d6 ideal, bond and flaw tables of a background.
+type PersonalityTables struct {
+	Traits []string `yaml:"traits"`
+	Ideals []Ideal  `yaml:"ideals"`
+	Bonds  []string `yaml:"bonds"`
+	Flaws  []string `yaml:"flaws"`
+}
+
+func (t PersonalityTables) IsEmpty() bool {
+	return len(t.Traits) == 0 && len(t.Ideals) == 0 && len(t.Bonds) == 0 && len(t.Flaws) == 0
+}
+
+func (t PersonalityTables) validate() error {
+	if t.IsEmpty() {
+		return nil
+	}
+	if len(t.Traits) < PersonalityTraitCount || len(t.Ideals) == 0 || len(t.Bonds) == 0 || len(t.Flaws) == 0 {
+		return fmt.Errorf("personality tables need at least %d traits and an ideal, bond and flaw", PersonalityTraitCount)
+	}
+	for _, ideal := range t.Ideals {
+		if !ideal.Alignment.IsValid() {
+			return fmt.Errorf("ideal %q has invalid alignment %s", ideal.Text, ideal.Alignment)
+		}
+	}
+	return nil
+}
+
+// PersonalityTraitCount is the number of personality traits a character rolls or picks.
+const PersonalityTraitCount = 2
+
+// maxPersonalityLength caps each written personality entry so a generated one stays a sentence or two.
+const maxPersonalityLength = 600
+
+// Personality is who the character is beyond the numbers: their traits, what they believe in and
+// care about, their flaw, how they look and their alignment.
+type Personality struct {
+	Traits     []string  `yaml:"traits"`
+	Ideal      string    `yaml:"ideal"`
+	Bond       string    `yaml:"bond"`
+	Flaw       string    `yaml:"flaw"`
+	Appearance string    `yaml:"appearance"`
+	Alignment  Alignment `yaml:"alignment"`
+}
+
+// IsEmpty reports whether nothing about the personality has been written yet.
+func (p Personality) IsEmpty() bool {
+	return len(p.Traits) == 0 && p.Ideal == "" && p.Bond == "" && p.Flaw == "" && p.Appearance == "" && p.Alignment == ""
+}
+
+// Validate checks a complete personality, like one written by an LLM: every part is filled in and
+// short, and the alignment is one of the nine.
+func (p Personality) Validate() error {
+	if len(p.Traits) == 0 || len(p.Traits) > PersonalityTraitCount {
+		return fmt.Errorf("%w: expected 1 to %d traits, got %d", ErrInvalidPersonality, PersonalityTraitCount, len(p.Traits))
+	}
+	fields := map[string]string{"ideal": p.Ideal, "bond": p.Bond, "flaw": p.Flaw, "appearance": p.Appearance}
+	for i, trait := range p.Traits {
+		fields[fmt.Sprintf("trait %d", i+1)] = trait
+	}
+	for name, value := range fields {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("%w: %s is empty", ErrInvalidPersonality, name)
+		}
+		if len(value) > maxPersonalityLength {
+			return fmt.Errorf("%w: %s is longer than %d characters", ErrInvalidPersonality, name, maxPersonalityLength)
+		}
+	}
+	if !p.Alignment.IsValid() {
+		return fmt.Errorf("%w: %w: %s", ErrInvalidPersonality, ErrInvalidAlignment, p.Alignment)
+	}
+	return nil
+}
+
+// GetPersonalityTables returns the background's personality tables. The 2024 backgrounds have
+// none of their own, so they use the tables of the 2014 background with the same name.
+func (b *Background) GetPersonalityTables() (PersonalityTables, bool) {
+	definition, _ := b.getDefinition()
+	if definition.Personality.IsEmpty() && b.Ruleset != Ruleset2014 {
+		definition, _ = RulesForOwner(Ruleset2014, b.OwnerId).Background(b.Name)
+	}
+	return definition.Personality, !definition.Personality.IsEmpty()
+}
+
+// RollPersonality rolls two different traits and an ideal, bond and flaw on the tables, then an
+// alignment the ideal allows. Appearance has no table and is left for the player.
+func RollPersonality(tables PersonalityTables, roller *dice.Roller) (Personality, error) {
+	if err := tables.validate(); err != nil || tables.IsEmpty() {
+		return Personality{}, ErrNoPersonalityTables
+	}
+	roll := func(sides int) int {
+		return roller.Roll(&dice.Expression{Terms: []dice.Term{{Count: 1, Sides: sides}}}).Total - 1
+	}
+
+	first := roll(len(tables.Traits))
+	second := roll(len(tables.Traits) - 1)
+	if second >= first {
+		second++
+	}
+	ideal := tables.Ideals[roll(len(tables.Ideals))]
+	alignments := slices.DeleteFunc(slices.Clone(Alignments), func(alignment Alignment) bool {
+		return !ideal.Alignment.Allows(alignment)
+	})
+	return Personality{
+		Traits:    []string{tables.Traits[first], tables.Traits[second]},
+		Ideal:     ideal.Text,
+		Bond:      tables.Bonds[roll(len(tables.Bonds))],
+		Flaw:      tables.Flaws[roll(len(tables.Flaws))],
+		Alignment: alignments[roll(len(alignments))],
+	}, nil
+}
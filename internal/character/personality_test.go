@@ -0,0 +1,108 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/dice"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// sequenceRNG returns the given die faces in order, cycling when exhausted.
+type sequenceRNG struct {
+	values []int
+	index  int
+}
+
+func (s *sequenceRNG) IntN(n int) int {
+	value := s.values[s.index%len(s.values)]
+	s.index++
+	return (value - 1) % n
+}
+
+func TestRollPersonality(t *testing.T) {
+	background := character.Background{Name: character.BackgroundAcolyte, Ruleset: character.Ruleset2014}
+	tables, ok := background.GetPersonalityTables()
+	if !ok {
+		t.Fatalf("got no personality tables for the Acolyte")
+	}
+
+	// The second trait is rolled on the seven traits left, so rolling 1 twice gives the first two.
+	// Charity is a Good ideal, which leaves Lawful, Neutral and Chaotic Good to roll on.
+	roller := dice.NewRoller(&sequenceRNG{values: []int{1, 1, 2, 6, 3, 2}})
+	personality, err := character.RollPersonality(tables, roller)
+	if err != nil {
+		t.Fatalf("unexpected error rolling a personality: %v", err)
+	}
+	if len(personality.Traits) != 2 || personality.Traits[0] != tables.Traits[0] || personality.Traits[1] != tables.Traits[1] {
+		t.Fatalf("got traits %q; want the first two Acolyte traits", personality.Traits)
+	}
+	if !strings.HasPrefix(personality.Ideal, "Charity.") || personality.Bond != tables.Bonds[5] || personality.Flaw != tables.Flaws[2] {
+		t.Fatalf("got ideal %q, bond %q and flaw %q; want Charity, the sixth bond and the third flaw", personality.Ideal, personality.Bond, personality.Flaw)
+	}
+	if personality.Alignment != character.AlignmentNeutralGood {
+		t.Fatalf("got alignment %s; want %s", personality.Alignment, character.AlignmentNeutralGood)
+	}
+
+	// There is no table for appearance, so a rolled personality isn't complete until the player writes one.
+	if err := personality.Validate(); !errors.Is(err, character.ErrInvalidPersonality) {
+		t.Fatalf("got error %v validating a personality without appearance; want %v", err, character.ErrInvalidPersonality)
+	}
+	personality.Appearance = "Shaved head and a threadbare robe."
+	if err := personality.Validate(); err != nil {
+		t.Fatalf("unexpected error validating a complete personality: %v", err)
+	}
+}
+
+func TestPersonalityTables(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Background character.Background
+		ExpectOk   bool
+	}{
+		{"2014 background", character.Background{Name: character.BackgroundSage, Ruleset: character.Ruleset2014}, true},
+		{"2024 background uses the 2014 tables", character.Background{Name: character.BackgroundAcolyte, Ruleset: character.Ruleset2024}, true},
+		{"custom background", character.Background{Name: "Lighthouse Keeper", Ruleset: character.Ruleset2014}, false},
+	}
+	for _, test := range tests {
+		tables, ok := test.Background.GetPersonalityTables()
+		if ok != test.ExpectOk {
+			t.Fatalf("%s: got tables %v; want ok %t", test.Name, ok, test.ExpectOk)
+		}
+		if !ok {
+			if _, err := character.RollPersonality(tables, dice.NewRoller(nil)); !errors.Is(err, character.ErrNoPersonalityTables) {
+				t.Fatalf("%s: got error %v rolling without tables; want %v", test.Name, err, character.ErrNoPersonalityTables)
+			}
+		}
+	}
+}
+
+func TestPersonalityValidate(t *testing.T) {
+	valid := character.Personality{
+		Traits:     []string{"I quote scripture at every meal."},
+		Ideal:      "Faith. The gods guide my hand.",
+		Bond:       "The temple that raised me.",
+		Flaw:       "I trust anyone in robes.",
+		Appearance: "Tall, with ink-stained fingers.",
+		Alignment:  character.AlignmentLawfulGood,
+	}
+	tests := []struct {
+		Name      string
+		Change    func(p *character.Personality)
+		ExpectErr error
+	}{
+		{"valid", func(p *character.Personality) {}, nil},
+		{"too many traits", func(p *character.Personality) { p.Traits = []string{"a", "b", "c"} }, character.ErrInvalidPersonality},
+		{"empty bond", func(p *character.Personality) { p.Bond = " " }, character.ErrInvalidPersonality},
+		{"long flaw", func(p *character.Personality) { p.Flaw = strings.Repeat("a", 601) }, character.ErrInvalidPersonality},
+		{"unknown alignment", func(p *character.Personality) { p.Alignment = "Chaotic Stupid" }, character.ErrInvalidAlignment},
+	}
+	for _, test := range tests {
+		personality := valid
+		personality.Traits = append([]string{}, valid.Traits...)
+		test.Change(&personality)
+		if err := personality.Validate(); !errors.Is(err, test.ExpectErr) {
+			t.Fatalf("%s: got error %v; want %v", test.Name, err, test.ExpectErr)
+		}
+	}
+}
@@ -0,0 +1,462 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUndefinedProficiencyKind   = errors.New("attempted to use undefined proficiency kind")
+	ErrUndefinedProficiencySource = errors.New("attempted to use undefined proficiency source")
+	ErrInvalidProficiency         = errors.New("proficiency name is invalid")
+	ErrProficiencyGranted         = errors.New("the character already has that proficiency from that source")
+	ErrProficiencyNotGranted      = errors.New("the proficiency was not granted to the character")
+	ErrNotProficient              = errors.New("expertise requires proficiency")
+	ErrExpertiseChosen            = errors.New("expertise was already chosen for that proficiency")
+	ErrExpertiseNotChosen         = errors.New("expertise was not chosen for that proficiency")
+	ErrExpertiseLimit             = errors.New("no expertise choices left")
+	ErrInvalidSkillChoice         = errors.New("class skill choices are invalid")
+)
+
+// ProficiencyLevel is how much of the proficiency bonus applies to a roll.
+type ProficiencyLevel int
+
+const (
+	ProficiencyNone ProficiencyLevel = iota
+	ProficiencyHalf
+	ProficiencyProficient
+	ProficiencyExpertise
+)
+
+func (l ProficiencyLevel) String() string {
+	switch l {
+	case ProficiencyHalf:
+		return "Half"
+	case ProficiencyProficient:
+		return "Proficient"
+	case ProficiencyExpertise:
+		return "Expertise"
+	default:
+		return "None"
+	}
+}
+
+// GetBonus returns the part of the proficiency bonus the level adds. Half proficiency rounds down.
+func (l ProficiencyLevel) GetBonus(proficiencyBonus int) int {
+	switch l {
+	case ProficiencyHalf:
+		return proficiencyBonus / 2
+	case ProficiencyProficient:
+		return proficiencyBonus
+	case ProficiencyExpertise:
+		return proficiencyBonus * 2
+	default:
+		return 0
+	}
+}
+
+type ProficiencyKind string
+
+const (
+	ProficiencySkill    ProficiencyKind = "Skill"
+	ProficiencyTool     ProficiencyKind = "Tool"
+	ProficiencyLanguage ProficiencyKind = "Language"
+	ProficiencyArmor    ProficiencyKind = "Armor"
+	ProficiencyWeapon   ProficiencyKind = "Weapon"
+)
+
+var ProficiencyKinds = []ProficiencyKind{ProficiencySkill, ProficiencyTool, ProficiencyLanguage, ProficiencyArmor, ProficiencyWeapon}
+
+func (k ProficiencyKind) IsValid() bool {
+	return slices.Contains(ProficiencyKinds, k)
+}
+
+type ProficiencySource string
+
+const (
+	SourceClass      ProficiencySource = "Class"
+	SourceBackground ProficiencySource = "Background"
+	SourceRace       ProficiencySource = "Race"
+	SourceFeat       ProficiencySource = "Feat"
+)
+
+var ProficiencySources = []ProficiencySource{SourceClass, SourceBackground, SourceRace, SourceFeat}
+
+func (s ProficiencySource) IsValid() bool {
+	return slices.Contains(ProficiencySources, s)
+}
+
+// ShieldProficiency is the armor proficiency that covers shields.
+const ShieldProficiency = "Shields"
+
+// Proficiency is a proficiency the character has and where it comes from. The ones stored on the
+// character are choices made on top of what the class, background, race and feats grant, and
+// expertise is stored as a Class proficiency at the Expertise level.
+type Proficiency struct {
+	Kind   ProficiencyKind   `yaml:"kind"`
+	Name   string            `yaml:"name"`
+	Level  ProficiencyLevel  `yaml:"level"`
+	Source ProficiencySource `yaml:"source"`
+}
+
+// GetArmorProficiencies returns the armor the class is proficient with.
+func (d ClassDefinition) GetArmorProficiencies() []string {
+	return append([]string{}, d.Proficiencies.Armor...)
+}
+
+// GetMulticlassArmorProficiencies returns the armor gained when multiclassing into the class.
+func (d ClassDefinition) GetMulticlassArmorProficiencies() []string {
+	return append([]string{}, d.MulticlassProficiencies.Armor...)
+}
+
+// GetToolProficiencies returns the tools the class is proficient with that don't need a choice.
+func (d ClassDefinition) GetToolProficiencies() []string {
+	return append([]string{}, d.Proficiencies.Tools...)
+}
+
+// GetMulticlassToolProficiencies returns the tools gained when multiclassing into the class.
+func (d ClassDefinition) GetMulticlassToolProficiencies() []string {
+	return append([]string{}, d.MulticlassProficiencies.Tools...)
+}
+
+// getToolProficiencies returns the tools the background grants that don't need a choice.
+func (b *Background) getToolProficiencies() []string {
+	definition, _ := b.getDefinition()
+	return append([]string{}, definition.Tools...)
+}
+
+// GetLanguages returns the languages the race knows. Races that pick extra languages add them as
+// Race proficiencies.
+func (r *Race) GetLanguages() []string {
+	definition, _ := r.rules().Race(r.Type)
+	return append([]string{}, definition.Languages...)
+}
+
+// getSkillProficiencies returns the skills the race and subrace are proficient in.
+func (r *Race) getSkillProficiencies() []SkillName {
+	output := []SkillName{}
+	for _, rules := range r.getRules() {
+		output = append(output, rules.Skills...)
+	}
+	return output
+}
+
+// getWeaponProficiencies returns the weapons the race and subrace are trained with.
+func (r *Race) getWeaponProficiencies() []string {
+	output := []string{}
+	for _, rules := range r.getRules() {
+		output = append(output, rules.Weapons...)
+	}
+	return output
+}
+
+// getArmorProficiencies returns the armor the race and subrace are trained with.
+func (r *Race) getArmorProficiencies() []string {
+	output := []string{}
+	for _, rules := range r.getRules() {
+		output = append(output, rules.Armor...)
+	}
+	return output
+}
+
+// getToolProficiencies returns the tools the race and subrace grant that don't need a choice.
+func (r *Race) getToolProficiencies() []string {
+	output := []string{}
+	for _, rules := range r.getRules() {
+		output = append(output, rules.Tools...)
+	}
+	return output
+}
+
+func proficienciesOf(kind ProficiencyKind, source ProficiencySource, names ...string) []Proficiency {
+	output := make([]Proficiency, len(names))
+	for i, name := range names {
+		output[i] = Proficiency{Kind: kind, Name: name, Level: ProficiencyProficient, Source: source}
+	}
+	return output
+}
+
+func skillNames(skills []SkillName) []string {
+	output := make([]string, len(skills))
+	for i, skill := range skills {
+		output[i] = string(skill)
+	}
+	return output
+}
+
+// getGrantedProficiencies returns the proficiencies that come with the character's classes,
+// background, race and feats. Classes after the first only grant their multiclass proficiencies.
+func (c *Character) getGrantedProficiencies() []Proficiency {
+	output := []Proficiency{}
+	for i, classLevel := range c.Classes {
+		class := c.GetClassDefinition(classLevel.Class)
+		if i == 0 {
+			output = append(output, proficienciesOf(ProficiencyArmor, SourceClass, class.GetArmorProficiencies()...)...)
+			output = append(output, proficienciesOf(ProficiencyTool, SourceClass, class.GetToolProficiencies()...)...)
+			for _, category := range class.GetWeaponCategoryProficiencies() {
+				output = append(output, proficienciesOf(ProficiencyWeapon, SourceClass, string(category))...)
+			}
+			output = append(output, proficienciesOf(ProficiencyWeapon, SourceClass, class.GetWeaponProficiencies()...)...)
+			continue
+		}
+		output = append(output, proficienciesOf(ProficiencyArmor, SourceClass, class.GetMulticlassArmorProficiencies()...)...)
+		output = append(output, proficienciesOf(ProficiencyTool, SourceClass, class.GetMulticlassToolProficiencies()...)...)
+		for _, category := range class.GetMulticlassWeaponCategoryProficiencies() {
+			output = append(output, proficienciesOf(ProficiencyWeapon, SourceClass, string(category))...)
+		}
+		output = append(output, proficienciesOf(ProficiencyWeapon, SourceClass, class.GetMulticlassWeaponProficiencies()...)...)
+	}
+
+	output = append(output, proficienciesOf(ProficiencySkill, SourceBackground, skillNames(c.Background.GetProficiencies())...)...)
+	output = append(output, proficienciesOf(ProficiencyTool, SourceBackground, c.Background.getToolProficiencies()...)...)
+
+	output = append(output, proficienciesOf(ProficiencySkill, SourceRace, skillNames(c.Race.getSkillProficiencies())...)...)
+	output = append(output, proficienciesOf(ProficiencyLanguage, SourceRace, c.Race.GetLanguages()...)...)
+	output = append(output, proficienciesOf(ProficiencyArmor, SourceRace, c.Race.getArmorProficiencies()...)...)
+	output = append(output, proficienciesOf(ProficiencyWeapon, SourceRace, c.Race.getWeaponProficiencies()...)...)
+	output = append(output, proficienciesOf(ProficiencyTool, SourceRace, c.Race.getToolProficiencies()...)...)
+
+	for _, feat := range c.GetFeats() {
+		output = append(output, proficienciesOf(ProficiencyArmor, SourceFeat, feat.ArmorProficiencies...)...)
+	}
+	return output
+}
+
+// GetProficiencies lists every proficiency of the kind with the highest level the character has in
+// it, sorted by name. Expertise only counts for proficiencies the character still has.
+func (c *Character) GetProficiencies(kind ProficiencyKind) []Proficiency {
+	best := map[string]Proficiency{}
+	expertise := map[string]bool{}
+	for _, proficiency := range append(c.getGrantedProficiencies(), c.Proficiencies...) {
+		if proficiency.Kind != kind {
+			continue
+		}
+		key := strings.ToLower(proficiency.Name)
+		if proficiency.Level == ProficiencyExpertise {
+			expertise[key] = true
+			continue
+		}
+		if existing, ok := best[key]; !ok || proficiency.Level > existing.Level {
+			best[key] = proficiency
+		}
+	}
+
+	output := make([]Proficiency, 0, len(best))
+	for key, proficiency := range best {
+		if expertise[key] && proficiency.Level >= ProficiencyProficient {
+			proficiency.Level = ProficiencyExpertise
+		}
+		output = append(output, proficiency)
+	}
+	slices.SortFunc(output, func(a, b Proficiency) int { return strings.Compare(a.Name, b.Name) })
+	return output
+}
+
+// GetProficiencyLevel returns the character's level of proficiency in the named skill, tool,
+// language, armor or weapon.
+func (c *Character) GetProficiencyLevel(kind ProficiencyKind, name string) ProficiencyLevel {
+	for _, proficiency := range c.GetProficiencies(kind) {
+		if strings.EqualFold(proficiency.Name, name) {
+			return proficiency.Level
+		}
+	}
+	return ProficiencyNone
+}
+
+// GetSkillProficiency returns the proficiency level of the skill. Jack of All Trades gives half
+// proficiency in skills the character isn't proficient in.
+func (c *Character) GetSkillProficiency(skill SkillName) ProficiencyLevel {
+	level := c.GetProficiencyLevel(ProficiencySkill, string(skill))
+	if level == ProficiencyNone && c.hasJackOfAllTrades() {
+		return ProficiencyHalf
+	}
+	return level
+}
+
+func (c *Character) hasJackOfAllTrades() bool {
+	_, ok := c.GetActiveFeature("Jack of All Trades")
+	return ok
+}
+
+// GetClassSkills returns the skills chosen from the starting class's skill list.
+func (c *Character) GetClassSkills() []SkillName {
+	output := []SkillName{}
+	for _, proficiency := range c.Proficiencies {
+		if proficiency.Kind == ProficiencySkill && proficiency.Source == SourceClass && proficiency.Level == ProficiencyProficient {
+			output = append(output, SkillName(proficiency.Name))
+		}
+	}
+	return output
+}
+
+// ValidateClassSkills checks the class skill choices against the starting class's list and count.
+// A skill the background or race already grants can't be chosen again.
+func (c *Character) ValidateClassSkills() error {
+	class := c.GetStartingClass()
+	choices := c.GetClassDefinition(class).GetSkillChoices()
+	chosen := c.GetClassSkills()
+	if len(chosen) > choices.Count {
+		return fmt.Errorf("%w: %s picks %d skills, not %d", ErrInvalidSkillChoice, class, choices.Count, len(chosen))
+	}
+
+	granted := map[string]ProficiencySource{}
+	for _, proficiency := range c.getGrantedProficiencies() {
+		if proficiency.Kind == ProficiencySkill {
+			granted[proficiency.Name] = proficiency.Source
+		}
+	}
+	for _, proficiency := range c.Proficiencies {
+		if proficiency.Kind == ProficiencySkill && proficiency.Source != SourceClass {
+			granted[proficiency.Name] = proficiency.Source
+		}
+	}
+
+	seen := map[SkillName]bool{}
+	for _, skill := range chosen {
+		if !slices.Contains(choices.Options, skill) {
+			return fmt.Errorf("%w: %s is not on the %s skill list", ErrInvalidSkillChoice, skill, class)
+		}
+		if seen[skill] {
+			return fmt.Errorf("%w: %s was chosen more than once", ErrInvalidSkillChoice, skill)
+		}
+		if source, ok := granted[string(skill)]; ok {
+			return fmt.Errorf("%w: %s is already granted by the %s", ErrInvalidSkillChoice, skill, strings.ToLower(string(source)))
+		}
+		seen[skill] = true
+	}
+	return nil
+}
+
+// GetExpertiseSlots returns how many expertise choices the character's classes grant.
+func (c *Character) GetExpertiseSlots() int {
+	total := 0
+	for _, classLevel := range c.Classes {
+		total += getByLevel(classLevel.Class.GetDefinition().Expertise, classLevel.Level, 0)
+	}
+	return total
+}
+
+// GetChosenProficiencies lists the proficiencies chosen for the character, leaving out expertise.
+func (c *Character) GetChosenProficiencies() []Proficiency {
+	output := []Proficiency{}
+	for _, proficiency := range c.Proficiencies {
+		if proficiency.Level != ProficiencyExpertise {
+			output = append(output, proficiency)
+		}
+	}
+	return output
+}
+
+// GetExpertise lists the chosen expertise.
+func (c *Character) GetExpertise() []Proficiency {
+	output := []Proficiency{}
+	for _, proficiency := range c.Proficiencies {
+		if proficiency.Level == ProficiencyExpertise {
+			output = append(output, proficiency)
+		}
+	}
+	return output
+}
+
+// GetExpertiseOptions lists the skills and tools the character is proficient in but hasn't chosen
+// expertise for.
+func (c *Character) GetExpertiseOptions() []Proficiency {
+	output := []Proficiency{}
+	for _, kind := range []ProficiencyKind{ProficiencySkill, ProficiencyTool} {
+		for _, proficiency := range c.GetProficiencies(kind) {
+			if proficiency.Level == ProficiencyProficient {
+				output = append(output, proficiency)
+			}
+		}
+	}
+	return output
+}
+
+func (c *Character) findProficiency(target Proficiency) int {
+	return slices.IndexFunc(c.Proficiencies, func(proficiency Proficiency) bool {
+		return proficiency.Kind == target.Kind && strings.EqualFold(proficiency.Name, target.Name) &&
+			proficiency.Level == target.Level && proficiency.Source == target.Source
+	})
+}
+
+// ChooseExpertise doubles the proficiency bonus for a skill or tool the character is proficient in.
+func (c *Character) ChooseExpertise(kind ProficiencyKind, name string) error {
+	if kind != ProficiencySkill && kind != ProficiencyTool {
+		return fmt.Errorf("%w: expertise applies to skills and tools, not %s", ErrUndefinedProficiencyKind, kind)
+	}
+	switch c.GetProficiencyLevel(kind, name) {
+	case ProficiencyExpertise:
+		return fmt.Errorf("%w: %s", ErrExpertiseChosen, name)
+	case ProficiencyProficient:
+	default:
+		return fmt.Errorf("%w: %s", ErrNotProficient, name)
+	}
+	if len(c.GetExpertise()) >= c.GetExpertiseSlots() {
+		return ErrExpertiseLimit
+	}
+	c.Proficiencies = append(c.Proficiencies, Proficiency{Kind: kind, Name: name, Level: ProficiencyExpertise, Source: SourceClass})
+	return nil
+}
+
+func (c *Character) RemoveExpertise(kind ProficiencyKind, name string) error {
+	index := c.findProficiency(Proficiency{Kind: kind, Name: name, Level: ProficiencyExpertise, Source: SourceClass})
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrExpertiseNotChosen, name)
+	}
+	c.Proficiencies = slices.Delete(c.Proficiencies, index, index+1)
+	return nil
+}
+
+// GrantProficiency adds a proficiency the character chose, such as a background language or a
+// racial skill choice, on top of the ones their rules grant.
+func (c *Character) GrantProficiency(kind ProficiencyKind, name string, source ProficiencySource) error {
+	name = strings.TrimSpace(name)
+	if !kind.IsValid() {
+		return fmt.Errorf("%w: %s", ErrUndefinedProficiencyKind, kind)
+	}
+	if !source.IsValid() {
+		return fmt.Errorf("%w: %s", ErrUndefinedProficiencySource, source)
+	}
+	if name == "" {
+		return fmt.Errorf("%w: name must not be empty", ErrInvalidProficiency)
+	}
+	if kind == ProficiencySkill && !c.IsDefinedSkill(SkillName(name)) {
+		return fmt.Errorf("%w: %s", ErrUndefinedSkill, name)
+	}
+	if kind == ProficiencySkill && (source == SourceClass || source == SourceBackground) {
+		return fmt.Errorf("%w: %s skills are chosen with the %s", ErrInvalidProficiency, source, strings.ToLower(string(source)))
+	}
+	if kind == ProficiencyArmor && !ArmorCategory(name).IsValid() && name != ShieldProficiency {
+		return fmt.Errorf("%w: %s is not an armor category", ErrInvalidProficiency, name)
+	}
+	proficiency := Proficiency{Kind: kind, Name: name, Level: ProficiencyProficient, Source: source}
+	if c.findProficiency(proficiency) != -1 {
+		return fmt.Errorf("%w: %s", ErrProficiencyGranted, name)
+	}
+	c.Proficiencies = append(c.Proficiencies, proficiency)
+	return nil
+}
+
+// RevokeProficiency removes a chosen proficiency. Proficiencies that come from the rules can't be
+// revoked.
+func (c *Character) RevokeProficiency(kind ProficiencyKind, name string, source ProficiencySource) error {
+	index := c.findProficiency(Proficiency{Kind: kind, Name: name, Level: ProficiencyProficient, Source: source})
+	if index == -1 {
+		return fmt.Errorf("%w: %s", ErrProficiencyNotGranted, name)
+	}
+	c.Proficiencies = slices.Delete(c.Proficiencies, index, index+1)
+	return nil
+}
+
+// IsProficientWithWeapon checks the weapon against the character's weapon proficiencies, which
+// are either weapon categories or individual weapons.
+func (c *Character) IsProficientWithWeapon(item Item) bool {
+	for _, proficiency := range c.GetProficiencies(ProficiencyWeapon) {
+		if proficiency.Name == string(item.Weapon.Category) || strings.EqualFold(proficiency.Name, strings.TrimSpace(item.Name)) {
+			return true
+		}
+	}
+	return false
+}
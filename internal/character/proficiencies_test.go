@@ -0,0 +1,211 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"testing"
+)
+
+func TestSkillProficiencySources(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+
+	tests := []struct {
+		skill character.SkillName
+		level character.ProficiencyLevel
+	}{
+		// Acolyte background.
+		{character.SkillInsight, character.ProficiencyProficient},
+		// Half-Orc race.
+		{character.SkillIntimidation, character.ProficiencyProficient},
+		{character.SkillArcana, character.ProficiencyNone},
+	}
+	for _, test := range tests {
+		if level := char.GetSkillProficiency(test.skill); level != test.level {
+			t.Fatalf("got %s proficiency %s; want %s", test.skill, level, test.level)
+		}
+	}
+	// Charisma 8 gives -1, plus the proficiency bonus of 2.
+	if bonus := char.GetSkill(character.SkillIntimidation); bonus != 1 {
+		t.Fatalf("got Intimidation %d; want 1", bonus)
+	}
+}
+
+func TestExpertise(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassRogue, Level: 1})
+	if slots := char.GetExpertiseSlots(); slots != 2 {
+		t.Fatalf("got %d expertise slots; want 2", slots)
+	}
+
+	tests := []struct {
+		name string
+		kind character.ProficiencyKind
+		prof string
+		err  error
+	}{
+		{"not proficient", character.ProficiencySkill, string(character.SkillArcana), character.ErrNotProficient},
+		{"language", character.ProficiencyLanguage, "Orc", character.ErrUndefinedProficiencyKind},
+		{"skill", character.ProficiencySkill, string(character.SkillInsight), nil},
+		{"chosen twice", character.ProficiencySkill, string(character.SkillInsight), character.ErrExpertiseChosen},
+		{"tool", character.ProficiencyTool, "Thieves' Tools", nil},
+		{"no choices left", character.ProficiencySkill, string(character.SkillIntimidation), character.ErrExpertiseLimit},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := char.ChooseExpertise(test.kind, test.prof); !errors.Is(err, test.err) {
+				t.Fatalf("got %v; want %v", err, test.err)
+			}
+		})
+	}
+
+	// Wisdom 10 gives 0, plus twice the proficiency bonus of 2.
+	if bonus := char.GetSkill(character.SkillInsight); bonus != 4 {
+		t.Fatalf("got Insight %d with expertise; want 4", bonus)
+	}
+	if level := char.GetProficiencyLevel(character.ProficiencyTool, "thieves' tools"); level != character.ProficiencyExpertise {
+		t.Fatalf("got Thieves' Tools proficiency %s; want Expertise", level)
+	}
+
+	if err := char.RemoveExpertise(character.ProficiencySkill, string(character.SkillInsight)); err != nil {
+		t.Fatalf("unexpected error removing expertise: %v", err)
+	}
+	if err := char.RemoveExpertise(character.ProficiencySkill, string(character.SkillInsight)); !errors.Is(err, character.ErrExpertiseNotChosen) {
+		t.Fatalf("got %v; want %v", err, character.ErrExpertiseNotChosen)
+	}
+	if bonus := char.GetSkill(character.SkillInsight); bonus != 2 {
+		t.Fatalf("got Insight %d after removing expertise; want 2", bonus)
+	}
+}
+
+func TestJackOfAllTrades(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassBard, Level: 1})
+	if level := char.GetSkillProficiency(character.SkillArcana); level != character.ProficiencyNone {
+		t.Fatalf("got Arcana proficiency %s at bard 1; want None", level)
+	}
+
+	char.SetLevel(5)
+	// Intelligence 13 gives +1, plus half of the proficiency bonus of 3 rounded down.
+	if bonus := char.GetSkill(character.SkillArcana); bonus != 2 {
+		t.Fatalf("got Arcana %d with Jack of All Trades; want 2", bonus)
+	}
+	if level := char.GetSkillProficiency(character.SkillInsight); level != character.ProficiencyProficient {
+		t.Fatalf("expected Jack of All Trades not to replace proficiency, got %s", level)
+	}
+}
+
+func TestGrantProficiency(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassWizard, Level: 4})
+	longbow := character.Item{Name: "Longbow", Type: character.ItemWeapon, Weapon: character.WeaponStats{
+		Category: character.WeaponMartial, Damage: "1d8", DamageType: "piercing",
+	}}
+	if char.IsProficientWithWeapon(longbow) {
+		t.Fatal("did not expect a wizard to be proficient with a longbow")
+	}
+
+	tests := []struct {
+		name   string
+		kind   character.ProficiencyKind
+		prof   string
+		source character.ProficiencySource
+		err    error
+	}{
+		{"undefined kind", "Vehicle", "Cart", character.SourceBackground, character.ErrUndefinedProficiencyKind},
+		{"undefined source", character.ProficiencyLanguage, "Elvish", "Luck", character.ErrUndefinedProficiencySource},
+		{"undefined skill", character.ProficiencySkill, "Juggling", character.SourceRace, character.ErrUndefinedSkill},
+		{"armor", character.ProficiencyArmor, "Plate", character.SourceFeat, character.ErrInvalidProficiency},
+		{"language", character.ProficiencyLanguage, "Elvish", character.SourceBackground, nil},
+		{"granted twice", character.ProficiencyLanguage, "elvish", character.SourceBackground, character.ErrProficiencyGranted},
+		{"weapon", character.ProficiencyWeapon, "Longbow", character.SourceRace, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := char.GrantProficiency(test.kind, test.prof, test.source); !errors.Is(err, test.err) {
+				t.Fatalf("got %v; want %v", err, test.err)
+			}
+		})
+	}
+
+	languages := char.GetProficiencies(character.ProficiencyLanguage)
+	if len(languages) != 3 || languages[1].Name != "Elvish" {
+		t.Fatalf("got languages %v; want Common, Elvish and Orc", languages)
+	}
+	if !char.IsProficientWithWeapon(longbow) {
+		t.Fatal("expected the granted weapon proficiency to apply to attacks")
+	}
+
+	if err := char.RevokeProficiency(character.ProficiencyWeapon, "Longbow", character.SourceRace); err != nil {
+		t.Fatalf("unexpected error revoking proficiency: %v", err)
+	}
+	if err := char.RevokeProficiency(character.ProficiencyLanguage, "Orc", character.SourceRace); !errors.Is(err, character.ErrProficiencyNotGranted) {
+		t.Fatalf("got %v; want %v", err, character.ErrProficiencyNotGranted)
+	}
+}
+
+func TestArmorProficiencies(t *testing.T) {
+	char := newMulticlassCharacter(
+		character.ClassLevel{Class: character.ClassWizard, Level: 4},
+		character.ClassLevel{Class: character.ClassFighter, Level: 1},
+	)
+	armor := char.GetProficiencies(character.ProficiencyArmor)
+	if len(armor) != 3 || armor[0].Source != character.SourceClass {
+		t.Fatalf("got armor %v; want the light, medium and shield proficiencies of a fighter multiclass", armor)
+	}
+	if char.GetProficiencyLevel(character.ProficiencyArmor, string(character.ArmorHeavy)) != character.ProficiencyNone {
+		t.Fatal("did not expect multiclassing into fighter to grant heavy armor")
+	}
+
+	char = newMulticlassCharacter(character.ClassLevel{Class: character.ClassWizard, Level: 4})
+	err := char.ChooseAbilityScoreImprovement(character.AbilityScoreImprovement{
+		Class: character.ClassWizard, Level: 4, Feat: character.FeatLightlyArmored, Stats: []character.StatName{character.StatDexterity},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error choosing feat: %v", err)
+	}
+	armor = char.GetProficiencies(character.ProficiencyArmor)
+	if len(armor) != 1 || armor[0].Name != string(character.ArmorLight) || armor[0].Source != character.SourceFeat {
+		t.Fatalf("got armor %v; want light armor from Lightly Armored", armor)
+	}
+}
+
+func TestClassSkillChoices(t *testing.T) {
+	choices := character.ClassRogue.GetDefinition().GetSkillChoices()
+	if choices.Count != 4 || len(choices.Options) != 11 {
+		t.Fatalf("got rogue skill choices %d of %d; want 4 of 11", choices.Count, len(choices.Options))
+	}
+
+	tests := []struct {
+		name   string
+		skills []character.SkillName
+		err    error
+	}{
+		{"none yet", []character.SkillName{}, nil},
+		{"valid", []character.SkillName{character.SkillStealth, character.SkillPerception, character.SkillAcrobatics, character.SkillDeception}, nil},
+		{"not on the list", []character.SkillName{character.SkillArcana}, character.ErrInvalidSkillChoice},
+		{"granted by race", []character.SkillName{character.SkillIntimidation}, character.ErrInvalidSkillChoice},
+		{"granted by background", []character.SkillName{character.SkillInsight}, character.ErrInvalidSkillChoice},
+		{"chosen twice", []character.SkillName{character.SkillStealth, character.SkillStealth}, character.ErrInvalidSkillChoice},
+		{"too many", []character.SkillName{
+			character.SkillStealth, character.SkillPerception, character.SkillAcrobatics, character.SkillDeception, character.SkillAthletics,
+		}, character.ErrInvalidSkillChoice},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassRogue, Level: 1})
+			for _, skill := range test.skills {
+				char.Proficiencies = append(char.Proficiencies, character.Proficiency{
+					Kind: character.ProficiencySkill, Name: string(skill), Level: character.ProficiencyProficient, Source: character.SourceClass,
+				})
+			}
+			if err := char.ValidateClassSkills(); !errors.Is(err, test.err) {
+				t.Fatalf("got %v; want %v", err, test.err)
+			}
+			if test.err == nil && len(test.skills) > 0 && char.GetSkillProficiency(test.skills[0]) != character.ProficiencyProficient {
+				t.Fatalf("expected class skill %s to be proficient", test.skills[0])
+			}
+		})
+	}
+
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassRogue, Level: 1})
+	if err := char.GrantProficiency(character.ProficiencySkill, string(character.SkillStealth), character.SourceClass); !errors.Is(err, character.ErrInvalidProficiency) {
+		t.Fatalf("got %v; want %v", err, character.ErrInvalidProficiency)
+	}
+}
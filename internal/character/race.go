@@ -3,8 +3,7 @@ package character
 import (
 	"errors"
 	"fmt"
-
-	"gopkg.in/yaml.v3"
+	"slices"
 )
 
 var (
@@ -16,8 +15,8 @@ type RaceName string
 type SubraceName string
 
 type StatIncrease struct {
-	Stat   StatName
-	Amount int
+	Stat   StatName `yaml:"stat"`
+	Amount int      `yaml:"amount"`
 }
 
 const (
@@ -30,77 +29,25 @@ const (
 	RaceHalfElf    RaceName = "Half-Elf"
 	RaceHalfOrc    RaceName = "Half-Orc"
 	RaceTiefling   RaceName = "Tiefling"
-)
 
-func (r *RaceName) UnmarshalYAML(value *yaml.Node) error {
-	var str string
-	if err := value.Decode(&str); err != nil {
-		return err
-	}
-	switch RaceName(str) {
-	case RaceDwarf, RaceElf,
-		RaceHalfling, RaceHuman,
-		RaceDragonborn, RaceGnome,
-		RaceHalfElf, RaceHalfOrc, RaceTiefling:
-		*r = RaceName(str)
-		return nil
-	default:
-		return fmt.Errorf("an error ocurred while parsing race from yaml: %v -> %s", ErrUndefinedRace, str)
-	}
-}
+	// RaceOrc is a 2024 species.
+	RaceOrc RaceName = "Orc"
+)
 
-func (r RaceName) getMoveSpeed() int {
-	switch r {
-	case RaceElf, RaceHuman, RaceDragonborn, RaceHalfElf, RaceHalfOrc, RaceTiefling:
-		return 30
-	case RaceDwarf, RaceHalfling, RaceGnome:
-		return 25
-	default:
+func (r RaceName) getMoveSpeed(rules *Registry) int {
+	definition, ok := rules.Race(r)
+	if !ok {
 		return -1
 	}
+	return definition.Speed
 }
 
-func (r RaceName) getAbilityIncrease() ([]StatIncrease, error) {
-	switch r {
-	case RaceDwarf:
-		return []StatIncrease{{StatConstitution, 2}}, nil
-	case RaceElf, RaceHalfling:
-		return []StatIncrease{{StatDexterity, 2}}, nil
-	case RaceHuman:
-		return []StatIncrease{
-			{StatStrength, 1},
-			{StatCharisma, 1},
-			{StatConstitution, 1},
-			{StatDexterity, 1},
-			{StatIntelligence, 1},
-			{StatWisdom, 1},
-		}, nil
-	case RaceDragonborn:
-		return []StatIncrease{
-			{StatStrength, 2},
-			{StatCharisma, 1},
-		}, nil
-	case RaceGnome:
-		return []StatIncrease{
-			{StatIntelligence, 2},
-		}, nil
-	case RaceHalfElf:
-		return []StatIncrease{
-			{StatCharisma, 2},
-		}, nil
-	case RaceHalfOrc:
-		return []StatIncrease{
-			{StatStrength, 2},
-			{StatConstitution, 1},
-		}, nil
-	case RaceTiefling:
-		return []StatIncrease{
-			{StatIntelligence, 1},
-			{StatCharisma, 2},
-		}, nil
-	default:
-		return []StatIncrease{{StatStrength, 2}}, ErrUndefinedRace
+func (r RaceName) getAbilityIncrease(rules *Registry) ([]StatIncrease, error) {
+	definition, ok := rules.Race(r)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUndefinedRace, r)
 	}
+	return slices.Clone(definition.AbilityIncreases), nil
 }
 
 const (
@@ -116,34 +63,23 @@ const (
 	SubraceRockGnome     SubraceName = "Rock Gnome"
 )
 
-func (s SubraceName) getMoveSpeed() int {
-	switch s {
-	case SubraceWoodElf:
-		return 35
-	default:
+func (s SubraceName) getMoveSpeed(rules *Registry) int {
+	definition, ok := rules.Subrace(s)
+	if !ok || definition.Speed == 0 {
 		return -1
 	}
+	return definition.Speed
 }
 
-func (s SubraceName) getAbilityIncrease() ([]StatIncrease, error) {
-	switch s {
-	case SubraceHillDwarf, SubraceWoodElf:
-		return []StatIncrease{{StatWisdom, 1}}, nil
-	case SubraceMountainDwarf:
-		return []StatIncrease{{StatStrength, 2}}, nil
-	case SubraceHighElf:
-		return []StatIncrease{{StatIntelligence, 1}}, nil
-	case SubraceDrow, SubraceLightfoot:
-		return []StatIncrease{{StatCharisma, 1}}, nil
-	case SubraceStout, SubraceRockGnome:
-		return []StatIncrease{{StatConstitution, 1}}, nil
-	case SubraceForestGnome:
-		return []StatIncrease{{StatDexterity, 1}}, nil
-	case SubraceNone:
+func (s SubraceName) getAbilityIncrease(rules *Registry) ([]StatIncrease, error) {
+	if s == SubraceNone {
 		return nil, nil
-	default:
-		return nil, ErrUndefinedSubrace
 	}
+	definition, ok := rules.Subrace(s)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUndefinedSubrace, s)
+	}
+	return slices.Clone(definition.AbilityIncreases), nil
 }
 
 type Race struct {
@@ -151,15 +87,33 @@ type Race struct {
 	Subrace      SubraceName    `yaml:"subrace"`
 	MoveSpeed    int            `yaml:"move-speed"`
 	StatIncrease []StatIncrease `yaml:"stat-increase"`
+	StatChoices  []StatName     `yaml:"stat-choices"`
+	// Ancestry is the dragon a Dragonborn descends from.
+	Ancestry DraconicAncestry `yaml:"ancestry"`
+	// Ruleset is the version of the character the race belongs to, set by SetRuleset.
+	Ruleset RulesetVersion `yaml:"-"`
+	// OwnerId is the user who owns the character, set by SetOwner.
+	OwnerId int `yaml:"-"`
+}
+
+// rules returns the registry of the race's ruleset version and owner.
+func (r *Race) rules() *Registry {
+	return RulesForOwner(r.Ruleset, r.OwnerId)
+}
+
+// IsValid reports whether the rules of the race's ruleset version define it.
+func (r *Race) IsValid() bool {
+	_, ok := r.rules().Race(r.Type)
+	return ok
 }
 
 func (r *Race) GetMoveSpeed() int {
 	speed := -1
 	if r.Subrace != SubraceNone {
-		speed = r.Subrace.getMoveSpeed()
+		speed = r.Subrace.getMoveSpeed(r.rules())
 	}
 	if speed == -1 {
-		speed = r.Type.getMoveSpeed()
+		speed = r.Type.getMoveSpeed(r.rules())
 	}
 	if r.MoveSpeed != 0 || speed == -1 {
 		speed = r.MoveSpeed
@@ -169,12 +123,12 @@ func (r *Race) GetMoveSpeed() int {
 }
 
 func (r *Race) GetAbilityIncrease() ([]StatIncrease, error) {
-	increase, err := r.Type.getAbilityIncrease()
+	increase, err := r.Type.getAbilityIncrease(r.rules())
 	if err != nil {
 		return nil, err
 	}
 
-	if subraceIncrease, err := r.Subrace.getAbilityIncrease(); err != nil {
+	if subraceIncrease, err := r.Subrace.getAbilityIncrease(r.rules()); err != nil {
 		return nil, err
 	} else if subraceIncrease != nil {
 		increase = append(increase, subraceIncrease...)
@@ -182,3 +136,65 @@ func (r *Race) GetAbilityIncrease() ([]StatIncrease, error) {
 
 	return increase, nil
 }
+
+// GetStatChoiceCount returns how many StatYourChoice increases the race and subrace grant.
+func (r *Race) GetStatChoiceCount() int {
+	count := 0
+	for _, increase := range r.getSourcedIncreases() {
+		if increase.Stat == StatYourChoice {
+			count++
+		}
+	}
+	return count
+}
+
+// GetStatChoiceOptions returns the ability scores the StatYourChoice increases can go to, which
+// are those the race and subrace don't already raise.
+func (r *Race) GetStatChoiceOptions() []StatName {
+	increases := r.getSourcedIncreases()
+	return slices.DeleteFunc(slices.Clone(StatNames), func(stat StatName) bool {
+		return slices.ContainsFunc(increases, func(increase AbilityBonus) bool { return increase.Stat == stat })
+	})
+}
+
+// GetAbilityBonuses resolves the race and subrace increases into concrete bonuses.
+// Each StatYourChoice increase is replaced by the next entry in StatChoices, and is
+// skipped if the character has not made that choice yet.
+func (r *Race) GetAbilityBonuses() []AbilityBonus {
+	output := []AbilityBonus{}
+	choice := 0
+	for _, increase := range r.getSourcedIncreases() {
+		if increase.Stat == StatYourChoice {
+			if choice >= len(r.StatChoices) {
+				continue
+			}
+			increase.Stat = r.StatChoices[choice]
+			choice++
+			if !increase.Stat.IsValid() || increase.Stat == StatYourChoice {
+				continue
+			}
+		}
+		output = append(output, increase)
+	}
+	return output
+}
+
+// getSourcedIncreases tags each racial increase with the race or subrace that granted it.
+// Races that are not defined by the rules fall back to the increases stored on the character.
+func (r *Race) getSourcedIncreases() []AbilityBonus {
+	output := []AbilityBonus{}
+	raceIncrease, err := r.Type.getAbilityIncrease(r.rules())
+	if err != nil {
+		raceIncrease = r.StatIncrease
+	}
+	for _, increase := range raceIncrease {
+		output = append(output, AbilityBonus{Source: string(r.Type), Stat: increase.Stat, Amount: increase.Amount})
+	}
+
+	if subraceIncrease, err := r.Subrace.getAbilityIncrease(r.rules()); err == nil {
+		for _, increase := range subraceIncrease {
+			output = append(output, AbilityBonus{Source: string(r.Subrace), Stat: increase.Stat, Amount: increase.Amount})
+		}
+	}
+	return output
+}
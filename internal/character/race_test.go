@@ -14,17 +14,17 @@ func TestGetAbilityIncrease(t *testing.T) {
 	}{
 		// Dwarf
 		{character.RaceDwarf, character.SubraceNone, []character.StatIncrease{{character.StatConstitution, 2}}, false},
-		{character.RaceDwarf, character.SubraceHillDwarf, []character.StatIncrease{{character.StatWisdom, 1}}, false},
-		{character.RaceDwarf, character.SubraceMountainDwarf, []character.StatIncrease{{character.StatStrength, 2}}, false},
+		{character.RaceDwarf, character.SubraceHillDwarf, []character.StatIncrease{{character.StatConstitution, 2}, {character.StatWisdom, 1}}, false},
+		{character.RaceDwarf, character.SubraceMountainDwarf, []character.StatIncrease{{character.StatConstitution, 2}, {character.StatStrength, 2}}, false},
 		// Elf
 		{character.RaceElf, character.SubraceNone, []character.StatIncrease{{character.StatDexterity, 2}}, false},
-		{character.RaceElf, character.SubraceHighElf, []character.StatIncrease{{character.StatIntelligence, 1}}, false},
-		{character.RaceElf, character.SubraceWoodElf, []character.StatIncrease{{character.StatWisdom, 1}}, false},
-		{character.RaceElf, character.SubraceDrow, []character.StatIncrease{{character.StatCharisma, 1}}, false},
+		{character.RaceElf, character.SubraceHighElf, []character.StatIncrease{{character.StatDexterity, 2}, {character.StatIntelligence, 1}}, false},
+		{character.RaceElf, character.SubraceWoodElf, []character.StatIncrease{{character.StatDexterity, 2}, {character.StatWisdom, 1}}, false},
+		{character.RaceElf, character.SubraceDrow, []character.StatIncrease{{character.StatDexterity, 2}, {character.StatCharisma, 1}}, false},
 		// Halfling
 		{character.RaceHalfling, character.SubraceNone, []character.StatIncrease{{character.StatDexterity, 2}}, false},
-		{character.RaceHalfling, character.SubraceLightfoot, []character.StatIncrease{{character.StatCharisma, 1}}, false},
-		{character.RaceHalfling, character.SubraceStout, []character.StatIncrease{{character.StatConstitution, 1}}, false},
+		{character.RaceHalfling, character.SubraceLightfoot, []character.StatIncrease{{character.StatDexterity, 2}, {character.StatCharisma, 1}}, false},
+		{character.RaceHalfling, character.SubraceStout, []character.StatIncrease{{character.StatDexterity, 2}, {character.StatConstitution, 1}}, false},
 		// Human
 		{character.RaceHuman, character.SubraceNone, []character.StatIncrease{
 			{character.StatStrength, 1},
@@ -41,10 +41,14 @@ func TestGetAbilityIncrease(t *testing.T) {
 		}, false},
 		// Gnome
 		{character.RaceGnome, character.SubraceNone, []character.StatIncrease{{character.StatIntelligence, 2}}, false},
-		{character.RaceGnome, character.SubraceForestGnome, []character.StatIncrease{{character.StatDexterity, 1}}, false},
-		{character.RaceGnome, character.SubraceRockGnome, []character.StatIncrease{{character.StatConstitution, 1}}, false},
+		{character.RaceGnome, character.SubraceForestGnome, []character.StatIncrease{{character.StatIntelligence, 2}, {character.StatDexterity, 1}}, false},
+		{character.RaceGnome, character.SubraceRockGnome, []character.StatIncrease{{character.StatIntelligence, 2}, {character.StatConstitution, 1}}, false},
 		// Half-Elf
-		{character.RaceHalfElf, character.SubraceNone, []character.StatIncrease{{character.StatCharisma, 2}}, false},
+		{character.RaceHalfElf, character.SubraceNone, []character.StatIncrease{
+			{character.StatCharisma, 2},
+			{character.StatYourChoice, 1},
+			{character.StatYourChoice, 1},
+		}, false},
 		// Half-Orc
 		{character.RaceHalfOrc, character.SubraceNone, []character.StatIncrease{
 			{character.StatStrength, 2},
@@ -139,3 +143,53 @@ func TestGetMoveSpeed(t *testing.T) {
 		}
 	}
 }
+
+func TestGetAbilityBonuses(t *testing.T) {
+	tests := []struct {
+		Race     character.Race
+		Expected []character.AbilityBonus
+	}{
+		{
+			character.Race{Type: character.RaceDwarf, Subrace: character.SubraceHillDwarf},
+			[]character.AbilityBonus{
+				{Source: "Dwarf", Stat: character.StatConstitution, Amount: 2},
+				{Source: "Hill Dwarf", Stat: character.StatWisdom, Amount: 1},
+			},
+		},
+		{
+			character.Race{Type: character.RaceHalfElf, Subrace: character.SubraceNone, StatChoices: []character.StatName{character.StatStrength, character.StatDexterity}},
+			[]character.AbilityBonus{
+				{Source: "Half-Elf", Stat: character.StatCharisma, Amount: 2},
+				{Source: "Half-Elf", Stat: character.StatStrength, Amount: 1},
+				{Source: "Half-Elf", Stat: character.StatDexterity, Amount: 1},
+			},
+		},
+		// Unresolved choices are skipped
+		{
+			character.Race{Type: character.RaceHalfElf, Subrace: character.SubraceNone, StatChoices: []character.StatName{character.StatWisdom}},
+			[]character.AbilityBonus{
+				{Source: "Half-Elf", Stat: character.StatCharisma, Amount: 2},
+				{Source: "Half-Elf", Stat: character.StatWisdom, Amount: 1},
+			},
+		},
+		// Custom races use the stored increases
+		{
+			character.Race{Type: "Goblin", Subrace: character.SubraceNone, StatIncrease: []character.StatIncrease{{character.StatDexterity, 2}}},
+			[]character.AbilityBonus{
+				{Source: "Goblin", Stat: character.StatDexterity, Amount: 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		bonuses := test.Race.GetAbilityBonuses()
+		if len(bonuses) != len(test.Expected) {
+			t.Fatalf("%s-%s got incorrect length result: %v; want %v", test.Race.Type, test.Race.Subrace, bonuses, test.Expected)
+		}
+		for i := range bonuses {
+			if bonuses[i] != test.Expected[i] {
+				t.Fatalf("%s-%s got incorrect bonus: %v; want %v", test.Race.Type, test.Race.Subrace, bonuses[i], test.Expected[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrUndefinedDraconicAncestry = errors.New("an undefined draconic ancestry was defined")
+
+type Size string
+
+const (
+	SizeTiny   Size = "Tiny"
+	SizeSmall  Size = "Small"
+	SizeMedium Size = "Medium"
+	SizeLarge  Size = "Large"
+)
+
+type DraconicAncestry string
+
+const (
+	AncestryBlack  DraconicAncestry = "Black"
+	AncestryBlue   DraconicAncestry = "Blue"
+	AncestryBrass  DraconicAncestry = "Brass"
+	AncestryBronze DraconicAncestry = "Bronze"
+	AncestryCopper DraconicAncestry = "Copper"
+	AncestryGold   DraconicAncestry = "Gold"
+	AncestryGreen  DraconicAncestry = "Green"
+	AncestryRed    DraconicAncestry = "Red"
+	AncestrySilver DraconicAncestry = "Silver"
+	AncestryWhite  DraconicAncestry = "White"
+)
+
+var DraconicAncestries = []DraconicAncestry{
+	AncestryBlack, AncestryBlue, AncestryBrass, AncestryBronze, AncestryCopper,
+	AncestryGold, AncestryGreen, AncestryRed, AncestrySilver, AncestryWhite,
+}
+
+const (
+	breathLine = "5 by 30 ft. line"
+	breathCone = "15 ft. cone"
+)
+
+// draconicAncestry is the damage type, area and saving throw a dragon ancestry gives the breath weapon.
+type draconicAncestry struct {
+	DamageType DamageType
+	Area       string
+	Save       StatName
+}
+
+var draconicAncestries = map[DraconicAncestry]draconicAncestry{
+	AncestryBlack:  {DamageAcid, breathLine, StatDexterity},
+	AncestryBlue:   {DamageLightning, breathLine, StatDexterity},
+	AncestryBrass:  {DamageFire, breathLine, StatDexterity},
+	AncestryBronze: {DamageLightning, breathLine, StatDexterity},
+	AncestryCopper: {DamageAcid, breathLine, StatDexterity},
+	AncestryGold:   {DamageFire, breathCone, StatDexterity},
+	AncestryGreen:  {DamagePoison, breathCone, StatConstitution},
+	AncestryRed:    {DamageFire, breathCone, StatDexterity},
+	AncestrySilver: {DamageCold, breathCone, StatConstitution},
+	AncestryWhite:  {DamageCold, breathCone, StatConstitution},
+}
+
+func (a DraconicAncestry) IsValid() bool {
+	_, ok := draconicAncestries[a]
+	return ok
+}
+
+// breathWeaponTrait is the Dragonborn trait whose scaling holds the breath weapon damage.
+const breathWeaponTrait = "Breath Weapon"
+
+// BreathWeapon is the Dragonborn breath weapon at the character's current level.
+type BreathWeapon struct {
+	Damage     string
+	DamageType DamageType
+	Area       string
+	Save       StatName
+	DC         int
+}
+
+// InnateSpell is a spell a race can cast without a spell slot. Leveled innate spells can be cast
+// once per long rest.
+type InnateSpell struct {
+	Name string `yaml:"name"`
+	// SpellLevel is the level the spell is cast at, 0 for cantrips.
+	SpellLevel int `yaml:"spell-level"`
+	// Level is the character level the spell is gained at.
+	Level int      `yaml:"level"`
+	Stat  StatName `yaml:"stat"`
+}
+
+func (s InnateSpell) IsCantrip() bool {
+	return s.SpellLevel == 0
+}
+
+func (s InnateSpell) toFeature() ClassFeature {
+	return ClassFeature{
+		Name:        s.Name,
+		Level:       s.Level,
+		Description: fmt.Sprintf("Cast %s as a level %d spell without a spell slot, using %s.", s.Name, s.SpellLevel, s.Stat),
+		Uses:        &FeatureUses{ByLevel: map[int]int{s.Level: 1}, Recharge: RechargeLongRest},
+	}
+}
+
+// GetSize returns the size of the race, or Medium for races the rules don't define.
+func (r *Race) GetSize() Size {
+	definition, ok := r.rules().Race(r.Type)
+	if !ok || definition.Size == "" {
+		return SizeMedium
+	}
+	return definition.Size
+}
+
+// getRules returns the rules of the race followed by those of its subrace, leaving out any the
+// registry doesn't define.
+func (r *Race) getRules() []RaceRules {
+	output := []RaceRules{}
+	if definition, ok := r.rules().Race(r.Type); ok {
+		output = append(output, definition.RaceRules)
+	}
+	if definition, ok := r.rules().Subrace(r.Subrace); ok {
+		output = append(output, definition.RaceRules)
+	}
+	return output
+}
+
+func (r *Race) getTrait(name string) (ClassFeature, bool) {
+	for _, rules := range r.getRules() {
+		for _, trait := range rules.Traits {
+			if trait.Name == name {
+				return trait, true
+			}
+		}
+	}
+	return ClassFeature{}, false
+}
+
+// GetDarkvision returns the range of the race's darkvision in feet, or 0 without darkvision.
+func (r *Race) GetDarkvision() int {
+	darkvision := 0
+	for _, rules := range r.getRules() {
+		darkvision = max(darkvision, rules.Darkvision)
+	}
+	return darkvision
+}
+
+// GetDamageResistances returns the damage types the race and subrace are resistant to. A Dragonborn
+// resists the damage type of their ancestry once it is chosen.
+func (r *Race) GetDamageResistances() []DamageType {
+	resistances := []DamageType{}
+	for _, rules := range r.getRules() {
+		resistances = append(resistances, rules.Resistances...)
+	}
+	if ancestry, ok := draconicAncestries[r.Ancestry]; ok && r.Type == RaceDragonborn {
+		resistances = append(resistances, ancestry.DamageType)
+	}
+	return resistances
+}
+
+func (r *Race) getInnateSpells() []InnateSpell {
+	spells := []InnateSpell{}
+	for _, rules := range r.getRules() {
+		spells = append(spells, rules.InnateSpells...)
+	}
+	return spells
+}
+
+// getInnateSpellTraits returns a limited-use trait for every leveled innate spell of the race.
+func (r *Race) getInnateSpellTraits() []ClassFeature {
+	traits := []ClassFeature{}
+	for _, spell := range r.getInnateSpells() {
+		if !spell.IsCantrip() {
+			traits = append(traits, spell.toFeature())
+		}
+	}
+	return traits
+}
+
+func (c *Character) IsResistantTo(damageType DamageType) bool {
+	return slices.Contains(c.Race.GetDamageResistances(), damageType)
+}
+
+// GetBreathWeapon returns the Dragonborn breath weapon, or nil if the character isn't a Dragonborn
+// or hasn't chosen an ancestry.
+func (c *Character) GetBreathWeapon() *BreathWeapon {
+	ancestry, ok := draconicAncestries[c.Race.Ancestry]
+	if c.Race.Type != RaceDragonborn || !ok {
+		return nil
+	}
+	trait, _ := c.Race.getTrait(breathWeaponTrait)
+	return &BreathWeapon{
+		Damage:     getByLevel(trait.Scaling, c.Level, ""),
+		DamageType: ancestry.DamageType,
+		Area:       ancestry.Area,
+		Save:       ancestry.Save,
+		DC:         8 + c.GetAbilityScore(StatConstitution) + c.GetProficiencyBonus(),
+	}
+}
+
+// GetInnateSpells returns the racial spells the character can cast at their current level.
+func (c *Character) GetInnateSpells() []InnateSpell {
+	spells := []InnateSpell{}
+	for _, spell := range c.Race.getInnateSpells() {
+		if spell.Level <= c.Level {
+			spells = append(spells, spell)
+		}
+	}
+	return spells
+}
@@ -0,0 +1,119 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRacialSenses(t *testing.T) {
+	tests := []struct {
+		race       character.Race
+		size       character.Size
+		darkvision int
+	}{
+		{character.Race{Type: character.RaceHuman, Subrace: character.SubraceNone}, character.SizeMedium, 0},
+		{character.Race{Type: character.RaceElf, Subrace: character.SubraceHighElf}, character.SizeMedium, 60},
+		{character.Race{Type: character.RaceElf, Subrace: character.SubraceDrow}, character.SizeMedium, 120},
+		{character.Race{Type: character.RaceGnome, Subrace: character.SubraceRockGnome}, character.SizeSmall, 60},
+		{character.Race{Type: character.RaceHalfling, Subrace: character.SubraceLightfoot}, character.SizeSmall, 0},
+	}
+	for _, test := range tests {
+		if size := test.race.GetSize(); size != test.size {
+			t.Fatalf("got %s size %s; want %s", test.race.Type, size, test.size)
+		}
+		if darkvision := test.race.GetDarkvision(); darkvision != test.darkvision {
+			t.Fatalf("got %s darkvision %d; want %d", test.race.Subrace, darkvision, test.darkvision)
+		}
+	}
+}
+
+func TestDamageResistance(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	char.Race = character.Race{Type: character.RaceDwarf, Subrace: character.SubraceHillDwarf}
+
+	tests := []struct {
+		damageType character.DamageType
+		amount     int
+		err        error
+	}{
+		{character.DamagePoison, 3, nil},
+		{character.DamageFire, 7, nil},
+		{"", 7, nil},
+		{"sonic", 7, character.ErrUndefinedDamageType},
+	}
+	for _, test := range tests {
+		amount, err := char.ResistDamage(7, test.damageType)
+		if !errors.Is(err, test.err) {
+			t.Fatalf("got %v; want %v", err, test.err)
+		}
+		if amount != test.amount {
+			t.Fatalf("got %d %s damage after resistance; want %d", amount, test.damageType, test.amount)
+		}
+	}
+
+	char.Race = character.Race{Type: character.RaceTiefling, Subrace: character.SubraceNone}
+	if !char.IsResistantTo(character.DamageFire) || char.IsResistantTo(character.DamagePoison) {
+		t.Fatalf("got resistances %v for a tiefling; want fire", char.Race.GetDamageResistances())
+	}
+}
+
+func TestBreathWeapon(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	char.Race = character.Race{Type: character.RaceDragonborn, Subrace: character.SubraceNone}
+	if breath := char.GetBreathWeapon(); breath != nil {
+		t.Fatalf("expected no breath weapon without an ancestry, got %+v", breath)
+	}
+
+	char.Race.Ancestry = character.AncestrySilver
+	// Constitution 14 gives +2, plus the proficiency bonus of 2.
+	want := character.BreathWeapon{Damage: "2d6", DamageType: character.DamageCold, Area: "15 ft. cone", Save: character.StatConstitution, DC: 12}
+	if breath := char.GetBreathWeapon(); breath == nil || *breath != want {
+		t.Fatalf("got breath weapon %+v; want %+v", breath, want)
+	}
+	if !char.IsResistantTo(character.DamageCold) {
+		t.Fatal("expected a silver dragonborn to resist cold damage")
+	}
+
+	char.Classes[0].Level = 11
+	char.Level = 11
+	if breath := char.GetBreathWeapon(); breath.Damage != "4d6" || breath.DC != 14 {
+		t.Fatalf("got breath weapon %s DC %d at level 11; want 4d6 DC 14", breath.Damage, breath.DC)
+	}
+
+	if err := char.UseFeature("Breath Weapon"); err != nil {
+		t.Fatalf("unexpected error using breath weapon: %v", err)
+	}
+	if err := char.UseFeature("Breath Weapon"); !errors.Is(err, character.ErrNoFeatureUsesRemaining) {
+		t.Fatalf("got %v; want %v", err, character.ErrNoFeatureUsesRemaining)
+	}
+	char.ShortRest()
+	if feature, _ := char.GetActiveFeature("Breath Weapon"); feature.Remaining() != 1 || feature.Race != character.RaceDragonborn {
+		t.Fatalf("expected a short rest to restore the breath weapon, got %+v", feature)
+	}
+}
+
+func TestInnateSpells(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 3})
+	char.Race = character.Race{Type: character.RaceTiefling, Subrace: character.SubraceNone}
+
+	var names []string
+	for _, spell := range char.GetInnateSpells() {
+		names = append(names, spell.Name)
+	}
+	if !slices.Equal(names, []string{"Thaumaturgy", "Hellish Rebuke"}) {
+		t.Fatalf("got innate spells %v at level 3; want Thaumaturgy and Hellish Rebuke", names)
+	}
+
+	feature, ok := char.GetActiveFeature("Hellish Rebuke")
+	if !ok || feature.MaxUses != 1 || feature.Recharge != character.RechargeLongRest {
+		t.Fatalf("expected Hellish Rebuke to be usable once per long rest, got %+v", feature)
+	}
+	if _, ok := char.GetActiveFeature("Darkness"); ok {
+		t.Fatal("did not expect Darkness before level 5")
+	}
+	if _, ok := char.GetActiveFeature("Thaumaturgy"); ok {
+		t.Fatal("did not expect a cantrip to have limited uses")
+	}
+}
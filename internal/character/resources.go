@@ -0,0 +1,156 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrUndefinedHitDie     = errors.New("the character does not have hit dice of that size")
+	ErrNoHitDiceRemaining  = errors.New("no hit dice of that size remain")
+	ErrInvalidHitDiceCount = errors.New("hit dice to spend must not be negative")
+	ErrNoHitPointsToRest   = errors.New("a long rest needs at least 1 hit point at its start")
+)
+
+type ResourceKind string
+
+const (
+	ResourceFeature   ResourceKind = "Feature"
+	ResourceHitDice   ResourceKind = "Hit Dice"
+	ResourceSpellSlot ResourceKind = "Spell Slot"
+)
+
+// Resource is anything the character spends and regains by resting.
+type Resource struct {
+	Name     string
+	Kind     ResourceKind
+	Maximum  int
+	Expended int
+	Recharge Recharge
+}
+
+func (r Resource) Remaining() int {
+	return max(r.Maximum-r.Expended, 0)
+}
+
+// GetResources lists the character's limited-use features, hit dice and spell slots.
+func (c *Character) GetResources() []Resource {
+	resources := []Resource{}
+	for _, feature := range c.GetActiveFeatures() {
+		if !feature.IsLimited() {
+			continue
+		}
+		resources = append(resources, Resource{
+			Name:     feature.Name,
+			Kind:     ResourceFeature,
+			Maximum:  feature.MaxUses,
+			Expended: feature.Expended,
+			Recharge: feature.Recharge,
+		})
+	}
+	for _, pool := range c.GetHitDice() {
+		resources = append(resources, Resource{
+			Name:     fmt.Sprintf("d%d Hit Dice", pool.Die),
+			Kind:     ResourceHitDice,
+			Maximum:  pool.Count,
+			Expended: pool.Expended,
+			Recharge: RechargeLongRest,
+		})
+	}
+	for _, slots := range c.GetSpellSlots() {
+		resource := Resource{
+			Name:     fmt.Sprintf("Level %d Spell Slots", slots.Level),
+			Kind:     ResourceSpellSlot,
+			Maximum:  slots.Maximum,
+			Expended: slots.Expended,
+			Recharge: RechargeLongRest,
+		}
+		if slots.Pact {
+			resource.Name = fmt.Sprintf("Level %d Pact Slots", slots.Level)
+			resource.Recharge = RechargeShortRest
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+func (c *Character) HasHitDie(die HitDie) bool {
+	return slices.ContainsFunc(c.GetHitDice(), func(pool HitDicePool) bool { return pool.Die == die })
+}
+
+// SpendHitDie spends one hit die of the given size during a short rest. The roll is the result of
+// the die; the character regains that plus their Constitution modifier, to a minimum of 0.
+func (c *Character) SpendHitDie(die HitDie, roll int) (int, error) {
+	if c.IsDead() {
+		return 0, ErrCharacterDead
+	}
+	pools := c.GetHitDice()
+	index := slices.IndexFunc(pools, func(pool HitDicePool) bool { return pool.Die == die })
+	if index == -1 {
+		return 0, fmt.Errorf("%w: d%d", ErrUndefinedHitDie, die)
+	}
+	if pools[index].Remaining() == 0 {
+		return 0, fmt.Errorf("%w: d%d", ErrNoHitDiceRemaining, die)
+	}
+	if roll < 1 || roll > int(die) {
+		return 0, fmt.Errorf("%w: %d on a d%d", ErrInvalidHitDieRoll, roll, die)
+	}
+
+	if c.ExpendedHitDice == nil {
+		c.ExpendedHitDice = make(map[HitDie]int)
+	}
+	c.ExpendedHitDice[die]++
+
+	before := c.CurrentHealthPoints
+	c.CurrentHealthPoints = min(c.CurrentHealthPoints+max(roll+c.GetAbilityScore(StatConstitution), 0), c.GetMaxHealthPoints())
+	if c.CurrentHealthPoints > 0 {
+		c.resetDeathSaves()
+	}
+	return c.CurrentHealthPoints - before, nil
+}
+
+// ShortRest regains the features that recharge on a short rest and expended pact slots.
+// Hit dice are spent separately with SpendHitDie.
+func (c *Character) ShortRest() {
+	c.restoreFeatures(RechargeShortRest)
+	c.ExpendedPactSlots = 0
+}
+
+// LongRest regains all hit points, spell slots and feature uses, and up to half the character's
+// total level in hit dice, largest dice first. Temporary hit points are lost and exhaustion is
+// reduced by one level. A dead character can't rest, and one at 0 hit points gets nothing from it.
+func (c *Character) LongRest() error {
+	if c.IsDead() {
+		return ErrCharacterDead
+	}
+	if c.CurrentHealthPoints == 0 {
+		return ErrNoHitPointsToRest
+	}
+	c.ExhaustionLevel = max(c.ExhaustionLevel-1, 0)
+	c.restoreFeatures(RechargeShortRest, RechargeLongRest)
+	c.ExpendedSpellSlots = [9]int{}
+	c.ExpendedPactSlots = 0
+	c.CurrentHealthPoints = c.GetMaxHealthPoints()
+	c.TemporaryHitPoints = 0
+	c.resetDeathSaves()
+
+	regained := max(c.Level/2, 1)
+	for _, pool := range c.GetHitDice() {
+		restored := min(pool.Expended, regained)
+		if restored == 0 {
+			continue
+		}
+		c.ExpendedHitDice[pool.Die] = pool.Expended - restored
+		regained -= restored
+	}
+	return nil
+}
+
+func (c *Character) restoreFeatures(recharges ...Recharge) {
+	for _, feature := range c.GetActiveFeatures() {
+		if feature.IsLimited() && slices.Contains(recharges, feature.Recharge) {
+			c.setExpendedFeatureUses(feature.Name, 0)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"testing"
+)
+
+func TestSpendHitDie(t *testing.T) {
+	char := newMulticlassCharacter(
+		character.ClassLevel{Class: character.ClassFighter, Level: 2},
+		character.ClassLevel{Class: character.ClassWizard, Level: 1},
+	)
+	char.CurrentHealthPoints = 5
+
+	// Half-Orc Constitution 15 gives +2 per die.
+	healed, err := char.SpendHitDie(character.HitDieD10, 6)
+	if err != nil {
+		t.Fatalf("unexpected error spending hit die: %v", err)
+	}
+	if healed != 8 || char.CurrentHealthPoints != 13 {
+		t.Fatalf("got %d healed to %d; want 8 healed to 13", healed, char.CurrentHealthPoints)
+	}
+
+	if _, err := char.SpendHitDie(character.HitDieD12, 6); !errors.Is(err, character.ErrUndefinedHitDie) {
+		t.Fatalf("got %v; want %v", err, character.ErrUndefinedHitDie)
+	}
+	if _, err := char.SpendHitDie(character.HitDieD6, 7); !errors.Is(err, character.ErrInvalidHitDieRoll) {
+		t.Fatalf("got %v; want %v", err, character.ErrInvalidHitDieRoll)
+	}
+	if _, err := char.SpendHitDie(character.HitDieD6, 6); err != nil {
+		t.Fatalf("unexpected error spending hit die: %v", err)
+	}
+	if _, err := char.SpendHitDie(character.HitDieD6, 6); !errors.Is(err, character.ErrNoHitDiceRemaining) {
+		t.Fatalf("got %v; want %v", err, character.ErrNoHitDiceRemaining)
+	}
+	if char.CurrentHealthPoints != 21 {
+		t.Fatalf("got %d hit points; want 21", char.CurrentHealthPoints)
+	}
+
+	char.ExpendedHitDice[character.HitDieD10] = 0
+	char.CurrentHealthPoints = char.GetMaxHealthPoints() - 1
+	if healed, _ := char.SpendHitDie(character.HitDieD10, 10); healed != 1 {
+		t.Fatalf("healing went past maximum: healed %d", healed)
+	}
+}
+
+func TestRests(t *testing.T) {
+	char := newMulticlassCharacter(
+		character.ClassLevel{Class: character.ClassFighter, Level: 9},
+		character.ClassLevel{Class: character.ClassWarlock, Level: 2},
+	)
+	char.StatBlock.Charisma = 13
+	char.CurrentHealthPoints = 1
+	for _, feature := range []string{"Second Wind", "Action Surge", "Indomitable"} {
+		if err := char.UseFeature(feature); err != nil {
+			t.Fatalf("unexpected error using %s: %v", feature, err)
+		}
+	}
+	if err := char.ExpendSpellSlot(1, true); err != nil {
+		t.Fatalf("unexpected error expending pact slot: %v", err)
+	}
+	char.ExpendedHitDice = map[character.HitDie]int{character.HitDieD10: 9, character.HitDieD8: 2}
+
+	char.ShortRest()
+	if remaining := getResource(t, char, "Action Surge").Remaining(); remaining != 1 {
+		t.Fatalf("expected Action Surge back after a short rest, got %d", remaining)
+	}
+	if remaining := getResource(t, char, "Indomitable").Remaining(); remaining != 0 {
+		t.Fatalf("did not expect Indomitable back after a short rest, got %d", remaining)
+	}
+	if remaining := getResource(t, char, "Level 1 Pact Slots").Remaining(); remaining != 2 {
+		t.Fatalf("expected pact slot back after a short rest, got %d", remaining)
+	}
+	if char.CurrentHealthPoints != 1 {
+		t.Fatalf("short rest should not restore hit points, got %d", char.CurrentHealthPoints)
+	}
+
+	if err := char.LongRest(); err != nil {
+		t.Fatalf("unexpected error taking a long rest: %v", err)
+	}
+	if remaining := getResource(t, char, "Indomitable").Remaining(); remaining != 1 {
+		t.Fatalf("expected Indomitable back after a long rest, got %d", remaining)
+	}
+	if char.CurrentHealthPoints != char.GetMaxHealthPoints() {
+		t.Fatalf("got %d hit points after a long rest; want %d", char.CurrentHealthPoints, char.GetMaxHealthPoints())
+	}
+	// Level 11 regains 5 hit dice, largest first.
+	if expended := getResource(t, char, "d10 Hit Dice").Expended; expended != 4 {
+		t.Fatalf("got %d d10 hit dice expended; want 4", expended)
+	}
+	if expended := getResource(t, char, "d8 Hit Dice").Expended; expended != 2 {
+		t.Fatalf("got %d d8 hit dice expended; want 2", expended)
+	}
+}
+
+func TestRestsDoNotReviveTheDead(t *testing.T) {
+	newDamagedCharacter := func() *character.Character {
+		char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 3})
+		char.ExpendedHitDice = map[character.HitDie]int{character.HitDieD10: 1}
+		return char
+	}
+
+	char := newDamagedCharacter()
+	char.CurrentHealthPoints = 0
+	char.DeathSaveFailures = character.MaxDeathSaves
+	if err := char.LongRest(); !errors.Is(err, character.ErrCharacterDead) {
+		t.Fatalf("got %v taking a long rest with 3 failed death saves; want %v", err, character.ErrCharacterDead)
+	}
+	if _, err := char.SpendHitDie(character.HitDieD10, 6); !errors.Is(err, character.ErrCharacterDead) {
+		t.Fatalf("got %v spending a hit die with 3 failed death saves; want %v", err, character.ErrCharacterDead)
+	}
+	if char.CurrentHealthPoints != 0 || char.DeathSaveFailures != character.MaxDeathSaves || !char.IsDead() {
+		t.Fatalf("got %d hit points and %d failed death saves; want the character to stay dead", char.CurrentHealthPoints, char.DeathSaveFailures)
+	}
+
+	char = newDamagedCharacter()
+	char.CurrentHealthPoints = 5
+	if err := char.SetExhaustionLevel(character.MaxExhaustionLevel); err != nil {
+		t.Fatalf("unexpected error setting exhaustion: %v", err)
+	}
+	if err := char.LongRest(); !errors.Is(err, character.ErrCharacterDead) {
+		t.Fatalf("got %v taking a long rest at exhaustion 6; want %v", err, character.ErrCharacterDead)
+	}
+	if char.ExhaustionLevel != character.MaxExhaustionLevel || !char.IsDead() {
+		t.Fatalf("got exhaustion %d after a long rest; want the character to stay dead at 6", char.ExhaustionLevel)
+	}
+
+	// A dying or stable character has to be healed before a long rest does anything.
+	char = newDamagedCharacter()
+	char.CurrentHealthPoints = 0
+	char.DeathSaveSuccesses = character.MaxDeathSaves
+	if err := char.LongRest(); !errors.Is(err, character.ErrNoHitPointsToRest) {
+		t.Fatalf("got %v taking a long rest at 0 hit points; want %v", err, character.ErrNoHitPointsToRest)
+	}
+	if char.CurrentHealthPoints != 0 || char.ExpendedHitDice[character.HitDieD10] != 1 {
+		t.Fatalf("got %d hit points and %d hit dice expended; want the rest to change nothing", char.CurrentHealthPoints, char.ExpendedHitDice[character.HitDieD10])
+	}
+}
+
+func getResource(t *testing.T, char *character.Character, name string) character.Resource {
+	t.Helper()
+	for _, resource := range char.GetResources() {
+		if resource.Name == name {
+			return resource
+		}
+	}
+	t.Fatalf("resource %s not found", name)
+	return character.Resource{}
+}
@@ -0,0 +1,430 @@
+package character
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"slices"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidContent = errors.New("rules content is invalid")
+
+// srdContent is the SRD content every registry starts from. Skills and classes at its root are
+// shared by every ruleset; races and backgrounds live in a directory per ruleset version.
+//
+//go:embed content/srd
+var srdContent embed.FS
+
+// SkillDefinition is the rules entry for a skill and the ability it is rolled with.
+type SkillDefinition struct {
+	Name    SkillName `yaml:"name"`
+	Ability StatName  `yaml:"ability"`
+}
+
+// RaceRules are what a race or subrace grants. A subrace adds to its race, and its speed replaces
+// the race's speed when set.
+type RaceRules struct {
+	Speed            int            `yaml:"speed,omitempty"`
+	Darkvision       int            `yaml:"darkvision,omitempty"`
+	AbilityIncreases []StatIncrease `yaml:"ability-increases,omitempty"`
+	Languages        []string       `yaml:"languages,omitempty"`
+	Skills           []SkillName    `yaml:"skills,omitempty"`
+	Armor            []string       `yaml:"armor,omitempty"`
+	Weapons          []string       `yaml:"weapons,omitempty"`
+	Tools            []string       `yaml:"tools,omitempty"`
+	Resistances      []DamageType   `yaml:"resistances,omitempty"`
+	InnateSpells     []InnateSpell  `yaml:"innate-spells,omitempty"`
+	// Traits are racial features. They use the character level rather than a class level.
+	Traits []ClassFeature `yaml:"traits,omitempty"`
+}
+
+// RaceDefinition is the rules entry for a race and the subraces it can take.
+type RaceDefinition struct {
+	Name      RaceName `yaml:"name"`
+	Size      Size     `yaml:"size"`
+	RaceRules `yaml:",inline"`
+	Subraces  []SubraceDefinition `yaml:"subraces,omitempty"`
+}
+
+type SubraceDefinition struct {
+	Name SubraceName `yaml:"name"`
+	// Race is the race the subrace belongs to. It is only written for subraces listed on their own,
+	// since a subrace nested under a race takes that race.
+	Race      RaceName `yaml:"race,omitempty"`
+	RaceRules `yaml:",inline"`
+}
+
+// BackgroundDefinition is the rules entry for a background and the proficiencies it grants. 2024
+// backgrounds also list the abilities their increases can go to and the origin feat they grant,
+// and 2014 backgrounds the personality tables characters roll on.
+type BackgroundDefinition struct {
+	Name        BackgroundName    `yaml:"name"`
+	Skills      []SkillName       `yaml:"skills"`
+	Tools       []string          `yaml:"tools,omitempty"`
+	Abilities   []StatName        `yaml:"abilities,omitempty"`
+	Feat        FeatName          `yaml:"feat,omitempty"`
+	Personality PersonalityTables `yaml:"personality,omitempty"`
+}
+
+// ContentPack is one YAML file of rules content.
+type ContentPack struct {
+	Skills []SkillDefinition `yaml:"skills,omitempty"`
+	Races  []RaceDefinition  `yaml:"races,omitempty"`
+	// Subraces adds subraces to races defined elsewhere, such as a homebrew subrace of an SRD race.
+	Subraces    []SubraceDefinition    `yaml:"subraces,omitempty"`
+	Backgrounds []BackgroundDefinition `yaml:"backgrounds,omitempty"`
+	Classes     []ClassDefinition      `yaml:"classes,omitempty"`
+}
+
+// catalog keeps definitions in the order they were first added, so option lists stay stable when a
+// later pack replaces an entry.
+type catalog[K ~string, V any] struct {
+	names   []K
+	entries map[K]V
+}
+
+func (c *catalog[K, V]) add(name K, entry V) {
+	if c.entries == nil {
+		c.entries = map[K]V{}
+	}
+	if _, ok := c.entries[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.entries[name] = entry
+}
+
+func (c *catalog[K, V]) clone() catalog[K, V] {
+	return catalog[K, V]{names: slices.Clone(c.names), entries: maps.Clone(c.entries)}
+}
+
+func (c *catalog[K, V]) get(name K) (V, bool) {
+	entry, ok := c.entries[name]
+	return entry, ok
+}
+
+// Registry holds the skills, races, backgrounds and classes loaded from content packs.
+type Registry struct {
+	skills      catalog[SkillName, SkillDefinition]
+	races       catalog[RaceName, RaceDefinition]
+	subraces    catalog[SubraceName, SubraceDefinition]
+	backgrounds catalog[BackgroundName, BackgroundDefinition]
+	classes     catalog[ClassName, ClassDefinition]
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// AddPack adds the pack's definitions to the registry. A definition replaces any earlier one with
+// the same name.
+func (r *Registry) AddPack(pack ContentPack) {
+	for _, skill := range pack.Skills {
+		r.skills.add(skill.Name, skill)
+	}
+	for _, race := range pack.Races {
+		r.races.add(race.Name, race)
+		for _, subrace := range race.Subraces {
+			subrace.Race = race.Name
+			r.subraces.add(subrace.Name, subrace)
+		}
+	}
+	for _, subrace := range pack.Subraces {
+		r.subraces.add(subrace.Name, subrace)
+	}
+	for _, background := range pack.Backgrounds {
+		r.backgrounds.add(background.Name, background)
+	}
+	for _, class := range pack.Classes {
+		r.classes.add(class.Name, class)
+	}
+}
+
+// Extend returns a copy of the registry with the packs added, leaving the registry itself unchanged.
+func (r *Registry) Extend(packs ...ContentPack) *Registry {
+	registry := &Registry{
+		skills:      r.skills.clone(),
+		races:       r.races.clone(),
+		subraces:    r.subraces.clone(),
+		backgrounds: r.backgrounds.clone(),
+		classes:     r.classes.clone(),
+	}
+	for _, pack := range packs {
+		registry.AddPack(pack)
+	}
+	return registry
+}
+
+// LoadPack decodes a YAML content pack and adds it to the registry.
+func (r *Registry) LoadPack(data []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	pack := ContentPack{}
+	if err := decoder.Decode(&pack); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %v", ErrInvalidContent, err)
+	}
+	r.AddPack(pack)
+	return nil
+}
+
+// LoadFS adds every .yaml content pack at the root of fsys, in file name order.
+func (r *Registry) LoadFS(fsys fs.FS) error {
+	names, err := fs.Glob(fsys, "*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to list content packs: %w", err)
+	}
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("failed to read content pack %s: %w", name, err)
+		}
+		if err := r.LoadPack(data); err != nil {
+			return fmt.Errorf("failed to load content pack %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that every definition is complete and only refers to skills, races and stats
+// the registry knows.
+func (r *Registry) Validate() error {
+	for _, name := range r.skills.names {
+		skill := r.skills.entries[name]
+		if !skill.Ability.IsValid() || skill.Ability == StatYourChoice {
+			return fmt.Errorf("%w: skill %s has no ability", ErrInvalidContent, skill.Name)
+		}
+	}
+	for _, name := range r.races.names {
+		race := r.races.entries[name]
+		if err := r.validateRaceRules(string(race.Name), race.RaceRules); err != nil {
+			return err
+		}
+	}
+	for _, name := range r.subraces.names {
+		subrace := r.subraces.entries[name]
+		if _, ok := r.races.get(subrace.Race); !ok {
+			return fmt.Errorf("%w: subrace %s: %w: %s", ErrInvalidContent, subrace.Name, ErrUndefinedRace, subrace.Race)
+		}
+		if err := r.validateRaceRules(string(subrace.Name), subrace.RaceRules); err != nil {
+			return err
+		}
+	}
+	for _, name := range r.backgrounds.names {
+		background := r.backgrounds.entries[name]
+		if err := r.validateSkills(string(background.Name), background.Skills); err != nil {
+			return err
+		}
+		for _, ability := range background.Abilities {
+			if !ability.IsValid() || ability == StatYourChoice {
+				return fmt.Errorf("%w: background %s: %s is not an ability score", ErrInvalidContent, background.Name, ability)
+			}
+		}
+		if background.Feat != "" && !background.Feat.IsValid() {
+			return fmt.Errorf("%w: background %s: %w: %s", ErrInvalidContent, background.Name, ErrUndefinedFeat, background.Feat)
+		}
+		if err := background.Personality.validate(); err != nil {
+			return fmt.Errorf("%w: background %s: %v", ErrInvalidContent, background.Name, err)
+		}
+	}
+	for _, name := range r.classes.names {
+		class := r.classes.entries[name]
+		if class.HitDie <= 0 {
+			return fmt.Errorf("%w: class %s has no hit die", ErrInvalidContent, class.Name)
+		}
+		if err := r.validateSkills(string(class.Name), class.SkillChoices.Options); err != nil {
+			return err
+		}
+		for _, proficiencies := range []ClassProficiencies{class.Proficiencies, class.MulticlassProficiencies} {
+			for _, category := range proficiencies.WeaponCategories {
+				if !category.IsValid() {
+					return fmt.Errorf("%w: class %s: %w: %s", ErrInvalidContent, class.Name, ErrUndefinedWeaponCategory, category)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Registry) validateRaceRules(name string, rules RaceRules) error {
+	for _, resistance := range rules.Resistances {
+		if !resistance.IsValid() {
+			return fmt.Errorf("%w: %s: %w: %s", ErrInvalidContent, name, ErrUndefinedDamageType, resistance)
+		}
+	}
+	return r.validateSkills(name, rules.Skills)
+}
+
+func (r *Registry) validateSkills(name string, skills []SkillName) error {
+	for _, skill := range skills {
+		if _, ok := r.skills.get(skill); !ok {
+			return fmt.Errorf("%w: %s: %w: %s", ErrInvalidContent, name, ErrUndefinedSkill, skill)
+		}
+	}
+	return nil
+}
+
+func (r *Registry) Skill(name SkillName) (SkillDefinition, bool) {
+	return r.skills.get(name)
+}
+
+func (r *Registry) Race(name RaceName) (RaceDefinition, bool) {
+	return r.races.get(name)
+}
+
+func (r *Registry) Subrace(name SubraceName) (SubraceDefinition, bool) {
+	return r.subraces.get(name)
+}
+
+func (r *Registry) Background(name BackgroundName) (BackgroundDefinition, bool) {
+	return r.backgrounds.get(name)
+}
+
+func (r *Registry) Class(name ClassName) (ClassDefinition, bool) {
+	return r.classes.get(name)
+}
+
+func (r *Registry) SkillNames() []SkillName {
+	return slices.Clone(r.skills.names)
+}
+
+func (r *Registry) RaceNames() []RaceName {
+	return slices.Clone(r.races.names)
+}
+
+func (r *Registry) SubraceNames() []SubraceName {
+	return slices.Clone(r.subraces.names)
+}
+
+// RaceSubraceNames returns the names of the subraces of the race.
+func (r *Registry) RaceSubraceNames(race RaceName) []SubraceName {
+	output := []SubraceName{}
+	for _, name := range r.subraces.names {
+		if r.subraces.entries[name].Race == race {
+			output = append(output, name)
+		}
+	}
+	return output
+}
+
+func (r *Registry) BackgroundNames() []BackgroundName {
+	return slices.Clone(r.backgrounds.names)
+}
+
+func (r *Registry) ClassNames() []ClassName {
+	return slices.Clone(r.classes.names)
+}
+
+// LoadRuleset builds a registry for the ruleset version from the shared SRD content, the SRD races
+// and backgrounds of that version, and then the content packs in extra, which can add to or
+// replace SRD entries. Like the SRD, packs at the root of extra apply to every version and packs in
+// a directory named after the version only to it. extra may be nil.
+func LoadRuleset(version RulesetVersion, extra fs.FS) (*Registry, error) {
+	if !version.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrUndefinedRuleset, version)
+	}
+	registry := NewRegistry()
+	srd, err := fs.Sub(srdContent, "content/srd")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the SRD content: %w", err)
+	}
+	for _, content := range []fs.FS{srd, extra} {
+		if content == nil {
+			continue
+		}
+		if err := registry.LoadFS(content); err != nil {
+			return nil, err
+		}
+		versioned, err := fs.Sub(content, string(version))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the %s content: %w", version, err)
+		}
+		if err := registry.LoadFS(versioned); err != nil {
+			return nil, err
+		}
+	}
+	if err := registry.Validate(); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// LoadRules builds a 2014 ruleset registry. See LoadRuleset.
+func LoadRules(extra fs.FS) (*Registry, error) {
+	return LoadRuleset(Ruleset2014, extra)
+}
+
+// rules holds the registry of each ruleset version. The map itself is never written after init.
+var rules = map[RulesetVersion]*atomic.Pointer[Registry]{}
+
+// ownerRules holds the registries of each ruleset version that add a user's private homebrew to
+// the shared rules, keyed by the user's id. Like rules, the outer map is never written after init.
+var ownerRules = map[RulesetVersion]*atomic.Pointer[map[int]*Registry]{}
+
+func init() {
+	for _, version := range RulesetVersions {
+		registry, err := LoadRuleset(version, nil)
+		if err != nil {
+			panic(fmt.Sprintf("the embedded SRD content is invalid: %v", err))
+		}
+		rules[version] = &atomic.Pointer[Registry]{}
+		rules[version].Store(registry)
+		ownerRules[version] = &atomic.Pointer[map[int]*Registry]{}
+		ownerRules[version].Store(&map[int]*Registry{})
+	}
+}
+
+// Rules returns the registry 2014 races, backgrounds, classes and skills resolve through.
+func Rules() *Registry {
+	return RulesFor(Ruleset2014)
+}
+
+// RulesFor returns the registry of the ruleset version. Characters saved before rulesets were
+// pinned have no version and use the 2014 rules.
+func RulesFor(version RulesetVersion) *Registry {
+	pointer, ok := rules[version]
+	if !ok {
+		pointer = rules[Ruleset2014]
+	}
+	return pointer.Load()
+}
+
+// RulesForOwner returns the registry of the ruleset version with the user's private homebrew, or
+// the registry every user shares when they have none.
+func RulesForOwner(version RulesetVersion, ownerId int) *Registry {
+	pointer, ok := ownerRules[version]
+	if !ok {
+		pointer = ownerRules[Ruleset2014]
+	}
+	if registry, ok := (*pointer.Load())[ownerId]; ok {
+		return registry
+	}
+	return RulesFor(version)
+}
+
+// UseRules replaces the 2014 registry. See UseRuleset.
+func UseRules(registry *Registry) {
+	UseRuleset(Ruleset2014, registry)
+}
+
+// UseRuleset replaces the registry the package resolves the ruleset version through. It is meant
+// to be called at startup, before any character is loaded.
+func UseRuleset(version RulesetVersion, registry *Registry) {
+	if pointer, ok := rules[version]; ok {
+		pointer.Store(registry)
+	}
+}
+
+// UseOwnerRulesets replaces the registries of the ruleset version that users with private homebrew
+// resolve through. Users left out of registries go back to the shared registry.
+func UseOwnerRulesets(version RulesetVersion, registries map[int]*Registry) {
+	if pointer, ok := ownerRules[version]; ok {
+		registries = maps.Clone(registries)
+		pointer.Store(&registries)
+	}
+}
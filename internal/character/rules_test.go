@@ -0,0 +1,127 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+const homebrewPack = `
+races:
+  - name: Goliath
+    size: Medium
+    speed: 30
+    ability-increases:
+      - {stat: Strength, amount: 2}
+      - {stat: Constitution, amount: 1}
+    languages: [Common, Giant]
+    skills: [Athletics]
+    traits:
+      - name: Stone's Endurance
+        level: 1
+        description: Reduce damage you take by 1d12 + your Constitution modifier.
+        uses: {by-level: {1: 1}, recharge: Short Rest}
+backgrounds:
+  - name: Acolyte
+    skills: [Religion, History]
+`
+
+func TestSRDRules(t *testing.T) {
+	rules := character.Rules()
+	if skills := rules.SkillNames(); len(skills) != 18 || skills[0] != character.SkillAcrobatics {
+		t.Fatalf("got skills %v; want the 18 SRD skills starting with Acrobatics", skills)
+	}
+	if classes := rules.ClassNames(); len(classes) != 13 || classes[len(classes)-1] != character.ClassCommoner {
+		t.Fatalf("got classes %v; want the 12 SRD classes and the Commoner", classes)
+	}
+	if subrace, ok := rules.Subrace(character.SubraceDrow); !ok || subrace.Race != character.RaceElf {
+		t.Fatalf("got subrace %+v; want Drow listed under Elf", subrace)
+	}
+	if ability := character.SkillName(character.SkillPerception).GetAbility(); ability != character.StatWisdom {
+		t.Fatalf("got Perception ability %s; want Wisdom", ability)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	rules, err := character.LoadRules(fstest.MapFS{"homebrew.yaml": {Data: []byte(homebrewPack)}})
+	if err != nil {
+		t.Fatalf("unexpected error loading content pack: %v", err)
+	}
+	previous := character.Rules()
+	character.UseRules(rules)
+	t.Cleanup(func() { character.UseRules(previous) })
+
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	char.Race = character.Race{Type: "Goliath", Subrace: character.SubraceNone}
+	if speed := char.GetMoveSpeed(); speed != 30 {
+		t.Fatalf("got Goliath speed %d; want 30", speed)
+	}
+	if increase, err := char.Race.GetAbilityIncrease(); err != nil || len(increase) != 2 {
+		t.Fatalf("got Goliath increases %v, %v; want Strength and Constitution", increase, err)
+	}
+	if level := char.GetSkillProficiency(character.SkillAthletics); level != character.ProficiencyProficient {
+		t.Fatalf("got Athletics proficiency %s for a Goliath; want Proficient", level)
+	}
+	if feature, ok := char.GetActiveFeature("Stone's Endurance"); !ok || feature.MaxUses != 1 || feature.Race != "Goliath" {
+		t.Fatalf("expected Stone's Endurance to be a Goliath trait with one use, got %+v", feature)
+	}
+
+	// The pack replaces the SRD Acolyte skills, and the Acolyte keeps its place in the options.
+	if level := char.GetSkillProficiency(character.SkillHistory); level != character.ProficiencyProficient {
+		t.Fatalf("got History proficiency %s for the replaced Acolyte; want Proficient", level)
+	}
+	if backgrounds := rules.BackgroundNames(); backgrounds[0] != character.BackgroundAcolyte || len(backgrounds) != 13 {
+		t.Fatalf("got backgrounds %v; want the Acolyte replaced in place", backgrounds)
+	}
+	if races := rules.RaceNames(); races[len(races)-1] != "Goliath" {
+		t.Fatalf("got races %v; want Goliath added after the SRD races", races)
+	}
+}
+
+func TestLoadRulesInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		pack string
+		err  error
+	}{
+		{"unknown field", "races:\n  - name: Goliath\n    sped: 30\n", character.ErrInvalidContent},
+		{"undefined skill", "backgrounds:\n  - name: Juggler\n    skills: [Juggling]\n", character.ErrUndefinedSkill},
+		{"undefined damage type", "races:\n  - name: Goliath\n    resistances: [sonic]\n", character.ErrUndefinedDamageType},
+		{"no hit die", "classes:\n  - name: Blood Hunter\n", character.ErrInvalidContent},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := character.LoadRules(fstest.MapFS{"homebrew.yaml": {Data: []byte(test.pack)}})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("got %v; want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestRegistryExtend(t *testing.T) {
+	base := character.Rules()
+	pack := character.ContentPack{
+		Subraces: []character.SubraceDefinition{{
+			Name:      "Sea Elf",
+			Race:      character.RaceElf,
+			RaceRules: character.RaceRules{Languages: []string{"Aquan"}},
+		}},
+	}
+	extended := base.Extend(pack)
+	if err := extended.Validate(); err != nil {
+		t.Fatalf("unexpected error validating the extended registry: %v", err)
+	}
+	if subrace, ok := extended.Subrace("Sea Elf"); !ok || subrace.Race != character.RaceElf {
+		t.Fatalf("got subrace %+v; want Sea Elf under Elf", subrace)
+	}
+	if _, ok := base.Subrace("Sea Elf"); ok {
+		t.Fatal("expected extending a registry to leave it unchanged")
+	}
+
+	orphan := base.Extend(character.ContentPack{Subraces: []character.SubraceDefinition{{Name: "Stone Giant", Race: "Goliath"}}})
+	if err := orphan.Validate(); !errors.Is(err, character.ErrUndefinedRace) {
+		t.Fatalf("got %v; want %v", err, character.ErrUndefinedRace)
+	}
+}
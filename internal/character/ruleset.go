@@ -0,0 +1,246 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrUndefinedRuleset  = errors.New("attempted to use an undefined ruleset")
+	ErrInvalidStatChoice = errors.New("ability score choices are invalid")
+)
+
+// RulesetVersion is the edition of the rules a character is built with. A character keeps its
+// version for life, so changing the rules of one edition never changes characters of the other.
+type RulesetVersion string
+
+const (
+	Ruleset2014 RulesetVersion = "2014"
+	Ruleset2024 RulesetVersion = "2024"
+)
+
+var RulesetVersions = []RulesetVersion{Ruleset2014, Ruleset2024}
+
+func (v RulesetVersion) IsValid() bool {
+	return slices.Contains(RulesetVersions, v)
+}
+
+// maxBackgroundIncrease is the most a 2024 background can raise one ability score.
+const maxBackgroundIncrease = 2
+
+// backgroundStatChoices is the number of +1 increases a 2024 background grants.
+const backgroundStatChoices = 3
+
+// Ruleset is what differs between editions of the rules: where the ability score increases and
+// feats a character starts with come from, and when classes choose their subclass.
+type Ruleset interface {
+	Version() RulesetVersion
+	// Rules returns the registry the ruleset's characters share, without any private homebrew.
+	Rules() *Registry
+	// RaceLabel and SubraceLabel are what the edition calls a race and subrace.
+	RaceLabel() string
+	SubraceLabel() string
+	// GetOriginAbilityBonuses resolves the increases from the character's origin, their race or
+	// background, skipping choices not made yet.
+	GetOriginAbilityBonuses(c *Character) []AbilityBonus
+	// GetOriginStatChoiceCount returns how many ability scores the character picks for their origin.
+	GetOriginStatChoiceCount(c *Character) int
+	// GetOriginStatOptions returns the ability scores the origin choices can go to.
+	GetOriginStatOptions(c *Character) []StatName
+	ValidateOriginStatChoices(c *Character) error
+	// GetOriginFeats returns the feats the character's origin grants.
+	GetOriginFeats(c *Character) []FeatName
+	// GetFeatNames lists the feats Ability Score Improvements can be spent on.
+	GetFeatNames() []FeatName
+	// GetSubclassLevel returns the class level the class chooses its subclass at.
+	GetSubclassLevel(definition ClassDefinition) int
+}
+
+// GetRuleset returns the ruleset for the version, or the 2014 ruleset for characters without one.
+func GetRuleset(version RulesetVersion) Ruleset {
+	if version == Ruleset2024 {
+		return ruleset2024{}
+	}
+	return ruleset2014{}
+}
+
+func (c *Character) GetRuleset() Ruleset {
+	return GetRuleset(c.Ruleset)
+}
+
+// rules returns the registry of the character's ruleset version and owner, which its classes and
+// skills resolve through.
+func (c *Character) rules() *Registry {
+	return RulesForOwner(c.Ruleset, c.OwnerId)
+}
+
+// SetRuleset pins the character to the ruleset version, along with the race and background that
+// look up its content. Call it again after replacing the race or background.
+func (c *Character) SetRuleset(version RulesetVersion) *Character {
+	c.Ruleset = version
+	c.Race.Ruleset = version
+	c.Background.Ruleset = version
+	return c
+}
+
+// SetOwner sets the user whose private homebrew the character, its race and its background
+// resolve through. Call it again after replacing the race or background.
+func (c *Character) SetOwner(ownerId int) *Character {
+	c.OwnerId = ownerId
+	c.Race.OwnerId = ownerId
+	c.Background.OwnerId = ownerId
+	return c
+}
+
+// ValidateOriginStatChoices checks the ability scores chosen for the character's race or background.
+func (c *Character) ValidateOriginStatChoices() error {
+	return c.GetRuleset().ValidateOriginStatChoices(c)
+}
+
+// validateStatChoices checks that choices are ability scores from options, picked no more than limit times each.
+func validateStatChoices(choices []StatName, count int, options []StatName, limit int) error {
+	if len(choices) > count {
+		return fmt.Errorf("%w: only %d can be chosen", ErrInvalidStatChoice, count)
+	}
+	picked := make(map[StatName]int)
+	for _, choice := range choices {
+		if !choice.IsValid() || choice == StatYourChoice {
+			return fmt.Errorf("%w: %s is not an ability score", ErrInvalidStatChoice, choice)
+		}
+		if !slices.Contains(options, choice) {
+			return fmt.Errorf("%w: %s is not one of %v", ErrInvalidStatChoice, choice, options)
+		}
+		picked[choice]++
+		if picked[choice] > limit {
+			return fmt.Errorf("%w: %s was chosen more than %d times", ErrInvalidStatChoice, choice, limit)
+		}
+	}
+	return nil
+}
+
+// ruleset2014 takes ability score increases from the race and subrace, and origin feats don't exist.
+type ruleset2014 struct{}
+
+func (ruleset2014) Version() RulesetVersion {
+	return Ruleset2014
+}
+
+func (ruleset2014) Rules() *Registry {
+	return RulesFor(Ruleset2014)
+}
+
+func (ruleset2014) RaceLabel() string {
+	return "Race"
+}
+
+func (ruleset2014) SubraceLabel() string {
+	return "Subrace"
+}
+
+func (ruleset2014) GetOriginAbilityBonuses(c *Character) []AbilityBonus {
+	return c.Race.GetAbilityBonuses()
+}
+
+func (ruleset2014) GetOriginStatChoiceCount(c *Character) int {
+	return c.Race.GetStatChoiceCount()
+}
+
+func (ruleset2014) GetOriginStatOptions(c *Character) []StatName {
+	return c.Race.GetStatChoiceOptions()
+}
+
+func (r ruleset2014) ValidateOriginStatChoices(c *Character) error {
+	return validateStatChoices(c.Race.StatChoices, r.GetOriginStatChoiceCount(c), r.GetOriginStatOptions(c), 1)
+}
+
+func (ruleset2014) GetOriginFeats(c *Character) []FeatName {
+	return nil
+}
+
+func (ruleset2014) GetFeatNames() []FeatName {
+	return slices.Clone(FeatNames)
+}
+
+func (ruleset2014) GetSubclassLevel(definition ClassDefinition) int {
+	return definition.SubclassLevel
+}
+
+// ruleset2024 takes three +1 ability score increases and an origin feat from the background.
+// Species don't raise ability scores, and every class picks its subclass at level 3.
+type ruleset2024 struct{}
+
+// subclassLevel2024 is the level every 2024 class chooses its subclass at.
+const subclassLevel2024 = 3
+
+func (ruleset2024) Version() RulesetVersion {
+	return Ruleset2024
+}
+
+func (ruleset2024) Rules() *Registry {
+	return RulesFor(Ruleset2024)
+}
+
+func (ruleset2024) RaceLabel() string {
+	return "Species"
+}
+
+func (ruleset2024) SubraceLabel() string {
+	return "Lineage"
+}
+
+func (ruleset2024) GetOriginAbilityBonuses(c *Character) []AbilityBonus {
+	output := []AbilityBonus{}
+	for _, stat := range c.Background.AbilityChoices {
+		if !stat.IsValid() || stat == StatYourChoice {
+			continue
+		}
+		if index := slices.IndexFunc(output, func(bonus AbilityBonus) bool { return bonus.Stat == stat }); index != -1 {
+			output[index].Amount++
+			continue
+		}
+		output = append(output, AbilityBonus{Source: string(c.Background.Name), Stat: stat, Amount: 1})
+	}
+	return output
+}
+
+func (ruleset2024) GetOriginStatChoiceCount(c *Character) int {
+	return backgroundStatChoices
+}
+
+// GetOriginStatOptions returns the abilities the background lists. A custom background can raise
+// any ability score.
+func (ruleset2024) GetOriginStatOptions(c *Character) []StatName {
+	if abilities := c.Background.GetAbilities(); len(abilities) > 0 {
+		return abilities
+	}
+	return slices.Clone(StatNames)
+}
+
+func (r ruleset2024) ValidateOriginStatChoices(c *Character) error {
+	return validateStatChoices(c.Background.AbilityChoices, r.GetOriginStatChoiceCount(c), r.GetOriginStatOptions(c), maxBackgroundIncrease)
+}
+
+func (ruleset2024) GetOriginFeats(c *Character) []FeatName {
+	if feat := c.Background.GetFeat(); feat != "" {
+		return []FeatName{feat}
+	}
+	return nil
+}
+
+func (ruleset2024) GetFeatNames() []FeatName {
+	output := slices.Clone(FeatNames)
+	for _, name := range OriginFeatNames {
+		if !slices.Contains(output, name) {
+			output = append(output, name)
+		}
+	}
+	return output
+}
+
+func (ruleset2024) GetSubclassLevel(definition ClassDefinition) int {
+	if definition.SubclassLevel == 0 {
+		return 0
+	}
+	return subclassLevel2024
+}
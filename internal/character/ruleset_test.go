@@ -0,0 +1,172 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"slices"
+	"testing"
+	"testing/fstest"
+)
+
+func new2024Character() *character.Character {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	char.Race = character.Race{Type: character.RaceOrc, Subrace: character.SubraceNone}
+	char.Background = character.Background{Name: character.BackgroundSoldier, Proficiencies: []character.SkillName{}}
+	return char.SetRuleset(character.Ruleset2024)
+}
+
+func TestRuleset2014Unchanged(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	if char.GetRuleset().Version() != character.Ruleset2014 {
+		t.Fatalf("got ruleset %s; want 2014 for a character without one", char.GetRuleset().Version())
+	}
+	if score := char.GetEffectiveScore(character.StatStrength); score != 16 {
+		t.Fatalf("got Half-Orc Strength %d; want 16 with the racial increase", score)
+	}
+	if feats := char.GetFeats(); len(feats) != 0 {
+		t.Fatalf("got feats %v; want none from a 2014 background", feats)
+	}
+	if _, ok := character.RulesFor(character.Ruleset2014).Race(character.RaceOrc); ok {
+		t.Fatalf("got an Orc race in the 2014 rules; want it only in the 2024 species")
+	}
+}
+
+func TestRuleset2014OriginStatChoices(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 1})
+	char.Race = character.Race{Type: character.RaceHalfElf, Subrace: character.SubraceNone}
+	if options := char.GetRuleset().GetOriginStatOptions(char); slices.Contains(options, character.StatCharisma) || len(options) != 5 {
+		t.Fatalf("got Half-Elf options %v; want every ability but Charisma", options)
+	}
+
+	char.Race.StatChoices = []character.StatName{character.StatStrength, character.StatDexterity}
+	if err := char.ValidateOriginStatChoices(); err != nil {
+		t.Fatalf("unexpected error validating Strength and Dexterity: %v", err)
+	}
+	char.Race.StatChoices = []character.StatName{character.StatCharisma, character.StatStrength}
+	if err := char.ValidateOriginStatChoices(); !errors.Is(err, character.ErrInvalidStatChoice) {
+		t.Fatalf("got error %v choosing the Charisma the Half-Elf already raises; want ErrInvalidStatChoice", err)
+	}
+}
+
+func TestRuleset2024Origin(t *testing.T) {
+	char := new2024Character()
+	if label := char.GetRuleset().RaceLabel(); label != "Species" {
+		t.Fatalf("got race label %s; want Species", label)
+	}
+	if speed := char.GetMoveSpeed(); speed != 30 {
+		t.Fatalf("got Orc speed %d; want 30", speed)
+	}
+	if darkvision := char.Race.GetDarkvision(); darkvision != 120 {
+		t.Fatalf("got Orc darkvision %d; want 120", darkvision)
+	}
+	if bonuses := char.GetAbilityBonuses(); len(bonuses) != 0 {
+		t.Fatalf("got bonuses %v; want none before the background choices are made", bonuses)
+	}
+	if !char.HasFeat(character.FeatSavageAttacker) {
+		t.Fatalf("got feats %v; want the Soldier's Savage Attacker", char.GetFeats())
+	}
+
+	char.Background.AbilityChoices = []character.StatName{character.StatStrength, character.StatStrength, character.StatConstitution}
+	if err := char.ValidateOriginStatChoices(); err != nil {
+		t.Fatalf("unexpected error validating +2/+1: %v", err)
+	}
+	if score := char.GetEffectiveScore(character.StatStrength); score != 16 {
+		t.Fatalf("got Strength %d; want 16 with +2 from the background", score)
+	}
+	if score := char.GetEffectiveScore(character.StatConstitution); score != 15 {
+		t.Fatalf("got Constitution %d; want 15 with +1 from the background", score)
+	}
+
+	tests := [][]character.StatName{
+		{character.StatStrength, character.StatStrength, character.StatStrength},
+		{character.StatWisdom},
+		{character.StatStrength, character.StatDexterity, character.StatConstitution, character.StatStrength},
+	}
+	for _, choices := range tests {
+		char.Background.AbilityChoices = choices
+		if err := char.ValidateOriginStatChoices(); !errors.Is(err, character.ErrInvalidStatChoice) {
+			t.Fatalf("got error %v for %v; want ErrInvalidStatChoice", err, choices)
+		}
+	}
+}
+
+func TestRuleset2024Feats(t *testing.T) {
+	char := new2024Character()
+	char.SetLevel(4).SetClass(character.ClassFighter)
+	available := char.GetAvailableFeats()
+	if slices.ContainsFunc(available, func(feat character.Feat) bool { return feat.Name == character.FeatSavageAttacker }) {
+		t.Fatalf("got Savage Attacker available; want it left out once the background grants it")
+	}
+	if !slices.ContainsFunc(available, func(feat character.Feat) bool { return feat.Name == character.FeatSkilled }) {
+		t.Fatalf("got feats %v; want the Skilled origin feat available", available)
+	}
+
+	old := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 4})
+	err := old.ChooseAbilityScoreImprovement(character.AbilityScoreImprovement{Class: character.ClassFighter, Level: 4, Feat: character.FeatSkilled})
+	if !errors.Is(err, character.ErrUndefinedFeat) {
+		t.Fatalf("got error %v; want ErrUndefinedFeat for a 2024 feat on a 2014 character", err)
+	}
+}
+
+func TestRuleset2024SubclassLevel(t *testing.T) {
+	char := new2024Character()
+	char.SetClass(character.ClassCleric)
+	if choices := char.GetSubclassChoices(); len(choices) != 0 {
+		t.Fatalf("got subclass choices %v; want none for a level 1 2024 Cleric", choices)
+	}
+	char.SetLevel(3)
+	if choices := char.GetSubclassChoices(); len(choices) != 1 {
+		t.Fatalf("got subclass choices %v; want the Cleric's at level 3", choices)
+	}
+}
+
+func TestLoadRulesetVersionedPacks(t *testing.T) {
+	extra := fstest.MapFS{
+		"2024/species.yaml": {Data: []byte("races:\n  - {name: Aasimar, size: Medium, speed: 30, darkvision: 60}\n")},
+	}
+	for _, version := range character.RulesetVersions {
+		rules, err := character.LoadRuleset(version, extra)
+		if err != nil {
+			t.Fatalf("unexpected error loading %s rules: %v", version, err)
+		}
+		if _, ok := rules.Race("Aasimar"); ok != (version == character.Ruleset2024) {
+			t.Fatalf("got Aasimar in the %s rules %v; want it only in 2024", version, ok)
+		}
+	}
+	if _, err := character.LoadRuleset("5e", nil); !errors.Is(err, character.ErrUndefinedRuleset) {
+		t.Fatalf("got error %v; want ErrUndefinedRuleset", err)
+	}
+}
+
+func TestRuleset2024Classes(t *testing.T) {
+	extra := fstest.MapFS{
+		"2024/classes.yaml": {Data: []byte("classes:\n  - {name: Artificer, hit-die: 8, saving-throws: [Constitution, Intelligence], skill-choices: {count: 2, options: [Arcana, History]}}\n")},
+	}
+	rules, err := character.LoadRuleset(character.Ruleset2024, extra)
+	if err != nil {
+		t.Fatalf("unexpected error loading 2024 rules: %v", err)
+	}
+	previous := character.RulesFor(character.Ruleset2024)
+	character.UseRuleset(character.Ruleset2024, rules)
+	t.Cleanup(func() { character.UseRuleset(character.Ruleset2024, previous) })
+
+	char := new2024Character()
+	char.SetClass("Artificer")
+	if err := char.ValidateClasses(); err != nil {
+		t.Fatalf("unexpected error validating a 2024 Artificer: %v", err)
+	}
+	if dice := char.GetHitDice(); len(dice) != 1 || dice[0].Die != character.HitDieD8 {
+		t.Fatalf("got hit dice %v; want the Artificer's d8", dice)
+	}
+	if !char.HasSavingThrowProficiency(character.StatIntelligence) {
+		t.Fatal("expected Intelligence saving throws from the 2024 Artificer")
+	}
+	if !slices.Contains(char.GetMulticlassOptions(), "Artificer") {
+		t.Fatalf("got multiclass options %v; want the Artificer", char.GetMulticlassOptions())
+	}
+
+	old := newMulticlassCharacter(character.ClassLevel{Class: "Artificer", Level: 1})
+	if err := old.ValidateClasses(); !errors.Is(err, character.ErrUndefinedClass) {
+		t.Fatalf("got error %v; want ErrUndefinedClass for a 2024 class on a 2014 character", err)
+	}
+}
@@ -0,0 +1,31 @@
+package character
+
+import "dndcc/internal/dice"
+
+// passiveRollModeBonus is added to a passive score for advantage and taken away for disadvantage.
+const passiveRollModeBonus = 5
+
+// GetPassiveScore returns the passive score for a skill: 10 plus the skill bonus, adjusted by 5 for
+// advantage or disadvantage on ability checks, plus passive bonuses from feats.
+func (c *Character) GetPassiveScore(skill SkillName) int {
+	score := 10 + c.GetSkill(skill) + c.getFeatPassiveBonus(skill)
+	switch c.GetAbilityCheckMode() {
+	case dice.RollAdvantage:
+		score += passiveRollModeBonus
+	case dice.RollDisadvantage:
+		score -= passiveRollModeBonus
+	}
+	return score
+}
+
+func (c *Character) GetPassivePerception() int {
+	return c.GetPassiveScore(SkillPerception)
+}
+
+func (c *Character) GetPassiveInvestigation() int {
+	return c.GetPassiveScore(SkillInvestigation)
+}
+
+func (c *Character) GetPassiveInsight() int {
+	return c.GetPassiveScore(SkillInsight)
+}
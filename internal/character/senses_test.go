@@ -0,0 +1,34 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"testing"
+)
+
+func TestPassiveScores(t *testing.T) {
+	char := newMulticlassCharacter(character.ClassLevel{Class: character.ClassFighter, Level: 4})
+
+	// Wisdom 10 gives 0, plus the proficiency bonus of 2 for Insight from the Acolyte background.
+	if char.GetPassivePerception() != 10 || char.GetPassiveInsight() != 12 || char.GetPassiveInvestigation() != 11 {
+		t.Fatalf("got passive Perception %d, Insight %d and Investigation %d; want 10, 12 and 11",
+			char.GetPassivePerception(), char.GetPassiveInsight(), char.GetPassiveInvestigation())
+	}
+
+	err := char.ChooseAbilityScoreImprovement(character.AbilityScoreImprovement{
+		Class: character.ClassFighter, Level: 4, Feat: character.FeatObservant, Stats: []character.StatName{character.StatWisdom},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error choosing feat: %v", err)
+	}
+	if char.GetPassivePerception() != 15 || char.GetPassiveInvestigation() != 16 || char.GetPassiveInsight() != 12 {
+		t.Fatalf("got passive Perception %d, Investigation %d and Insight %d with Observant; want 15, 16 and 12",
+			char.GetPassivePerception(), char.GetPassiveInvestigation(), char.GetPassiveInsight())
+	}
+
+	if err := char.AddCondition(character.ConditionPoisoned, 0); err != nil {
+		t.Fatalf("unexpected error adding condition: %v", err)
+	}
+	if char.GetPassivePerception() != 10 {
+		t.Fatalf("got passive Perception %d with disadvantage; want 10", char.GetPassivePerception())
+	}
+}
@@ -2,8 +2,6 @@ package character
 
 import (
 	"errors"
-
-	"gopkg.in/yaml.v3"
 )
 
 type SkillName string
@@ -34,19 +32,29 @@ const (
 	SkillSurvival       SkillName = "Survival"
 )
 
-func (s *SkillName) UnmarshalYAML(value *yaml.Node) error {
-	var str string
-	if err := value.Decode(&str); err != nil {
-		return err
-	}
-	switch SkillName(str) {
-	case SkillAcrobatics, SkillAnimalHandling, SkillArcana, SkillAthletics, SkillDeception,
-		SkillHistory, SkillInsight, SkillIntimidation, SkillInvestigation, SkillMedicine,
-		SkillNature, SkillPerception, SkillPerformance, SkillPersuasion, SkillReligion,
-		SkillSleightOfHand, SkillStealth, SkillSurvival:
-		*s = SkillName(str)
-		return nil
-	default:
-		return ErrUndefinedSkill
-	}
+// IsValid reports whether the 2014 rules define the skill. A character's skills are checked
+// against its own ruleset with Character.IsDefinedSkill.
+func (s SkillName) IsValid() bool {
+	_, ok := Rules().Skill(s)
+	return ok
+}
+
+// GetAbility returns the ability the skill is rolled with in the 2014 rules, or an empty StatName
+// for undefined skills.
+func (s SkillName) GetAbility() StatName {
+	definition, _ := Rules().Skill(s)
+	return definition.Ability
+}
+
+// IsDefinedSkill reports whether the character's ruleset defines the skill.
+func (c *Character) IsDefinedSkill(skill SkillName) bool {
+	_, ok := c.rules().Skill(skill)
+	return ok
+}
+
+// GetSkillAbility returns the ability the skill is rolled with under the character's ruleset, or
+// an empty StatName for undefined skills.
+func (c *Character) GetSkillAbility(skill SkillName) StatName {
+	definition, _ := c.rules().Skill(skill)
+	return definition.Ability
 }
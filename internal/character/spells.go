@@ -0,0 +1,369 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUndefinedSpellSchool  = errors.New("attempted to use undefined spell school")
+	ErrInvalidSpellLevel     = errors.New("spell level must be between 0 and 9")
+	ErrInvalidSpellSlot      = errors.New("the character does not have spell slots of that level")
+	ErrNoSpellSlotsRemaining = errors.New("no spell slots of that level remain")
+	ErrNoSpellSlotsExpended  = errors.New("no spell slots of that level have been expended")
+	ErrTooManyPreparedSpells = errors.New("no more spells can be prepared")
+)
+
+type SpellSchool string
+
+const (
+	SchoolAbjuration    SpellSchool = "Abjuration"
+	SchoolConjuration   SpellSchool = "Conjuration"
+	SchoolDivination    SpellSchool = "Divination"
+	SchoolEnchantment   SpellSchool = "Enchantment"
+	SchoolEvocation     SpellSchool = "Evocation"
+	SchoolIllusion      SpellSchool = "Illusion"
+	SchoolNecromancy    SpellSchool = "Necromancy"
+	SchoolTransmutation SpellSchool = "Transmutation"
+)
+
+// SpellSchools lists every school of magic.
+var SpellSchools = []SpellSchool{
+	SchoolAbjuration,
+	SchoolConjuration,
+	SchoolDivination,
+	SchoolEnchantment,
+	SchoolEvocation,
+	SchoolIllusion,
+	SchoolNecromancy,
+	SchoolTransmutation,
+}
+
+func (s SpellSchool) IsValid() bool {
+	switch s {
+	case SchoolAbjuration, SchoolConjuration, SchoolDivination, SchoolEnchantment,
+		SchoolEvocation, SchoolIllusion, SchoolNecromancy, SchoolTransmutation:
+		return true
+	default:
+		return false
+	}
+}
+
+type Spell struct {
+	Name          string      `yaml:"name"`
+	Level         int         `yaml:"level"` // 0 is a cantrip
+	School        SpellSchool `yaml:"school"`
+	CastingTime   string      `yaml:"casting-time"`
+	Range         string      `yaml:"range"`
+	Components    string      `yaml:"components"`
+	Duration      string      `yaml:"duration"`
+	Concentration bool        `yaml:"concentration"`
+	Ritual        bool        `yaml:"ritual"`
+	Description   string      `yaml:"description"`
+}
+
+func (s *Spell) IsCantrip() bool {
+	return s.Level == 0
+}
+
+func (s *Spell) Validate() error {
+	if s.Level < 0 || s.Level > 9 {
+		return ErrInvalidSpellLevel
+	}
+	if !s.School.IsValid() {
+		return fmt.Errorf("%w: %s", ErrUndefinedSpellSchool, s.School)
+	}
+	return nil
+}
+
+// KnownSpell is a spell on a character's list along with whether it is currently prepared.
+type KnownSpell struct {
+	ID       int `yaml:"-"`
+	Spell    `yaml:",inline"`
+	Prepared bool `yaml:"prepared"`
+}
+
+type CasterType string
+
+const (
+	CasterNone CasterType = "None"
+	CasterFull CasterType = "Full"
+	CasterHalf CasterType = "Half"
+	CasterPact CasterType = "Pact"
+)
+
+// SpellSlots describes the slots a character has for a single spell level.
+type SpellSlots struct {
+	Level    int
+	Maximum  int
+	Expended int
+	Pact     bool
+}
+
+func (s SpellSlots) Remaining() int {
+	return max(s.Maximum-s.Expended, 0)
+}
+
+// fullCasterSlots is the spell slot table for full casters indexed by caster level - 1.
+var fullCasterSlots = [20][9]int{
+	{2},
+	{3},
+	{4, 2},
+	{4, 3},
+	{4, 3, 2},
+	{4, 3, 3},
+	{4, 3, 3, 1},
+	{4, 3, 3, 2},
+	{4, 3, 3, 3, 1},
+	{4, 3, 3, 3, 2},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 2, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 1, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 1, 1, 1},
+	{4, 3, 3, 3, 3, 2, 2, 1, 1},
+}
+
+// pactMagicSlots is the Warlock pact magic table indexed by warlock level - 1.
+var pactMagicSlots = [20]struct {
+	Count int
+	Level int
+}{
+	{1, 1}, {2, 1}, {2, 2}, {2, 2}, {2, 3},
+	{2, 3}, {2, 4}, {2, 4}, {2, 5}, {2, 5},
+	{3, 5}, {3, 5}, {3, 5}, {3, 5}, {3, 5},
+	{3, 5}, {4, 5}, {4, 5}, {4, 5}, {4, 5},
+}
+
+// GetSpellSlotsForCasterLevel returns the number of slots per spell level, index 0 being 1st level.
+func GetSpellSlotsForCasterLevel(casterLevel int) [9]int {
+	if casterLevel < 1 {
+		return [9]int{}
+	}
+	return fullCasterSlots[min(casterLevel, 20)-1]
+}
+
+// GetPactMagicSlots returns the number of pact slots and their level for a warlock level.
+func GetPactMagicSlots(warlockLevel int) (int, int) {
+	if warlockLevel < 1 {
+		return 0, 0
+	}
+	slots := pactMagicSlots[min(warlockLevel, 20)-1]
+	return slots.Count, slots.Level
+}
+
+func (d ClassDefinition) GetCasterType() CasterType {
+	casterType := d.CasterType
+	if casterType == "" {
+		return CasterNone
+	}
+	return casterType
+}
+
+// GetSpellcastingAbility returns the stat the class casts with, or an empty StatName for non-casters.
+func (d ClassDefinition) GetSpellcastingAbility() StatName {
+	return d.SpellcastingAbility
+}
+
+// PreparesSpells reports whether the class prepares spells each day rather than knowing a fixed list.
+// getCasterLevel converts a class level into its caster level for the spell slot table.
+func (d ClassDefinition) getCasterLevel(level int) int {
+	switch d.GetCasterType() {
+	case CasterFull:
+		return level
+	case CasterHalf:
+		if level < 2 {
+			return 0
+		}
+		return (level + 1) / 2
+	default:
+		return 0
+	}
+}
+
+func (c *Character) IsSpellcaster() bool {
+	return c.GetSpellcastingClass() != ""
+}
+
+// GetSpellcastingClass returns the first class in the class list that casts spells.
+func (c *Character) GetSpellcastingClass() ClassName {
+	for _, classLevel := range c.Classes {
+		if c.GetClassDefinition(classLevel.Class).GetCasterType() != CasterNone {
+			return classLevel.Class
+		}
+	}
+	return ""
+}
+
+func (c *Character) GetSpellcastingAbility() StatName {
+	return c.GetClassDefinition(c.GetSpellcastingClass()).GetSpellcastingAbility()
+}
+
+// getCasterLevel returns the caster level used for the shared spell slot table. A single
+// spellcasting class uses its own progression; multiclassed casters add full caster levels
+// and half of each half caster's levels, rounded down. Pact magic is tracked separately.
+func (c *Character) getCasterLevel() int {
+	var casters []ClassLevel
+	for _, classLevel := range c.Classes {
+		casterType := c.GetClassDefinition(classLevel.Class).GetCasterType()
+		if casterType == CasterFull || casterType == CasterHalf {
+			casters = append(casters, classLevel)
+		}
+	}
+	if len(casters) == 1 {
+		return c.GetClassDefinition(casters[0].Class).getCasterLevel(casters[0].Level)
+	}
+
+	casterLevel := 0
+	for _, classLevel := range casters {
+		if c.GetClassDefinition(classLevel.Class).GetCasterType() == CasterFull {
+			casterLevel += classLevel.Level
+		} else {
+			casterLevel += classLevel.Level / 2
+		}
+	}
+	return casterLevel
+}
+
+// GetSpellSaveDC returns 8 + proficiency bonus + spellcasting ability modifier.
+func (c *Character) GetSpellSaveDC() int {
+	return 8 + c.GetSpellAttackBonus()
+}
+
+// GetSpellAttackBonus returns proficiency bonus + spellcasting ability modifier.
+func (c *Character) GetSpellAttackBonus() int {
+	ability := c.GetSpellcastingAbility()
+	if ability == "" {
+		return 0
+	}
+	return c.GetProficiencyBonus() + c.GetAbilityScore(ability)
+}
+
+// GetMaxPreparedSpells returns how many spells a preparing class can have prepared, or 0 if the class knows its spells.
+// Multiclassed characters use the first preparing class in their class list.
+func (c *Character) GetMaxPreparedSpells() int {
+	for _, classLevel := range c.Classes {
+		definition := c.GetClassDefinition(classLevel.Class)
+		if !definition.PreparesSpells {
+			continue
+		}
+		level := classLevel.Level
+		if definition.GetCasterType() == CasterHalf {
+			level /= 2
+		}
+		return max(level+c.GetAbilityScore(definition.GetSpellcastingAbility()), 1)
+	}
+	return 0
+}
+
+// GetPreparedSpellCount returns how many spells the character has prepared. Cantrips are always
+// ready and don't count.
+func (c *Character) GetPreparedSpellCount() int {
+	count := 0
+	for _, spell := range c.Spells {
+		if spell.Prepared && spell.Level > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// ValidatePreparedSpells checks that a preparing class has no more spells prepared than
+// GetMaxPreparedSpells. Classes that know their spells have no limit.
+func (c *Character) ValidatePreparedSpells() error {
+	maximum := c.GetMaxPreparedSpells()
+	if maximum == 0 {
+		return nil
+	}
+	if count := c.GetPreparedSpellCount(); count > maximum {
+		return fmt.Errorf("%w: %d prepared, up to %d allowed", ErrTooManyPreparedSpells, count, maximum)
+	}
+	return nil
+}
+
+// GetSpellSlots returns every spell slot level the character has, including pact magic slots.
+func (c *Character) GetSpellSlots() []SpellSlots {
+	var output []SpellSlots
+	table := GetSpellSlotsForCasterLevel(c.getCasterLevel())
+	for i, maximum := range table {
+		if maximum == 0 {
+			continue
+		}
+		output = append(output, SpellSlots{
+			Level:    i + 1,
+			Maximum:  maximum,
+			Expended: min(c.ExpendedSpellSlots[i], maximum),
+		})
+	}
+
+	if warlockLevel := c.GetClassLevel(ClassWarlock); warlockLevel > 0 {
+		count, level := GetPactMagicSlots(warlockLevel)
+		if count > 0 {
+			output = append(output, SpellSlots{
+				Level:    level,
+				Maximum:  count,
+				Expended: min(c.ExpendedPactSlots, count),
+				Pact:     true,
+			})
+		}
+	}
+	return output
+}
+
+func (c *Character) getSpellSlots(level int, pact bool) (SpellSlots, error) {
+	for _, slots := range c.GetSpellSlots() {
+		if slots.Level == level && slots.Pact == pact {
+			return slots, nil
+		}
+	}
+	return SpellSlots{}, fmt.Errorf("%w: level %d", ErrInvalidSpellSlot, level)
+}
+
+// ExpendSpellSlot marks one slot of the given level as used.
+func (c *Character) ExpendSpellSlot(level int, pact bool) error {
+	slots, err := c.getSpellSlots(level, pact)
+	if err != nil {
+		return err
+	}
+	if slots.Remaining() == 0 {
+		return fmt.Errorf("%w: level %d", ErrNoSpellSlotsRemaining, level)
+	}
+	if pact {
+		c.ExpendedPactSlots = slots.Expended + 1
+	} else {
+		c.ExpendedSpellSlots[level-1] = slots.Expended + 1
+	}
+	return nil
+}
+
+// RestoreSpellSlot returns one expended slot of the given level.
+func (c *Character) RestoreSpellSlot(level int, pact bool) error {
+	slots, err := c.getSpellSlots(level, pact)
+	if err != nil {
+		return err
+	}
+	if slots.Expended == 0 {
+		return fmt.Errorf("%w: level %d", ErrNoSpellSlotsExpended, level)
+	}
+	if pact {
+		c.ExpendedPactSlots = slots.Expended - 1
+	} else {
+		c.ExpendedSpellSlots[level-1] = slots.Expended - 1
+	}
+	return nil
+}
+
+// GetSpellsByLevel returns the character's spells of a single level, 0 being cantrips.
+func (c *Character) GetSpellsByLevel(level int) []KnownSpell {
+	output := []KnownSpell{}
+	for _, spell := range c.Spells {
+		if spell.Level == level {
+			output = append(output, spell)
+		}
+	}
+	return output
+}
@@ -0,0 +1,100 @@
+package character_test
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"testing"
+)
+
+func TestGetSpellSlots(t *testing.T) {
+	tests := []struct {
+		Class    character.ClassName
+		Level    int
+		Expected []character.SpellSlots
+	}{
+		{character.ClassWizard, 1, []character.SpellSlots{{Level: 1, Maximum: 2}}},
+		{character.ClassCleric, 5, []character.SpellSlots{{Level: 1, Maximum: 4}, {Level: 2, Maximum: 3}, {Level: 3, Maximum: 2}}},
+		{character.ClassPaladin, 1, nil},
+		{character.ClassPaladin, 2, []character.SpellSlots{{Level: 1, Maximum: 2}}},
+		{character.ClassRanger, 9, []character.SpellSlots{{Level: 1, Maximum: 4}, {Level: 2, Maximum: 3}, {Level: 3, Maximum: 2}}},
+		{character.ClassWarlock, 1, []character.SpellSlots{{Level: 1, Maximum: 1, Pact: true}}},
+		{character.ClassWarlock, 11, []character.SpellSlots{{Level: 5, Maximum: 3, Pact: true}}},
+		{character.ClassFighter, 20, nil},
+	}
+
+	for _, test := range tests {
+		char := character.NewCharacter().SetClass(test.Class).SetLevel(test.Level)
+		slots := char.GetSpellSlots()
+		if len(slots) != len(test.Expected) {
+			t.Fatalf("%s %d got incorrect slots: %v; want %v", test.Class, test.Level, slots, test.Expected)
+		}
+		for i := range slots {
+			if slots[i] != test.Expected[i] {
+				t.Fatalf("%s %d got incorrect slots: %v; want %v", test.Class, test.Level, slots, test.Expected)
+			}
+		}
+	}
+}
+
+func TestExpendSpellSlot(t *testing.T) {
+	char := character.NewCharacter().SetClass(character.ClassWizard).SetLevel(1)
+
+	for i := 0; i < 2; i++ {
+		if err := char.ExpendSpellSlot(1, false); err != nil {
+			t.Fatalf("got an unexpected err expending slot %d: %v", i, err)
+		}
+	}
+	if err := char.ExpendSpellSlot(1, false); !errors.Is(err, character.ErrNoSpellSlotsRemaining) {
+		t.Fatalf("got incorrect err expending an empty slot: %v; want %v", err, character.ErrNoSpellSlotsRemaining)
+	}
+	if err := char.ExpendSpellSlot(2, false); !errors.Is(err, character.ErrInvalidSpellSlot) {
+		t.Fatalf("got incorrect err expending a missing slot: %v; want %v", err, character.ErrInvalidSpellSlot)
+	}
+	if err := char.RestoreSpellSlot(1, false); err != nil {
+		t.Fatalf("got an unexpected err restoring a slot: %v", err)
+	}
+	if remaining := char.GetSpellSlots()[0].Remaining(); remaining != 1 {
+		t.Fatalf("got incorrect remaining slots: %d; want 1", remaining)
+	}
+}
+
+func TestSpellcastingStats(t *testing.T) {
+	char := character.NewCharacter().SetClass(character.ClassWizard).SetLevel(5)
+	char.StatBlock = &character.StatBlock{Intelligence: 16}
+	char.Race = character.Race{Type: character.RaceGnome, Subrace: character.SubraceNone}
+
+	if dc := char.GetSpellSaveDC(); dc != 15 {
+		t.Fatalf("got incorrect spell save dc: %d; want 15", dc)
+	}
+	if bonus := char.GetSpellAttackBonus(); bonus != 7 {
+		t.Fatalf("got incorrect spell attack bonus: %d; want 7", bonus)
+	}
+	if prepared := char.GetMaxPreparedSpells(); prepared != 9 {
+		t.Fatalf("got incorrect max prepared spells: %d; want 9", prepared)
+	}
+}
+
+func TestValidatePreparedSpells(t *testing.T) {
+	char := character.NewCharacter().SetClass(character.ClassWizard).SetLevel(1)
+	char.StatBlock = &character.StatBlock{Intelligence: 12}
+	char.Race = character.Race{Type: character.RaceHuman, Subrace: character.SubraceNone}
+	// A level 1 Wizard with Intelligence 13 prepares 2 spells.
+	char.Spells = []character.KnownSpell{
+		{Spell: character.Spell{Name: "Fire Bolt", Level: 0}, Prepared: true},
+		{Spell: character.Spell{Name: "Magic Missile", Level: 1}, Prepared: true},
+		{Spell: character.Spell{Name: "Shield", Level: 1}, Prepared: true},
+	}
+	if err := char.ValidatePreparedSpells(); err != nil {
+		t.Fatalf("unexpected error with %d of %d spells prepared: %v", char.GetPreparedSpellCount(), char.GetMaxPreparedSpells(), err)
+	}
+	char.Spells = append(char.Spells, character.KnownSpell{Spell: character.Spell{Name: "Sleep", Level: 1}, Prepared: true})
+	if err := char.ValidatePreparedSpells(); !errors.Is(err, character.ErrTooManyPreparedSpells) {
+		t.Fatalf("got incorrect err preparing a third spell: %v; want %v", err, character.ErrTooManyPreparedSpells)
+	}
+
+	sorcerer := character.NewCharacter().SetClass(character.ClassSorcerer).SetLevel(1)
+	sorcerer.Spells = char.Spells
+	if err := sorcerer.ValidatePreparedSpells(); err != nil {
+		t.Fatalf("got an unexpected err for a class that knows its spells: %v", err)
+	}
+}
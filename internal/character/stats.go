@@ -25,6 +25,16 @@ const (
 	StatYourChoice   StatName = "YourChoice" // Should be edited in the YAML file
 )
 
+// StatNames lists the six ability scores in sheet order.
+var StatNames = []StatName{
+	StatStrength,
+	StatDexterity,
+	StatConstitution,
+	StatIntelligence,
+	StatWisdom,
+	StatCharisma,
+}
+
 func (c StatName) IsValid() bool {
 	switch c {
 	case StatStrength, StatDexterity, StatConstitution, StatIntelligence, StatWisdom, StatCharisma, StatYourChoice:
@@ -60,6 +70,13 @@ func (s *StatName) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// AbilityBonus is a single increase to an ability score along with where it came from.
+type AbilityBonus struct {
+	Source string
+	Stat   StatName
+	Amount int
+}
+
 type StatBlock struct {
 	Strength     int `yaml:"strength"`
 	Dexterity    int `yaml:"dexterity"`
@@ -69,27 +86,39 @@ type StatBlock struct {
 	Charisma     int `yaml:"charisma"`
 }
 
-func (s *StatBlock) GetAbilityScore(stat StatName) int {
-	var statValue int
+// GetScore returns the raw ability score for the stat.
+func (s *StatBlock) GetScore(stat StatName) int {
 	switch stat {
 	case StatStrength:
-		statValue = s.Strength
+		return s.Strength
 	case StatDexterity:
-		statValue = s.Dexterity
+		return s.Dexterity
 	case StatConstitution:
-		statValue = s.Constitution
+		return s.Constitution
 	case StatIntelligence:
-		statValue = s.Intelligence
+		return s.Intelligence
 	case StatWisdom:
-		statValue = s.Wisdom
+		return s.Wisdom
 	case StatCharisma:
-		statValue = s.Charisma
-	case StatYourChoice:
+		return s.Charisma
+	default:
+		return 0
+	}
+}
+
+// GetAbilityScore returns the ability modifier for the stat.
+func (s *StatBlock) GetAbilityScore(stat StatName) int {
+	switch stat {
+	case StatStrength, StatDexterity, StatConstitution, StatIntelligence, StatWisdom, StatCharisma, StatYourChoice:
+		return GetModifier(s.GetScore(stat))
 	default:
 		return 0
 	}
+}
 
-	abilityScore := float64(statValue-10) / float64(2)
+// GetModifier converts an ability score into its modifier.
+func GetModifier(score int) int {
+	abilityScore := float64(score-10) / float64(2)
 	return int(math.Floor(abilityScore))
 }
 
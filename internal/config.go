@@ -4,33 +4,89 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 )
 
 var (
-	ErrEmptyLLMUrl = errors.New("the llm url field is required in your config")
+	ErrEmptyLLMUrl        = errors.New("the llm url field is required in your config")
+	ErrEmptyLLMModel      = errors.New("the llm model field is required in your config")
+	ErrInvalidLLMProvider = errors.New("the llm provider must be openai, ollama or template")
 )
 
+// LLMProvider names the kind of LLM the app talks to. LLMProviderNone, or "none" in LLM_PROVIDER,
+// turns the LLM features off.
+type LLMProvider string
+
+const (
+	LLMProviderNone     LLMProvider = ""
+	LLMProviderOpenAI   LLMProvider = "openai"
+	LLMProviderOllama   LLMProvider = "ollama"
+	LLMProviderTemplate LLMProvider = "template"
+)
+
+// defaultLLMTimeout bounds a whole chat completion request when LLM_TIMEOUT isn't set.
+const defaultLLMTimeout = 60 * time.Second
+
+// defaultOllamaURL is where a local Ollama server listens unless LLM_URL says otherwise.
+const defaultOllamaURL = "http://localhost:11434"
+
+// LLMConfig picks the LLM provider and how to reach it. For an OpenAI-compatible API, URL is either
+// the API's base URL or its chat completions endpoint, and Model is left out of requests when empty
+// so the server picks its default. The template provider writes from fixed templates and needs
+// neither.
 type LLMConfig struct {
+	Provider               LLMProvider
 	URL                    string
 	ApiKey                 string
+	Model                  string
 	AdditionalSystemPrompt string
+	Timeout                time.Duration
 }
 
+// LoadLLMConfigEnv reads LLM_PROVIDER and the settings it needs. Without LLM_PROVIDER the LLM is
+// off, unless LLM_URL is set, in which case it is taken to be an OpenAI-compatible API.
 func LoadLLMConfigEnv() (*LLMConfig, error) {
+	provider := LLMProvider(os.Getenv("LLM_PROVIDER"))
 	url := os.Getenv("LLM_URL")
-	if url == "" {
-		return nil, fmt.Errorf("no llm db url was provided")
+	if provider == LLMProviderNone && url != "" {
+		provider = LLMProviderOpenAI
 	}
-	apiKey := os.Getenv("LLM_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("no llm db api key was provided")
+	model := os.Getenv("LLM_MODEL")
+	switch provider {
+	case LLMProviderNone, "none":
+		return &LLMConfig{}, nil
+	case LLMProviderOpenAI:
+		if url == "" {
+			return nil, ErrEmptyLLMUrl
+		}
+	case LLMProviderOllama:
+		if url == "" {
+			url = defaultOllamaURL
+		}
+		if model == "" {
+			return nil, ErrEmptyLLMModel
+		}
+	case LLMProviderTemplate:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidLLMProvider, provider)
 	}
 	systemPrompt := os.Getenv("LLM_SYSTEM_PROMPT")
+	timeout := defaultLLMTimeout
+	if value := os.Getenv("LLM_TIMEOUT"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid llm timeout was provided: %s", value)
+		}
+		timeout = parsed
+	}
 
 	return &LLMConfig{
+		Provider:               provider,
 		URL:                    url,
-		ApiKey:                 apiKey,
+		ApiKey:                 os.Getenv("LLM_API_KEY"),
+		Model:                  model,
 		AdditionalSystemPrompt: systemPrompt,
+		Timeout:                timeout,
 	}, nil
 }
 
@@ -69,10 +125,23 @@ func LoadAuthServiceConfigEnv() (*AuthServiceConfig, error) {
 	}, nil
 }
 
+// RulesConfig points at an optional directory of YAML content packs loaded on top of the SRD. Packs
+// at its root apply to every ruleset, and packs in a 2014 or 2024 subdirectory only to that version.
+type RulesConfig struct {
+	ContentDir string
+}
+
+func LoadRulesConfigEnv() *RulesConfig {
+	return &RulesConfig{
+		ContentDir: os.Getenv("RULES_CONTENT_DIR"),
+	}
+}
+
 type AppConfig struct {
 	LLM               *LLMConfig
 	DB                *DbConfig
 	AuthServiceConfig *AuthServiceConfig
+	Rules             *RulesConfig
 }
 
 func ParseAppConfig() (*AppConfig, error) {
@@ -96,5 +165,6 @@ func ParseAppConfig() (*AppConfig, error) {
 		llmConfig,
 		dbConfig,
 		authServiceConfig,
+		LoadRulesConfigEnv(),
 	}, nil
 }
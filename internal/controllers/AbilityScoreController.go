@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type AbilityScoreController struct {
+	logger           grove.ILogger
+	service          *services.AbilityScoreService
+	partialTemplates *template.Template
+}
+
+func NewAbilityScoreController(logger grove.ILogger, service *services.AbilityScoreService) *AbilityScoreController {
+	partialTemplates := template.Must(template.ParseFiles(
+		"internal/templates/partials/abilityScores.html.tmpl",
+	))
+
+	return &AbilityScoreController{
+		logger:           logger,
+		service:          service,
+		partialTemplates: partialTemplates,
+	}
+}
+
+func (c *AbilityScoreController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /character/ability-scores", c.AbilityScores)
+	mux.HandleFunc("POST /character/ability-rolls", c.Roll)
+}
+
+// abilityScoresFromForm reads the in-progress ability scores from the edit form.
+// Unparseable scores are left at zero so the partial can report them as out of range.
+func abilityScoresFromForm(r *http.Request) *models.Character {
+	data := &models.Character{AbilityScoreMethod: r.FormValue("AbilityScoreMethod")}
+	data.ID, _ = strconv.Atoi(r.FormValue("CharacterID"))
+	data.Strength, _ = strconv.Atoi(r.FormValue("Strength"))
+	data.Dexterity, _ = strconv.Atoi(r.FormValue("Dexterity"))
+	data.Constitution, _ = strconv.Atoi(r.FormValue("Constitution"))
+	data.Intelligence, _ = strconv.Atoi(r.FormValue("Intelligence"))
+	data.Wisdom, _ = strconv.Atoi(r.FormValue("Wisdom"))
+	data.Charisma, _ = strconv.Atoi(r.FormValue("Charisma"))
+	return data
+}
+
+func (c *AbilityScoreController) renderAbilityScores(w http.ResponseWriter, data *models.Character) {
+	if err := c.partialTemplates.ExecuteTemplate(w, "abilityScores", page.NewAbilityScoresData(data)); err != nil {
+		c.logger.Error("an error occurred while rendering the ability scores panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+func (c *AbilityScoreController) AbilityScores(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	data := abilityScoresFromForm(r)
+	if data.AbilityScoreMethod == string(character.AbilityScoreMethodRolled) {
+		rolls, err := c.service.GetRolls(data.ID, claims.UserId)
+		if err != nil {
+			grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		data.AbilityScoreRolls = rolls
+	}
+
+	c.renderAbilityScores(w, data)
+}
+
+func (c *AbilityScoreController) Roll(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	data := abilityScoresFromForm(r)
+	data.AbilityScoreMethod = string(character.AbilityScoreMethodRolled)
+
+	rolls, err := c.service.GetRolls(data.ID, claims.UserId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if len(rolls) == 0 {
+		rolls, err = c.service.Roll(claims.UserId)
+		if err != nil {
+			c.logger.Error("failed to roll ability scores", err)
+			grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+			return
+		}
+	}
+	data.AbilityScoreRolls = rolls
+
+	c.renderAbilityScores(w, data)
+}
@@ -6,22 +6,28 @@ import (
 	"dndcc/internal/models"
 	"dndcc/internal/models/page"
 	"dndcc/internal/services"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"net/http"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/StevenAlexanderJohnson/grove"
 )
 
 type CharacterController struct {
-	logger        grove.ILogger
-	service       *services.CharacterService
-	pageTemplates map[string]*template.Template
+	logger             grove.ILogger
+	service            *services.CharacterService
+	homebrewService    *services.HomebrewService
+	llmService         *services.LLMService
+	personalityService *services.PersonalityService
+	draftService       *services.CharacterDraftService
+	pageTemplates      map[string]*template.Template
 }
 
-func NewCharacterController(logger grove.ILogger, service *services.CharacterService) *CharacterController {
+func NewCharacterController(logger grove.ILogger, service *services.CharacterService, homebrewService *services.HomebrewService, llmService *services.LLMService, personalityService *services.PersonalityService, draftService *services.CharacterDraftService) *CharacterController {
 	pageTemplates := make(map[string]*template.Template)
 	funcMap := template.FuncMap{
 		"statCard": func(name string, score int, modifier int) map[string]interface{} {
@@ -31,36 +37,22 @@ func NewCharacterController(logger grove.ILogger, service *services.CharacterSer
 				"Modifier": modifier,
 			}
 		},
-		"skill": func(name string, char *character.Character, base character.StatName) map[string]interface{} {
+		"skill": func(name string, char *page.CharacterViewPageData, base character.StatName) map[string]interface{} {
 			skillName := character.SkillName(name)
-			hasProficiency := func() bool {
-				for _, prof := range char.Background.GetProficiencies() {
-					if prof == skillName {
-						return true
-					}
-				}
-				return false
-			}()
 			return map[string]interface{}{
-				"Name":           name,
-				"Bonus":          char.GetSkill(skillName),
-				"HasProficiency": hasProficiency,
-				"StatName":       base,
+				"CharacterID": char.ID,
+				"Name":        name,
+				"Bonus":       char.GetSkill(skillName),
+				"Proficiency": char.GetSkillProficiency(skillName).String(),
+				"StatName":    base,
 			}
 		},
-		"savingThrow": func(stat character.StatName, char *character.Character) map[string]interface{} {
-			hasProficiency := func() bool {
-				for _, prof := range character.ClassBarbarian.GetSavingThrowsProficiencies() {
-					if prof == stat {
-						return true
-					}
-				}
-				return false
-			}()
+		"savingThrow": func(stat character.StatName, char *page.CharacterViewPageData) map[string]interface{} {
 			bonus := char.GetSavingThrow(stat)
 			return map[string]interface{}{
+				"CharacterID":    char.ID,
 				"Name":           stat,
-				"HasProficiency": hasProficiency,
+				"HasProficiency": char.HasSavingThrowProficiency(stat),
 				"Bonus":          bonus,
 			}
 		},
@@ -71,13 +63,16 @@ func NewCharacterController(logger grove.ILogger, service *services.CharacterSer
 		"internal/templates/pages/characterList.html.tmpl",
 	))
 
-	pageTemplates["new"] = template.Must(template.New("edit").Funcs(template.FuncMap{
+	pageTemplates["new"] = template.Must(template.New("edit").Funcs(itemFuncMap).Funcs(template.FuncMap{
 		"isCustomBackground": func(list []character.BackgroundName, item string) bool {
 			return !slices.Contains(list, character.BackgroundName(item))
 		},
 		"isCustomRace": func(list []character.RaceName, item string) bool {
 			return !slices.Contains(list, character.RaceName(item))
 		},
+		"hasSkill": func(list []string, skill character.SkillName) bool {
+			return slices.Contains(list, string(skill))
+		},
 		"isCustomSubrace": func(list []character.SubraceName, item sql.NullString) bool {
 			if !item.Valid {
 				return false
@@ -86,31 +81,82 @@ func NewCharacterController(logger grove.ILogger, service *services.CharacterSer
 		},
 	}).ParseFiles(
 		"internal/templates/layouts/layout.html.tmpl",
+		"internal/templates/partials/bio.html.tmpl",
+		"internal/templates/partials/personality.html.tmpl",
+		"internal/templates/partials/describe.html.tmpl",
+		"internal/templates/partials/raceBonuses.html.tmpl",
+		"internal/templates/partials/classSkills.html.tmpl",
+		"internal/templates/partials/abilityScores.html.tmpl",
+		"internal/templates/partials/inventory.html.tmpl",
 		"internal/templates/pages/characterEdit.html.tmpl",
 	))
 
-	pageTemplates["character"] = template.Must(template.New("character").Funcs(funcMap).ParseFiles(
+	pageTemplates["character"] = template.Must(template.New("character").Funcs(funcMap).Funcs(spellFuncMap).ParseFiles(
 		"internal/templates/layouts/layout.html.tmpl",
 		"internal/templates/partials/statCard.html.tmpl",
 		"internal/templates/partials/skill.html.tmpl",
 		"internal/templates/partials/savingThrow.html.tmpl",
+		"internal/templates/partials/attack.html.tmpl",
+		"internal/templates/partials/spells.html.tmpl",
+		"internal/templates/partials/level.html.tmpl",
+		"internal/templates/partials/features.html.tmpl",
+		"internal/templates/partials/racialTraits.html.tmpl",
+		"internal/templates/partials/characterPersonality.html.tmpl",
+		"internal/templates/partials/abilityScoreImprovements.html.tmpl",
+		"internal/templates/partials/proficiencies.html.tmpl",
+		"internal/templates/partials/resources.html.tmpl",
+		"internal/templates/partials/hitPoints.html.tmpl",
+		"internal/templates/partials/conditions.html.tmpl",
 		"internal/templates/pages/character.html.tmpl",
 	))
 
 	return &CharacterController{
-		logger:        logger,
-		service:       service,
-		pageTemplates: pageTemplates,
+		logger:             logger,
+		service:            service,
+		homebrewService:    homebrewService,
+		llmService:         llmService,
+		personalityService: personalityService,
+		draftService:       draftService,
+		pageTemplates:      pageTemplates,
+	}
+}
+
+// editPageData builds the edit form data with the homebrew the user can pick. If the homebrew
+// can't be loaded the form still renders with the shared rules.
+func (c *CharacterController) editPageData(method, action, errorMessage string, data *models.Character, userId int) *page.CharacterEditPageData {
+	var ruleset character.RulesetVersion
+	if data != nil {
+		ruleset = character.RulesetVersion(data.Ruleset)
+		data.OwnerId = userId
+	}
+	rules, err := c.homebrewService.Rules(userId, ruleset)
+	if err != nil {
+		c.logger.Warning("failed to load homebrew for the character form", err)
+		rules = character.RulesFor(ruleset)
 	}
+	pageData := page.NewCharacterEditPageData(method, action, errorMessage, data, rules)
+	pageData.LLMEnabled = c.llmService.IsConfigured()
+	pageData.Personality.LLMEnabled = pageData.LLMEnabled
+	if pageData.Inventory.HomebrewItems, err = c.homebrewService.ListItems(userId); err != nil {
+		c.logger.Warning("failed to load homebrew items for the character form", err)
+	}
+	return pageData
 }
 
 func (c *CharacterController) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /character", c.Create)
 	mux.HandleFunc("GET /character", c.GetAll)
 	mux.HandleFunc("GET /character/new", c.NewCharacter)
+	mux.HandleFunc("GET /character/race-bonuses", c.RaceBonuses)
+	mux.HandleFunc("GET /character/class-skills", c.ClassSkills)
+	mux.HandleFunc("POST /character/bio", c.GenerateBio)
+	mux.HandleFunc("POST /character/personality", c.GeneratePersonality)
+	mux.HandleFunc("POST /character/describe", c.Describe)
 	mux.HandleFunc("GET /character/{id}", c.GetByID)
 	mux.HandleFunc("GET /character/{id}/edit", c.EditCharacter)
 	mux.HandleFunc("PUT /character/{id}", c.Update)
+	mux.HandleFunc("GET /character/{id}/bio/stream", c.StreamBio)
+	mux.HandleFunc("PUT /character/{id}/bio", c.SaveBio)
 	mux.HandleFunc("DELETE /character/{id}", c.Delete)
 }
 
@@ -131,7 +177,7 @@ func (c *CharacterController) Create(w http.ResponseWriter, r *http.Request) {
 	data, err := models.CharacterFromForm(r)
 	if err != nil {
 		c.logger.Warning("parsing request form to character in /character/new endpoint failed: %v", err)
-		if err := c.pageTemplates["new"].ExecuteTemplate(w, "layout.html.tmpl", page.NewCharacterEditPageData("/character", "post", err.Error(), data)); err != nil {
+		if err := c.pageTemplates["new"].ExecuteTemplate(w, "layout.html.tmpl", c.editPageData("/character", "post", err.Error(), data, claims.UserId)); err != nil {
 			c.logger.Error("an error occurred while rendering the edit page after failed create", err)
 			http.Error(w, "", http.StatusInternalServerError)
 		}
@@ -142,7 +188,7 @@ func (c *CharacterController) Create(w http.ResponseWriter, r *http.Request) {
 	_, err = c.service.Create(data)
 	if err != nil {
 		c.logger.Error("an error occurred while updating character", err)
-		pageData := page.NewCharacterEditPageData("post", "/character", err.Error(), data)
+		pageData := c.editPageData("post", "/character", err.Error(), data, claims.UserId)
 		if err := c.pageTemplates["new"].ExecuteTemplate(w, "content", pageData); err != nil {
 			c.logger.Error("an error occurred while rendering the edit page after failed update", err)
 			http.Error(w, "", http.StatusInternalServerError)
@@ -177,11 +223,31 @@ func (c *CharacterController) GetAll(w http.ResponseWriter, r *http.Request) {
 
 func (c *CharacterController) NewCharacter(w http.ResponseWriter, r *http.Request) {
 	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
-	pageData := page.NewPageData(ok, claims, page.NewCharacterEditPageData(
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+	// New characters use the latest rules unless an older ruleset is picked.
+	ruleset := character.RulesetVersion(r.URL.Query().Get("Ruleset"))
+	if !ruleset.IsValid() {
+		ruleset = character.Ruleset2024
+	}
+	pageData := page.NewPageData(ok, claims, c.editPageData(
 		"post",
 		"/character",
 		"",
-		&models.Character{Level: 1},
+		&models.Character{
+			Level:              1,
+			Ruleset:            string(ruleset),
+			AbilityScoreMethod: string(character.AbilityScoreMethodPointBuy),
+			Strength:           8,
+			Dexterity:          8,
+			Constitution:       8,
+			Intelligence:       8,
+			Wisdom:             8,
+			Charisma:           8,
+		},
+		claims.UserId,
 	))
 	if err := c.pageTemplates["new"].ExecuteTemplate(w, "layout.html.tmpl", pageData); err != nil {
 		c.logger.Error("failed to render template new within the character controller", err)
@@ -190,6 +256,236 @@ func (c *CharacterController) NewCharacter(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+func (c *CharacterController) RaceBonuses(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+	query := r.URL.Query()
+	data := &models.Character{
+		OwnerId:          claims.UserId,
+		Ruleset:          query.Get("Ruleset"),
+		Background:       query.Get("Background"),
+		RaceType:         query.Get("RaceType"),
+		SubraceType:      sql.NullString{String: query.Get("SubraceType"), Valid: true},
+		RaceStatChoices:  query["RaceStatChoice"],
+		DraconicAncestry: query.Get("DraconicAncestry"),
+	}
+	if err := c.pageTemplates["new"].ExecuteTemplate(w, "raceBonuses", page.NewRaceBonusesData(data)); err != nil {
+		c.logger.Error("failed to render race bonuses within the character controller", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+}
+
+// ClassSkills renders the class skill checkboxes for the class picked on the form, keeping the
+// ticked skills that are on the new class's list.
+func (c *CharacterController) ClassSkills(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+	query := r.URL.Query()
+	data := &models.Character{
+		OwnerId:       claims.UserId,
+		Class:         query.Get("ClassSelect"),
+		Proficiencies: models.SkillProficienciesFromForm(query["ClassSkill"], character.SourceClass),
+	}
+	if err := c.pageTemplates["new"].ExecuteTemplate(w, "classSkills", page.NewClassSkillsData(data)); err != nil {
+		c.logger.Error("failed to render class skills within the character controller", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+}
+
+// GenerateBio asks the LLM for a bio draft from the character form as it is filled in so far and
+// swaps it into the bio field. On failure the field keeps what the user wrote and shows the error.
+func (c *CharacterController) GenerateBio(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+	if !c.llmService.IsConfigured() {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, services.ErrLLMNotConfigured.Error())
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	data := models.CharacterDraftFromForm(r)
+	data.OwnerId = claims.UserId
+	output := &page.BioData{Bio: data.Bio}
+	bio, err := c.llmService.GenerateBio(r.Context(), data)
+	if err != nil {
+		c.logger.Warning("failed to generate a character bio", err)
+		output.Error = fmt.Sprintf("Failed to generate a bio: %v", err)
+	} else {
+		output.Bio = bio
+	}
+	if err := c.pageTemplates["new"].ExecuteTemplate(w, "bio", output); err != nil {
+		c.logger.Error("failed to render the bio within the character controller", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+}
+
+// GeneratePersonality fills in the personality fields from the character form as it is filled in
+// so far, written by the LLM or rolled on the background's tables. On failure the fields keep what
+// the user wrote and show the error.
+func (c *CharacterController) GeneratePersonality(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	data := models.CharacterDraftFromForm(r)
+	data.OwnerId = claims.UserId
+	personality, err := c.personalityService.Generate(r.Context(), data)
+	if err != nil {
+		c.logger.Warning("failed to generate a character personality", err)
+	} else {
+		data.SetPersonality(personality)
+	}
+	output := page.NewPersonalityData(data)
+	output.LLMEnabled = c.llmService.IsConfigured()
+	if err != nil {
+		output.Error = fmt.Sprintf("Failed to generate a personality: %v", err)
+	}
+	if err := c.pageTemplates["new"].ExecuteTemplate(w, "personality", output); err != nil {
+		c.logger.Error("failed to render the personality within the character controller", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+}
+
+// Describe creates a character from the player's description with the LLM and opens it for editing.
+// A character the LLM can't get right isn't saved, and the description is shown again with the error.
+func (c *CharacterController) Describe(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+	if !c.llmService.IsConfigured() {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, services.ErrLLMNotConfigured.Error())
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	description := r.FormValue("Description")
+	ruleset := character.RulesetVersion(r.FormValue("Ruleset"))
+	data, err := c.draftService.Create(r.Context(), claims.UserId, ruleset, description)
+	if err != nil {
+		c.logger.Warning("failed to create a character from a description", err)
+		output := &page.DescribeData{Description: description, Error: fmt.Sprintf("Failed to create the character: %v", err)}
+		if err := c.pageTemplates["new"].ExecuteTemplate(w, "describe", output); err != nil {
+			c.logger.Error("failed to render the description within the character controller", err)
+			grove.WriteErrorToResponse(w, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	w.Header().Set("HX-Redirect", fmt.Sprintf("/character/%d/edit", data.ID))
+}
+
+// StreamBio streams a bio draft for the saved character as server-sent events: a token event with
+// each piece of the draft as a JSON string, then done, or failed with the error message. The user
+// cancels the generation by closing the stream, which cancels the request to the LLM with it.
+func (c *CharacterController) StreamBio(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+	if !c.llmService.IsConfigured() {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, services.ErrLLMNotConfigured.Error())
+		return
+	}
+	id, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	item, err := c.service.Get(id, claims.UserId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if item == nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, "Item not found")
+		return
+	}
+
+	responseController := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	send := func(event string, data string) error {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, encoded); err != nil {
+			return err
+		}
+		return responseController.Flush()
+	}
+
+	err = c.llmService.StreamBio(r.Context(), item, func(token string) error {
+		return send("token", token)
+	})
+	if r.Context().Err() != nil {
+		return
+	}
+	if err != nil {
+		c.logger.Warning("failed to stream a character bio", err)
+		send("failed", fmt.Sprintf("Failed to generate a bio: %v", err))
+		return
+	}
+	send("done", "")
+}
+
+// SaveBio writes an accepted bio draft to the character and swaps it into the bio field.
+func (c *CharacterController) SaveBio(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+	id, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	output := &page.BioData{Bio: strings.TrimSpace(r.FormValue("Bio"))}
+	if err := c.service.SetBio(id, claims.UserId, output.Bio); err != nil {
+		c.logger.Error("failed to save the character bio", err)
+		output.Error = "Failed to save the bio"
+	}
+	if err := c.pageTemplates["new"].ExecuteTemplate(w, "bio", output); err != nil {
+		c.logger.Error("failed to render the bio within the character controller", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+}
+
 func (c *CharacterController) GetByID(w http.ResponseWriter, r *http.Request) {
 	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
 	if !ok {
@@ -252,11 +548,12 @@ func (c *CharacterController) EditCharacter(w http.ResponseWriter, r *http.Reque
 		grove.WriteErrorToResponse(w, http.StatusNotFound, "Item not found")
 		return
 	}
-	pageData := page.NewPageData(ok, claims, page.NewCharacterEditPageData(
+	pageData := page.NewPageData(ok, claims, c.editPageData(
 		"put",
 		fmt.Sprintf("/character/%d", item.ID),
 		"",
 		item,
+		claims.UserId,
 	))
 	if err := c.pageTemplates["new"].ExecuteTemplate(w, "layout.html.tmpl", pageData); err != nil {
 		c.logger.Error("failed to render template edit within the character controller", err)
@@ -282,7 +579,7 @@ func (c *CharacterController) Update(w http.ResponseWriter, r *http.Request) {
 	data, err := models.CharacterFromForm(r)
 	if err != nil {
 		c.logger.Warning("parsing request form to character in /character/new endpoint failed: %v", err)
-		if err := c.pageTemplates["new"].ExecuteTemplate(w, "layout.html.tmpl", page.NewCharacterEditPageData("/character", "post", err.Error(), data)); err != nil {
+		if err := c.pageTemplates["new"].ExecuteTemplate(w, "layout.html.tmpl", c.editPageData("/character", "post", err.Error(), data, claims.UserId)); err != nil {
 			c.logger.Error("an error occurred while rendering the edit page after failed create", err)
 			http.Error(w, "", http.StatusInternalServerError)
 		}
@@ -303,7 +600,7 @@ func (c *CharacterController) Update(w http.ResponseWriter, r *http.Request) {
 	updatedData, err := c.service.Update(data, id, claims.UserId)
 	if err != nil {
 		c.logger.Error("an error occurred while updating character", err)
-		pageData := page.NewCharacterEditPageData("put", fmt.Sprintf("/character/%d", id), err.Error(), data)
+		pageData := c.editPageData("put", fmt.Sprintf("/character/%d", id), err.Error(), data, claims.UserId)
 		if err := c.pageTemplates["new"].ExecuteTemplate(w, "content", pageData); err != nil {
 			c.logger.Error("an error occurred while rendering the edit page after failed update", err)
 			http.Error(w, "", http.StatusInternalServerError)
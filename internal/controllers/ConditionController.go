@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type ConditionController struct {
+	logger           grove.ILogger
+	service          *services.ConditionService
+	characterService *services.CharacterService
+	partialTemplates *template.Template
+}
+
+func NewConditionController(logger grove.ILogger, service *services.ConditionService, characterService *services.CharacterService) *ConditionController {
+	partialTemplates := template.Must(template.ParseFiles(
+		"internal/templates/partials/conditions.html.tmpl",
+	))
+
+	return &ConditionController{
+		logger:           logger,
+		service:          service,
+		characterService: characterService,
+		partialTemplates: partialTemplates,
+	}
+}
+
+func (c *ConditionController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/death-save", c.RollDeathSave)
+	mux.HandleFunc("POST /character/{id}/conditions", c.AddCondition)
+	mux.HandleFunc("POST /character/{id}/conditions/remove", c.RemoveCondition)
+	mux.HandleFunc("POST /character/{id}/conditions/round", c.EndRound)
+	mux.HandleFunc("POST /character/{id}/exhaustion", c.SetExhaustionLevel)
+}
+
+// renderConditions writes the conditions panel for the character, showing errorMessage if one is provided.
+func (c *ConditionController) renderConditions(w http.ResponseWriter, characterId, userId int, errorMessage string) {
+	item, err := c.characterService.Get(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	pageData := page.NewCharacterViewPageData(item.ID, item.ToCharacterSheet())
+	pageData.Error = errorMessage
+	if err := c.partialTemplates.ExecuteTemplate(w, "conditions", pageData); err != nil {
+		c.logger.Error("an error occurred while rendering the conditions panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+func (c *ConditionController) RollDeathSave(w http.ResponseWriter, r *http.Request) {
+	c.updateConditions(w, r, func(characterId, userId int) error {
+		return c.service.RollDeathSave(characterId, userId)
+	})
+}
+
+// AddCondition applies the condition for the number of rounds entered, or until removed when left blank.
+func (c *ConditionController) AddCondition(w http.ResponseWriter, r *http.Request) {
+	c.updateConditions(w, r, func(characterId, userId int) error {
+		rounds := 0
+		if input := r.FormValue("Rounds"); input != "" {
+			var err error
+			if rounds, err = strconv.Atoi(input); err != nil {
+				return fmt.Errorf("%w: %s", character.ErrInvalidConditionDuration, input)
+			}
+		}
+		return c.service.AddCondition(characterId, userId, character.ConditionName(r.FormValue("Condition")), rounds)
+	})
+}
+
+func (c *ConditionController) RemoveCondition(w http.ResponseWriter, r *http.Request) {
+	c.updateConditions(w, r, func(characterId, userId int) error {
+		return c.service.RemoveCondition(characterId, userId, character.ConditionName(r.FormValue("Condition")))
+	})
+}
+
+func (c *ConditionController) EndRound(w http.ResponseWriter, r *http.Request) {
+	c.updateConditions(w, r, c.service.EndRound)
+}
+
+func (c *ConditionController) SetExhaustionLevel(w http.ResponseWriter, r *http.Request) {
+	c.updateConditions(w, r, func(characterId, userId int) error {
+		level, err := strconv.Atoi(r.FormValue("ExhaustionLevel"))
+		if err != nil {
+			return fmt.Errorf("%w: %s", character.ErrInvalidExhaustionLevel, r.FormValue("ExhaustionLevel"))
+		}
+		return c.service.SetExhaustionLevel(characterId, userId, level)
+	})
+}
+
+// updateConditions runs update with the parsed form. The whole sheet is refreshed afterwards
+// because conditions and exhaustion change speed, hit points and roll modes.
+func (c *ConditionController) updateConditions(w http.ResponseWriter, r *http.Request, update func(characterId, userId int) error) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	if err := update(characterId, claims.UserId); err != nil {
+		c.logger.Warning("failed to update character conditions", err)
+		c.renderConditions(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	c.renderConditions(w, characterId, claims.UserId, "")
+}
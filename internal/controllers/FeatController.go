@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type FeatController struct {
+	logger           grove.ILogger
+	service          *services.FeatService
+	characterService *services.CharacterService
+	partialTemplates *template.Template
+}
+
+func NewFeatController(logger grove.ILogger, service *services.FeatService, characterService *services.CharacterService) *FeatController {
+	partialTemplates := template.Must(template.ParseFiles(
+		"internal/templates/partials/abilityScoreImprovements.html.tmpl",
+	))
+
+	return &FeatController{
+		logger:           logger,
+		service:          service,
+		characterService: characterService,
+		partialTemplates: partialTemplates,
+	}
+}
+
+func (c *FeatController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/ability-score-improvements", c.ChooseAbilityScoreImprovement)
+	mux.HandleFunc("POST /character/{id}/ability-score-improvements/remove", c.RemoveAbilityScoreImprovement)
+}
+
+// renderAbilityScoreImprovements writes the ability score improvements panel for the character,
+// showing errorMessage if one is provided.
+func (c *FeatController) renderAbilityScoreImprovements(w http.ResponseWriter, characterId, userId int, errorMessage string) {
+	item, err := c.characterService.Get(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	pageData := page.NewCharacterViewPageData(item.ID, item.ToCharacterSheet())
+	pageData.Error = errorMessage
+	if err := c.partialTemplates.ExecuteTemplate(w, "abilityScoreImprovements", pageData); err != nil {
+		c.logger.Error("an error occurred while rendering the ability score improvements panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+// ChooseAbilityScoreImprovement raises the two chosen scores, or takes the chosen feat using the
+// first score when the feat offers a choice. The whole sheet is refreshed afterwards because
+// ability scores feed into most of it.
+func (c *FeatController) ChooseAbilityScoreImprovement(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	level, err := strconv.Atoi(r.FormValue("Level"))
+	if err != nil {
+		c.renderAbilityScoreImprovements(w, characterId, claims.UserId, "invalid value was passed for level: "+r.FormValue("Level"))
+		return
+	}
+	choice := character.AbilityScoreImprovement{
+		Class: character.ClassName(r.FormValue("Class")),
+		Level: level,
+		Feat:  character.FeatName(r.FormValue("Feat")),
+		Stats: []character.StatName{},
+	}
+	for _, stat := range r.Form["Stat"] {
+		choice.Stats = append(choice.Stats, character.StatName(stat))
+	}
+
+	if err := c.service.ChooseAbilityScoreImprovement(characterId, claims.UserId, choice); err != nil {
+		c.logger.Warning("failed to choose ability score improvement", err)
+		c.renderAbilityScoreImprovements(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	c.renderAbilityScoreImprovements(w, characterId, claims.UserId, "")
+}
+
+func (c *FeatController) RemoveAbilityScoreImprovement(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	level, err := strconv.Atoi(r.FormValue("Level"))
+	if err != nil {
+		c.renderAbilityScoreImprovements(w, characterId, claims.UserId, "invalid value was passed for level: "+r.FormValue("Level"))
+		return
+	}
+	if err := c.service.RemoveAbilityScoreImprovement(characterId, claims.UserId, character.ClassName(r.FormValue("Class")), level); err != nil {
+		c.logger.Warning("failed to remove ability score improvement", err)
+		c.renderAbilityScoreImprovements(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	c.renderAbilityScoreImprovements(w, characterId, claims.UserId, "")
+}
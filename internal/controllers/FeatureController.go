@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"html/template"
+	"net/http"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type FeatureController struct {
+	logger           grove.ILogger
+	service          *services.FeatureService
+	characterService *services.CharacterService
+	partialTemplates *template.Template
+}
+
+func NewFeatureController(logger grove.ILogger, service *services.FeatureService, characterService *services.CharacterService) *FeatureController {
+	partialTemplates := template.Must(template.ParseFiles(
+		"internal/templates/partials/features.html.tmpl",
+	))
+
+	return &FeatureController{
+		logger:           logger,
+		service:          service,
+		characterService: characterService,
+		partialTemplates: partialTemplates,
+	}
+}
+
+func (c *FeatureController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/subclass", c.SetSubclass)
+	mux.HandleFunc("POST /character/{id}/features/use", c.UseFeature)
+	mux.HandleFunc("POST /character/{id}/features/restore", c.RestoreFeature)
+}
+
+// renderFeatures writes the features panel for the character, showing errorMessage if one is provided.
+func (c *FeatureController) renderFeatures(w http.ResponseWriter, characterId, userId int, errorMessage string) {
+	item, err := c.characterService.Get(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	pageData := page.NewCharacterViewPageData(item.ID, item.ToCharacterSheet())
+	pageData.Error = errorMessage
+	if err := c.partialTemplates.ExecuteTemplate(w, "features", pageData); err != nil {
+		c.logger.Error("an error occurred while rendering the features panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+func (c *FeatureController) SetSubclass(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	class := character.ClassName(r.FormValue("Class"))
+	subclass := character.SubclassName(r.FormValue("Subclass"))
+	if err := c.service.SetSubclass(characterId, claims.UserId, class, subclass); err != nil {
+		c.logger.Warning("failed to choose subclass", err)
+		c.renderFeatures(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderFeatures(w, characterId, claims.UserId, "")
+}
+
+func (c *FeatureController) UseFeature(w http.ResponseWriter, r *http.Request) {
+	c.updateUses(w, r, c.service.UseFeature)
+}
+
+func (c *FeatureController) RestoreFeature(w http.ResponseWriter, r *http.Request) {
+	c.updateUses(w, r, c.service.RestoreFeature)
+}
+
+func (c *FeatureController) updateUses(w http.ResponseWriter, r *http.Request, update func(characterId, userId int, feature string) error) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	if err := update(characterId, claims.UserId, r.FormValue("Feature")); err != nil {
+		c.logger.Warning("failed to update feature uses", err)
+		c.renderFeatures(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderFeatures(w, characterId, claims.UserId, "")
+}
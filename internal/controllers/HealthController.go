@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type HealthController struct {
+	logger           grove.ILogger
+	service          *services.HealthService
+	characterService *services.CharacterService
+	partialTemplates *template.Template
+}
+
+func NewHealthController(logger grove.ILogger, service *services.HealthService, characterService *services.CharacterService) *HealthController {
+	partialTemplates := template.Must(template.ParseFiles(
+		"internal/templates/partials/hitPoints.html.tmpl",
+	))
+
+	return &HealthController{
+		logger:           logger,
+		service:          service,
+		characterService: characterService,
+		partialTemplates: partialTemplates,
+	}
+}
+
+func (c *HealthController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/damage", c.Damage)
+	mux.HandleFunc("POST /character/{id}/heal", c.Heal)
+	mux.HandleFunc("POST /character/{id}/temp-hp", c.SetTemporaryHitPoints)
+	mux.HandleFunc("POST /character/{id}/max-hp", c.SetMaxHitPoints)
+}
+
+// renderHitPoints writes the hit points panel for the character, showing errorMessage if one is provided.
+func (c *HealthController) renderHitPoints(w http.ResponseWriter, characterId, userId int, errorMessage string) {
+	item, err := c.characterService.Get(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	pageData := page.NewCharacterViewPageData(item.ID, item.ToCharacterSheet())
+	pageData.Error = errorMessage
+	if err := c.partialTemplates.ExecuteTemplate(w, "hitPoints", pageData); err != nil {
+		c.logger.Error("an error occurred while rendering the hit points panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+func (c *HealthController) Damage(w http.ResponseWriter, r *http.Request) {
+	c.updateHealth(w, r, "Amount", func(characterId, userId, amount int) error {
+		_, err := c.service.Damage(characterId, userId, amount, character.DamageType(r.FormValue("DamageType")), r.FormValue("Critical") == "on")
+		return err
+	})
+}
+
+func (c *HealthController) Heal(w http.ResponseWriter, r *http.Request) {
+	c.updateHealth(w, r, "Amount", c.service.Heal)
+}
+
+func (c *HealthController) SetTemporaryHitPoints(w http.ResponseWriter, r *http.Request) {
+	c.updateHealth(w, r, "TemporaryHitPoints", c.service.SetTemporaryHitPoints)
+}
+
+func (c *HealthController) SetMaxHitPoints(w http.ResponseWriter, r *http.Request) {
+	c.updateHealth(w, r, "MaxHitPoints", c.service.SetMaxHitPointsOverride)
+}
+
+// updateHealth reads a whole number from the form field and passes it to update. An empty field
+// counts as 0 so an override can be cleared by submitting it blank.
+func (c *HealthController) updateHealth(w http.ResponseWriter, r *http.Request, field string, update func(characterId, userId, value int) error) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	value := 0
+	if input := r.FormValue(field); input != "" {
+		value, err = strconv.Atoi(input)
+		if err != nil {
+			c.renderHitPoints(w, characterId, claims.UserId, "invalid value was passed for hit points: "+input)
+			return
+		}
+	}
+
+	if err := update(characterId, claims.UserId, value); err != nil {
+		c.logger.Warning("failed to update character hit points", err)
+		c.renderHitPoints(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderHitPoints(w, characterId, claims.UserId, "")
+}
@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type HomebrewController struct {
+	logger       grove.ILogger
+	service      *services.HomebrewService
+	pageTemplate *template.Template
+}
+
+func NewHomebrewController(logger grove.ILogger, service *services.HomebrewService) *HomebrewController {
+	pageTemplate := template.Must(template.ParseFiles(
+		"internal/templates/layouts/layout.html.tmpl",
+		"internal/templates/partials/homebrewEditor.html.tmpl",
+		"internal/templates/pages/homebrew.html.tmpl",
+	))
+
+	return &HomebrewController{
+		logger:       logger,
+		service:      service,
+		pageTemplate: pageTemplate,
+	}
+}
+
+func (c *HomebrewController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /homebrew", c.List)
+	mux.HandleFunc("GET /homebrew/template", c.Template)
+	mux.HandleFunc("GET /homebrew/{id}", c.GetByID)
+	mux.HandleFunc("POST /homebrew", c.Create)
+	mux.HandleFunc("PUT /homebrew/{id}", c.Update)
+	mux.HandleFunc("DELETE /homebrew/{id}", c.Delete)
+}
+
+// renderPage writes the homebrew list with the editor open on homebrew.
+func (c *HomebrewController) renderPage(w http.ResponseWriter, claims *models.Claims, homebrew *models.Homebrew) {
+	entries, err := c.service.List(claims.UserId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	pageData := page.NewPageData(true, claims, &page.HomebrewPageData{
+		UserID:  claims.UserId,
+		Entries: entries,
+		Editor:  page.NewHomebrewEditorData(homebrew, claims.UserId, ""),
+	})
+	if err := c.pageTemplate.ExecuteTemplate(w, "layout.html.tmpl", pageData); err != nil {
+		c.logger.Error("failed to render the homebrew page", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+// renderEditor writes the editor panel for homebrew, showing errorMessage if one is provided.
+func (c *HomebrewController) renderEditor(w http.ResponseWriter, homebrew *models.Homebrew, userId int, errorMessage string) {
+	if err := c.pageTemplate.ExecuteTemplate(w, "homebrewEditor", page.NewHomebrewEditorData(homebrew, userId, errorMessage)); err != nil {
+		c.logger.Error("failed to render the homebrew editor", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+func (c *HomebrewController) List(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	c.renderPage(w, claims, page.NewHomebrew(models.HomebrewKind(r.URL.Query().Get("Kind"))))
+}
+
+// Template renders the editor for new homebrew of the picked kind, starting from its template.
+func (c *HomebrewController) Template(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	c.renderEditor(w, page.NewHomebrew(models.HomebrewKind(r.URL.Query().Get("Kind"))), claims.UserId, "")
+}
+
+func (c *HomebrewController) GetByID(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	id, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	homebrew, err := c.service.Get(id, claims.UserId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	c.renderPage(w, claims, homebrew)
+}
+
+func (c *HomebrewController) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	data, err := models.HomebrewFromForm(r)
+	if err != nil {
+		c.renderEditor(w, page.NewHomebrew(models.HomebrewKind(r.FormValue("Kind"))), claims.UserId, err.Error())
+		return
+	}
+
+	created, err := c.service.Create(data, claims.UserId)
+	if err != nil {
+		c.logger.Warning("failed to create homebrew", err)
+		c.renderEditor(w, data, claims.UserId, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Redirect", fmt.Sprintf("/homebrew/%d", created.ID))
+}
+
+func (c *HomebrewController) Update(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	id, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	data, err := models.HomebrewFromForm(r)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := c.service.Update(data, id, claims.UserId); err != nil {
+		c.logger.Warning("failed to update homebrew", err)
+		data.OwnerId = claims.UserId
+		c.renderEditor(w, data, claims.UserId, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Redirect", fmt.Sprintf("/homebrew/%d", id))
+}
+
+func (c *HomebrewController) Delete(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	id, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.service.Delete(id, claims.UserId); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/homebrew")
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type ItemController struct {
+	logger           grove.ILogger
+	service          *services.ItemService
+	characterService *services.CharacterService
+	homebrewService  *services.HomebrewService
+	partialTemplates *template.Template
+}
+
+func NewItemController(logger grove.ILogger, service *services.ItemService, characterService *services.CharacterService, homebrewService *services.HomebrewService) *ItemController {
+	partialTemplates := template.Must(template.New("inventory").Funcs(itemFuncMap).ParseFiles(
+		"internal/templates/partials/inventory.html.tmpl",
+	))
+
+	return &ItemController{
+		logger:           logger,
+		service:          service,
+		characterService: characterService,
+		homebrewService:  homebrewService,
+		partialTemplates: partialTemplates,
+	}
+}
+
+var itemFuncMap = template.FuncMap{
+	"itemTypes": func() []character.ItemType {
+		return character.ItemTypes
+	},
+	"armorCategories": func() []character.ArmorCategory {
+		return character.ArmorCategories
+	},
+	"weaponCategories": func() []character.WeaponCategory {
+		return character.WeaponCategories
+	},
+	"weaponProperties": func() []character.WeaponProperty {
+		return character.WeaponProperties
+	},
+}
+
+func (c *ItemController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/items", c.Create)
+	mux.HandleFunc("POST /character/{id}/items/homebrew", c.CreateFromHomebrew)
+	mux.HandleFunc("PUT /character/{id}/items/{itemId}/equipped", c.SetEquipped)
+	mux.HandleFunc("DELETE /character/{id}/items/{itemId}", c.Delete)
+}
+
+// renderInventory writes the inventory panel for the character, showing errorMessage if one is provided.
+func (c *ItemController) renderInventory(w http.ResponseWriter, characterId, userId int, errorMessage string) {
+	item, err := c.characterService.Get(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	data := page.NewInventoryData(item)
+	data.Error = errorMessage
+	if data.HomebrewItems, err = c.homebrewService.ListItems(userId); err != nil {
+		c.logger.Warning("failed to load homebrew items for the inventory panel", err)
+	}
+	if err := c.partialTemplates.ExecuteTemplate(w, "inventory", data); err != nil {
+		c.logger.Error("an error occurred while rendering the inventory panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+func (c *ItemController) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	data, err := models.CharacterItemFromForm(r)
+	if err != nil {
+		c.renderInventory(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	if _, err := c.service.Create(data, characterId, claims.UserId); err != nil {
+		c.logger.Warning("failed to add item to character", err)
+		c.renderInventory(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderInventory(w, characterId, claims.UserId, "")
+}
+
+// CreateFromHomebrew adds a copy of one of the user's own or shared homebrew items.
+func (c *ItemController) CreateFromHomebrew(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	homebrewId, err := strconv.Atoi(r.FormValue("HomebrewID"))
+	if err != nil {
+		c.renderInventory(w, characterId, claims.UserId, "pick a homebrew item to add")
+		return
+	}
+	homebrew, err := c.homebrewService.Get(homebrewId, claims.UserId)
+	if err != nil {
+		c.renderInventory(w, characterId, claims.UserId, err.Error())
+		return
+	}
+	item, err := homebrew.ToItem()
+	if err != nil {
+		c.renderInventory(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	if _, err := c.service.Create(models.CharacterItemFromItem(item), characterId, claims.UserId); err != nil {
+		c.logger.Warning("failed to add homebrew item to character", err)
+		c.renderInventory(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderInventory(w, characterId, claims.UserId, "")
+}
+
+func (c *ItemController) SetEquipped(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	itemId, err := parsePathId(r, "itemId")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	if err := c.service.SetEquipped(itemId, characterId, claims.UserId, r.FormValue("Equipped") == "on"); err != nil {
+		c.logger.Warning("failed to update equipped item", err)
+		c.renderInventory(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderInventory(w, characterId, claims.UserId, "")
+}
+
+func (c *ItemController) Delete(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	itemId, err := parsePathId(r, "itemId")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.service.Delete(itemId, characterId, claims.UserId); err != nil {
+		c.logger.Warning("failed to remove item from character", err)
+		c.renderInventory(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderInventory(w, characterId, claims.UserId, "")
+}
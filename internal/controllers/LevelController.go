@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type LevelController struct {
+	logger           grove.ILogger
+	service          *services.LevelService
+	characterService *services.CharacterService
+	partialTemplates *template.Template
+}
+
+func NewLevelController(logger grove.ILogger, service *services.LevelService, characterService *services.CharacterService) *LevelController {
+	partialTemplates := template.Must(template.ParseFiles(
+		"internal/templates/partials/level.html.tmpl",
+	))
+
+	return &LevelController{
+		logger:           logger,
+		service:          service,
+		characterService: characterService,
+		partialTemplates: partialTemplates,
+	}
+}
+
+func (c *LevelController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/experience", c.AddExperience)
+	mux.HandleFunc("GET /character/{id}/level-up", c.LevelUpSummary)
+	mux.HandleFunc("POST /character/{id}/level-up", c.LevelUp)
+}
+
+// renderLevel writes the level panel for the character, showing errorMessage if one is provided.
+func (c *LevelController) renderLevel(w http.ResponseWriter, characterId, userId int, levelUpClass character.ClassName, errorMessage string) {
+	item, err := c.characterService.Get(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	pageData := page.NewCharacterViewPageData(item.ID, item.ToCharacterSheet())
+	pageData.Error = errorMessage
+	pageData.LevelUpClass = levelUpClass
+	if err := c.partialTemplates.ExecuteTemplate(w, "level", pageData); err != nil {
+		c.logger.Error("an error occurred while rendering the level panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+func (c *LevelController) AddExperience(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	amount, err := strconv.Atoi(r.FormValue("Experience"))
+	if err != nil {
+		c.renderLevel(w, characterId, claims.UserId, "", "invalid value was passed for experience: "+r.FormValue("Experience"))
+		return
+	}
+
+	if err := c.service.AddExperience(characterId, claims.UserId, amount); err != nil {
+		c.logger.Warning("failed to add experience to character", err)
+		c.renderLevel(w, characterId, claims.UserId, "", err.Error())
+		return
+	}
+
+	c.renderLevel(w, characterId, claims.UserId, "", "")
+}
+
+// LevelUpSummary renders the level panel with the summary for the class picked in the level up select.
+func (c *LevelController) LevelUpSummary(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.renderLevel(w, characterId, claims.UserId, character.ClassName(r.URL.Query().Get("Class")), "")
+}
+
+// LevelUp advances the character a level. The whole sheet is refreshed afterwards because
+// most derived values depend on level.
+func (c *LevelController) LevelUp(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	class := character.ClassName(r.FormValue("Class"))
+	if _, err := c.service.LevelUp(characterId, claims.UserId, class, r.FormValue("HitPoints") == "roll"); err != nil {
+		c.logger.Warning("failed to level up character", err)
+		c.renderLevel(w, characterId, claims.UserId, class, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	c.renderLevel(w, characterId, claims.UserId, "", "")
+}
@@ -0,0 +1,120 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type ProficiencyController struct {
+	logger           grove.ILogger
+	service          *services.ProficiencyService
+	characterService *services.CharacterService
+	partialTemplates *template.Template
+}
+
+func NewProficiencyController(logger grove.ILogger, service *services.ProficiencyService, characterService *services.CharacterService) *ProficiencyController {
+	partialTemplates := template.Must(template.ParseFiles(
+		"internal/templates/partials/proficiencies.html.tmpl",
+	))
+
+	return &ProficiencyController{
+		logger:           logger,
+		service:          service,
+		characterService: characterService,
+		partialTemplates: partialTemplates,
+	}
+}
+
+func (c *ProficiencyController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/proficiencies", c.GrantProficiency)
+	mux.HandleFunc("POST /character/{id}/proficiencies/remove", c.RevokeProficiency)
+	mux.HandleFunc("POST /character/{id}/expertise", c.ChooseExpertise)
+	mux.HandleFunc("POST /character/{id}/expertise/remove", c.RemoveExpertise)
+}
+
+// renderProficiencies writes the proficiencies panel for the character, showing errorMessage if one is provided.
+func (c *ProficiencyController) renderProficiencies(w http.ResponseWriter, characterId, userId int, errorMessage string) {
+	item, err := c.characterService.Get(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	pageData := page.NewCharacterViewPageData(item.ID, item.ToCharacterSheet())
+	pageData.Error = errorMessage
+	if err := c.partialTemplates.ExecuteTemplate(w, "proficiencies", pageData); err != nil {
+		c.logger.Error("an error occurred while rendering the proficiencies panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+func (c *ProficiencyController) GrantProficiency(w http.ResponseWriter, r *http.Request) {
+	c.updateProficiencies(w, r, func(characterId, userId int) error {
+		return c.service.GrantProficiency(characterId, userId, character.ProficiencyKind(r.FormValue("Kind")),
+			r.FormValue("Name"), character.ProficiencySource(r.FormValue("Source")))
+	})
+}
+
+func (c *ProficiencyController) RevokeProficiency(w http.ResponseWriter, r *http.Request) {
+	c.updateProficiencies(w, r, func(characterId, userId int) error {
+		return c.service.RevokeProficiency(characterId, userId, character.ProficiencyKind(r.FormValue("Kind")),
+			r.FormValue("Name"), character.ProficiencySource(r.FormValue("Source")))
+	})
+}
+
+// ChooseExpertise takes the Expertise form value as "Kind:Name", matching the options the panel offers.
+func (c *ProficiencyController) ChooseExpertise(w http.ResponseWriter, r *http.Request) {
+	c.updateProficiencies(w, r, func(characterId, userId int) error {
+		kind, name := parseProficiencyOption(r.FormValue("Expertise"))
+		return c.service.ChooseExpertise(characterId, userId, kind, name)
+	})
+}
+
+func (c *ProficiencyController) RemoveExpertise(w http.ResponseWriter, r *http.Request) {
+	c.updateProficiencies(w, r, func(characterId, userId int) error {
+		return c.service.RemoveExpertise(characterId, userId, character.ProficiencyKind(r.FormValue("Kind")), r.FormValue("Name"))
+	})
+}
+
+// updateProficiencies runs update with the parsed form. The whole sheet is refreshed afterwards
+// because proficiencies change skill bonuses and attacks.
+func (c *ProficiencyController) updateProficiencies(w http.ResponseWriter, r *http.Request, update func(characterId, userId int) error) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	if err := update(characterId, claims.UserId); err != nil {
+		c.logger.Warning("failed to update character proficiencies", err)
+		c.renderProficiencies(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	c.renderProficiencies(w, characterId, claims.UserId, "")
+}
+
+// parseProficiencyOption splits a "Kind:Name" option value into its parts.
+func parseProficiencyOption(value string) (character.ProficiencyKind, string) {
+	kind, name, _ := strings.Cut(value, ":")
+	return character.ProficiencyKind(kind), name
+}
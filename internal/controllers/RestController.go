@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type RestController struct {
+	logger           grove.ILogger
+	service          *services.RestService
+	characterService *services.CharacterService
+	partialTemplates *template.Template
+}
+
+func NewRestController(logger grove.ILogger, service *services.RestService, characterService *services.CharacterService) *RestController {
+	partialTemplates := template.Must(template.ParseFiles(
+		"internal/templates/partials/resources.html.tmpl",
+	))
+
+	return &RestController{
+		logger:           logger,
+		service:          service,
+		characterService: characterService,
+		partialTemplates: partialTemplates,
+	}
+}
+
+func (c *RestController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/rest/short", c.ShortRest)
+	mux.HandleFunc("POST /character/{id}/rest/long", c.LongRest)
+}
+
+// renderResources writes the resources panel for the character, showing errorMessage if one is provided.
+func (c *RestController) renderResources(w http.ResponseWriter, characterId, userId int, errorMessage string) {
+	item, err := c.characterService.Get(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	pageData := page.NewCharacterViewPageData(item.ID, item.ToCharacterSheet())
+	pageData.Error = errorMessage
+	if err := c.partialTemplates.ExecuteTemplate(w, "resources", pageData); err != nil {
+		c.logger.Error("an error occurred while rendering the resources panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+// ShortRest spends the hit dice entered for each die size and regains short rest resources.
+// The whole sheet is refreshed afterwards because hit points, features and slots all change.
+func (c *RestController) ShortRest(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	hitDice := make(map[character.HitDie]int)
+	for _, die := range []character.HitDie{character.HitDieD12, character.HitDieD10, character.HitDieD8, character.HitDieD6} {
+		value := r.FormValue(fmt.Sprintf("HitDice%d", die))
+		if value == "" {
+			continue
+		}
+		count, err := strconv.Atoi(value)
+		if err != nil {
+			c.renderResources(w, characterId, claims.UserId, fmt.Sprintf("invalid value was passed for d%d hit dice: %s", die, value))
+			return
+		}
+		hitDice[die] = count
+	}
+
+	if _, err := c.service.ShortRest(characterId, claims.UserId, hitDice); err != nil {
+		c.logger.Warning("failed to take a short rest", err)
+		c.renderResources(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	c.renderResources(w, characterId, claims.UserId, "")
+}
+
+func (c *RestController) LongRest(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.service.LongRest(characterId, claims.UserId); err != nil {
+		c.logger.Warning("failed to take a long rest", err)
+		c.renderResources(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	c.renderResources(w, characterId, claims.UserId, "")
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"html/template"
+	"net/http"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type RollController struct {
+	logger           grove.ILogger
+	service          *services.RollService
+	partialTemplates *template.Template
+}
+
+func NewRollController(logger grove.ILogger, service *services.RollService) *RollController {
+	partialTemplates := template.Must(template.ParseFiles(
+		"internal/templates/partials/rollLog.html.tmpl",
+	))
+
+	return &RollController{
+		logger:           logger,
+		service:          service,
+		partialTemplates: partialTemplates,
+	}
+}
+
+func (c *RollController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/roll", c.Roll)
+	mux.HandleFunc("GET /character/{id}/rolls", c.List)
+}
+
+// renderRollLog writes the roll log for the character, showing errorMessage if one is provided.
+func (c *RollController) renderRollLog(w http.ResponseWriter, characterId, userId int, errorMessage string) {
+	rolls, err := c.service.List(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	pageData := &page.RollLogData{
+		CharacterID: characterId,
+		Error:       errorMessage,
+		Rolls:       rolls,
+	}
+	if err := c.partialTemplates.ExecuteTemplate(w, "rollLog", pageData); err != nil {
+		c.logger.Error("an error occurred while rendering the roll log", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+// Roll rolls a skill check, saving throw or free-form expression depending on which form field is set.
+func (c *RollController) Roll(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	switch {
+	case r.FormValue("Skill") != "":
+		_, err = c.service.RollSkill(characterId, claims.UserId, character.SkillName(r.FormValue("Skill")))
+	case r.FormValue("Save") != "":
+		_, err = c.service.RollSavingThrow(characterId, claims.UserId, character.StatName(r.FormValue("Save")))
+	default:
+		_, err = c.service.Roll(characterId, claims.UserId, r.FormValue("Label"), r.FormValue("Expression"))
+	}
+	if err != nil {
+		c.logger.Warning("failed to roll for character", err)
+		c.renderRollLog(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderRollLog(w, characterId, claims.UserId, "")
+}
+
+func (c *RollController) List(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.renderRollLog(w, characterId, claims.UserId, "")
+}
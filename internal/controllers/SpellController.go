@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/models/page"
+	"dndcc/internal/services"
+	"html/template"
+	"net/http"
+
+	"github.com/StevenAlexanderJohnson/grove"
+)
+
+type SpellController struct {
+	logger           grove.ILogger
+	service          *services.SpellService
+	characterService *services.CharacterService
+	partialTemplates *template.Template
+}
+
+func NewSpellController(logger grove.ILogger, service *services.SpellService, characterService *services.CharacterService) *SpellController {
+	partialTemplates := template.Must(template.New("spells").Funcs(spellFuncMap).ParseFiles(
+		"internal/templates/partials/spells.html.tmpl",
+	))
+
+	return &SpellController{
+		logger:           logger,
+		service:          service,
+		characterService: characterService,
+		partialTemplates: partialTemplates,
+	}
+}
+
+var spellFuncMap = template.FuncMap{
+	"spellSchools": func() []character.SpellSchool {
+		return character.SpellSchools
+	},
+}
+
+func (c *SpellController) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /character/{id}/spells", c.Create)
+	mux.HandleFunc("PUT /character/{id}/spells/{spellId}/prepared", c.SetPrepared)
+	mux.HandleFunc("DELETE /character/{id}/spells/{spellId}", c.Delete)
+	mux.HandleFunc("POST /character/{id}/slots/{level}/expend", c.ExpendSlot)
+	mux.HandleFunc("POST /character/{id}/slots/{level}/restore", c.RestoreSlot)
+}
+
+// renderSpells writes the spells panel for the character, showing errorMessage if one is provided.
+func (c *SpellController) renderSpells(w http.ResponseWriter, characterId, userId int, errorMessage string) {
+	item, err := c.characterService.Get(characterId, userId)
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	pageData := page.NewCharacterViewPageData(item.ID, item.ToCharacterSheet())
+	pageData.Error = errorMessage
+	if err := c.partialTemplates.ExecuteTemplate(w, "spells", pageData); err != nil {
+		c.logger.Error("an error occurred while rendering the spells panel", err)
+		grove.WriteErrorToResponse(w, http.StatusInternalServerError, "")
+	}
+}
+
+func (c *SpellController) Create(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	data, err := models.CharacterSpellFromForm(r)
+	if err != nil {
+		c.renderSpells(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	if _, err := c.service.Create(data, characterId, claims.UserId); err != nil {
+		c.logger.Warning("failed to add spell to character", err)
+		c.renderSpells(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderSpells(w, characterId, claims.UserId, "")
+}
+
+func (c *SpellController) SetPrepared(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	spellId, err := parsePathId(r, "spellId")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, "failed to parse form")
+		return
+	}
+
+	if err := c.service.SetPrepared(spellId, characterId, claims.UserId, r.FormValue("Prepared") == "on"); err != nil {
+		c.logger.Warning("failed to update prepared spell", err)
+		c.renderSpells(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderSpells(w, characterId, claims.UserId, "")
+}
+
+func (c *SpellController) Delete(w http.ResponseWriter, r *http.Request) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	spellId, err := parsePathId(r, "spellId")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.service.Delete(spellId, characterId, claims.UserId); err != nil {
+		c.logger.Warning("failed to remove spell from character", err)
+		c.renderSpells(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderSpells(w, characterId, claims.UserId, "")
+}
+
+func (c *SpellController) ExpendSlot(w http.ResponseWriter, r *http.Request) {
+	c.updateSlot(w, r, c.service.ExpendSlot)
+}
+
+func (c *SpellController) RestoreSlot(w http.ResponseWriter, r *http.Request) {
+	c.updateSlot(w, r, c.service.RestoreSlot)
+}
+
+func (c *SpellController) updateSlot(w http.ResponseWriter, r *http.Request, update func(characterId, userId, level int, pact bool) error) {
+	claims, ok := r.Context().Value(grove.AuthTokenKey).(*models.Claims)
+	if !ok {
+		grove.WriteErrorToResponse(w, http.StatusUnauthorized, "")
+		return
+	}
+
+	characterId, err := parsePathId(r, "id")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	level, err := parsePathId(r, "level")
+	if err != nil {
+		grove.WriteErrorToResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	pact := r.URL.Query().Get("pact") == "true"
+
+	if err := update(characterId, claims.UserId, level, pact); err != nil {
+		c.logger.Warning("failed to update spell slots", err)
+		c.renderSpells(w, characterId, claims.UserId, err.Error())
+		return
+	}
+
+	c.renderSpells(w, characterId, claims.UserId, "")
+}
@@ -2,7 +2,9 @@ package controllers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -58,3 +60,16 @@ func clearAuthCookies(w http.ResponseWriter) {
 	SetAuthCookie(w, "", -1)
 	SetSessionCookie(w, "", -1)
 }
+
+// parsePathId reads an integer ID from the named path value of the request.
+func parsePathId(r *http.Request, name string) (int, error) {
+	idString := r.PathValue(name)
+	if idString == "" {
+		return 0, fmt.Errorf("%s is required", name)
+	}
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s format", name)
+	}
+	return id, nil
+}
@@ -0,0 +1,228 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrEmptyExpression   = errors.New("dice expression cannot be empty")
+	ErrInvalidExpression = errors.New("invalid dice expression")
+	ErrInvalidRollMode   = errors.New("advantage and disadvantage require exactly one single-die roll")
+)
+
+const (
+	maxDiceCount = 100
+	maxDiceSides = 1000
+)
+
+// RNG is the source of randomness used when rolling. IntN returns a value in [0, n).
+type RNG interface {
+	IntN(n int) int
+}
+
+type defaultRNG struct{}
+
+func (defaultRNG) IntN(n int) int {
+	return rand.IntN(n)
+}
+
+type RollMode string
+
+const (
+	RollNormal       RollMode = ""
+	RollAdvantage    RollMode = "adv"
+	RollDisadvantage RollMode = "dis"
+)
+
+type KeepRule string
+
+const (
+	KeepAll     KeepRule = ""
+	KeepHighest KeepRule = "kh"
+	KeepLowest  KeepRule = "kl"
+)
+
+// Term is a single part of an expression, either a group of dice or a flat modifier.
+type Term struct {
+	Negative  bool
+	Count     int
+	Sides     int
+	Keep      KeepRule
+	KeepCount int
+	Constant  int
+}
+
+func (t Term) IsDice() bool {
+	return t.Sides > 0
+}
+
+func (t Term) String() string {
+	if !t.IsDice() {
+		return strconv.Itoa(t.Constant)
+	}
+	output := fmt.Sprintf("%dd%d", t.Count, t.Sides)
+	if t.Keep != KeepAll {
+		output += fmt.Sprintf("%s%d", t.Keep, t.KeepCount)
+	}
+	return output
+}
+
+type Expression struct {
+	Terms []Term
+	Mode  RollMode
+}
+
+func (e *Expression) String() string {
+	var builder strings.Builder
+	for i, term := range e.Terms {
+		if term.Negative {
+			builder.WriteString("-")
+		} else if i > 0 {
+			builder.WriteString("+")
+		}
+		builder.WriteString(term.String())
+	}
+	if e.Mode != RollNormal {
+		builder.WriteString(" " + string(e.Mode))
+	}
+	return builder.String()
+}
+
+// Parse reads expressions such as "2d6+3", "4d6kh3", "d%" or "1d20+5 adv".
+func Parse(input string) (*Expression, error) {
+	text := strings.ToLower(strings.TrimSpace(input))
+	if text == "" {
+		return nil, ErrEmptyExpression
+	}
+
+	expression := &Expression{}
+	for _, mode := range []RollMode{RollAdvantage, RollDisadvantage} {
+		if strings.HasSuffix(text, " "+string(mode)) {
+			expression.Mode = mode
+			text = strings.TrimSpace(strings.TrimSuffix(text, string(mode)))
+		}
+	}
+	text = strings.ReplaceAll(text, " ", "")
+
+	start := 0
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) && (text[i] != '+' && text[i] != '-' || i == start) {
+			continue
+		}
+		term, err := parseTerm(text[start:i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, input)
+		}
+		expression.Terms = append(expression.Terms, term)
+		start = i
+	}
+
+	if expression.Mode != RollNormal {
+		if err := expression.applyMode(); err != nil {
+			return nil, err
+		}
+	}
+
+	return expression, nil
+}
+
+// applyMode turns the single die of an advantage or disadvantage roll into two dice keeping one.
+func (e *Expression) applyMode() error {
+	diceTerm := -1
+	for i, term := range e.Terms {
+		if !term.IsDice() {
+			continue
+		}
+		if diceTerm != -1 || term.Count != 1 || term.Keep != KeepAll {
+			return ErrInvalidRollMode
+		}
+		diceTerm = i
+	}
+	if diceTerm == -1 {
+		return ErrInvalidRollMode
+	}
+
+	e.Terms[diceTerm].Count = 2
+	e.Terms[diceTerm].KeepCount = 1
+	e.Terms[diceTerm].Keep = KeepHighest
+	if e.Mode == RollDisadvantage {
+		e.Terms[diceTerm].Keep = KeepLowest
+	}
+	return nil
+}
+
+func parseTerm(text string) (Term, error) {
+	term := Term{}
+	if strings.HasPrefix(text, "-") {
+		term.Negative = true
+	}
+	text = strings.TrimLeft(text, "+-")
+	if text == "" {
+		return term, ErrInvalidExpression
+	}
+
+	dIndex := strings.Index(text, "d")
+	if dIndex == -1 {
+		constant, err := strconv.Atoi(text)
+		if err != nil {
+			return term, ErrInvalidExpression
+		}
+		term.Constant = constant
+		return term, nil
+	}
+
+	term.Count = 1
+	if dIndex > 0 {
+		count, err := strconv.Atoi(text[:dIndex])
+		if err != nil {
+			return term, ErrInvalidExpression
+		}
+		term.Count = count
+	}
+
+	sides := text[dIndex+1:]
+	for _, rule := range []KeepRule{KeepHighest, KeepLowest, "dl", "dh", "k"} {
+		index := strings.Index(sides, string(rule))
+		if index == -1 {
+			continue
+		}
+		amount, err := strconv.Atoi(sides[index+len(rule):])
+		if err != nil {
+			return term, ErrInvalidExpression
+		}
+		switch rule {
+		case "k":
+			term.Keep, term.KeepCount = KeepHighest, amount
+		case "dl":
+			term.Keep, term.KeepCount = KeepHighest, term.Count-amount
+		case "dh":
+			term.Keep, term.KeepCount = KeepLowest, term.Count-amount
+		default:
+			term.Keep, term.KeepCount = rule, amount
+		}
+		sides = sides[:index]
+		break
+	}
+
+	if sides == "%" {
+		term.Sides = 100
+	} else {
+		value, err := strconv.Atoi(sides)
+		if err != nil {
+			return term, ErrInvalidExpression
+		}
+		term.Sides = value
+	}
+
+	if term.Count < 1 || term.Count > maxDiceCount || term.Sides < 1 || term.Sides > maxDiceSides {
+		return term, ErrInvalidExpression
+	}
+	if term.Keep != KeepAll && (term.KeepCount < 1 || term.KeepCount > term.Count) {
+		return term, ErrInvalidExpression
+	}
+	return term, nil
+}
@@ -0,0 +1,88 @@
+package dice_test
+
+import (
+	"dndcc/internal/dice"
+	"errors"
+	"testing"
+)
+
+// sequenceRNG returns the given die faces in order, cycling when exhausted.
+type sequenceRNG struct {
+	values []int
+	index  int
+}
+
+func (s *sequenceRNG) IntN(n int) int {
+	value := s.values[s.index%len(s.values)]
+	s.index++
+	return (value - 1) % n
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Input     string
+		Expected  string
+		ExpectErr error
+	}{
+		{"2d6+3", "2d6+3", nil},
+		{"d20", "1d20", nil},
+		{"4d6kh3", "4d6kh3", nil},
+		{"4d6dl1", "4d6kh3", nil},
+		{"2d20kl1 - 1", "2d20kl1-1", nil},
+		{"1d20+5 adv", "2d20kh1+5 adv", nil},
+		{"1d20 dis", "2d20kl1 dis", nil},
+		{"d%", "1d100", nil},
+		{"", "", dice.ErrEmptyExpression},
+		{"2d", "", dice.ErrInvalidExpression},
+		{"2d6++3", "", dice.ErrInvalidExpression},
+		{"4d6kh5", "", dice.ErrInvalidExpression},
+		{"abc", "", dice.ErrInvalidExpression},
+		{"2d20 adv", "", dice.ErrInvalidRollMode},
+		{"5 adv", "", dice.ErrInvalidRollMode},
+	}
+
+	for _, test := range tests {
+		expression, err := dice.Parse(test.Input)
+		if test.ExpectErr != nil {
+			if !errors.Is(err, test.ExpectErr) {
+				t.Fatalf("%q got incorrect err: %v; want %v", test.Input, err, test.ExpectErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q got an unexpected err: %v", test.Input, err)
+		}
+		if expression.String() != test.Expected {
+			t.Fatalf("%q parsed incorrectly: %s; want %s", test.Input, expression, test.Expected)
+		}
+	}
+}
+
+func TestRoll(t *testing.T) {
+	tests := []struct {
+		Input     string
+		Faces     []int
+		Total     int
+		Breakdown string
+	}{
+		{"2d6+3", []int{4, 5}, 12, "2d6 [4, 5] + 3"},
+		{"4d6kh3", []int{3, 1, 6, 3}, 12, "4d6kh3 [3, ~1~, 6, 3]"},
+		{"1d20+2 adv", []int{7, 15}, 17, "2d20kh1 [~7~, 15] + 2"},
+		{"1d20+2 dis", []int{7, 15}, 9, "2d20kl1 [7, ~15~] + 2"},
+		{"1d8-1", []int{1}, 0, "1d8 [1] - 1"},
+	}
+
+	for _, test := range tests {
+		roller := dice.NewRoller(&sequenceRNG{values: test.Faces})
+		result, err := roller.RollString(test.Input)
+		if err != nil {
+			t.Fatalf("%q got an unexpected err: %v", test.Input, err)
+		}
+		if result.Total != test.Total {
+			t.Fatalf("%q got incorrect total: %d; want %d", test.Input, result.Total, test.Total)
+		}
+		if result.Breakdown() != test.Breakdown {
+			t.Fatalf("%q got incorrect breakdown: %s; want %s", test.Input, result.Breakdown(), test.Breakdown)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package dice
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type Die struct {
+	Sides   int
+	Value   int
+	Dropped bool
+}
+
+// GroupResult is the outcome of a single term of an expression.
+type GroupResult struct {
+	Term     Term
+	Dice     []Die
+	Subtotal int
+}
+
+func (g GroupResult) String() string {
+	if !g.Term.IsDice() {
+		return g.Term.String()
+	}
+	values := make([]string, len(g.Dice))
+	for i, die := range g.Dice {
+		values[i] = fmt.Sprint(die.Value)
+		if die.Dropped {
+			values[i] = "~" + values[i] + "~"
+		}
+	}
+	return fmt.Sprintf("%s [%s]", g.Term, strings.Join(values, ", "))
+}
+
+type Result struct {
+	Expression string
+	Groups     []GroupResult
+	Total      int
+}
+
+// Breakdown describes every die rolled, with dropped dice wrapped in tildes.
+func (r *Result) Breakdown() string {
+	var builder strings.Builder
+	for i, group := range r.Groups {
+		if group.Term.Negative {
+			builder.WriteString(" - ")
+		} else if i > 0 {
+			builder.WriteString(" + ")
+		}
+		builder.WriteString(group.String())
+	}
+	return builder.String()
+}
+
+type Roller struct {
+	rng RNG
+}
+
+// NewRoller creates a roller using rng, falling back to math/rand when rng is nil.
+func NewRoller(rng RNG) *Roller {
+	if rng == nil {
+		rng = defaultRNG{}
+	}
+	return &Roller{rng: rng}
+}
+
+// RollString parses and rolls an expression in one step.
+func (r *Roller) RollString(input string) (*Result, error) {
+	expression, err := Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return r.Roll(expression), nil
+}
+
+func (r *Roller) Roll(expression *Expression) *Result {
+	result := &Result{Expression: expression.String()}
+	for _, term := range expression.Terms {
+		group := r.rollTerm(term)
+		if term.Negative {
+			result.Total -= group.Subtotal
+		} else {
+			result.Total += group.Subtotal
+		}
+		result.Groups = append(result.Groups, group)
+	}
+	return result
+}
+
+func (r *Roller) rollTerm(term Term) GroupResult {
+	group := GroupResult{Term: term}
+	if !term.IsDice() {
+		group.Subtotal = term.Constant
+		return group
+	}
+
+	group.Dice = make([]Die, term.Count)
+	for i := range group.Dice {
+		group.Dice[i] = Die{Sides: term.Sides, Value: r.rng.IntN(term.Sides) + 1}
+	}
+
+	if term.Keep != KeepAll {
+		order := make([]int, len(group.Dice))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int {
+			if term.Keep == KeepHighest {
+				return group.Dice[b].Value - group.Dice[a].Value
+			}
+			return group.Dice[a].Value - group.Dice[b].Value
+		})
+		for _, index := range order[term.KeepCount:] {
+			group.Dice[index].Dropped = true
+		}
+	}
+
+	for _, die := range group.Dice {
+		if !die.Dropped {
+			group.Subtotal += die.Value
+		}
+	}
+	return group
+}
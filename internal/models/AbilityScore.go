@@ -0,0 +1,12 @@
+package models
+
+// AbilityScoreRoll is one 4d6-drop-lowest roll recorded for the rolled ability score method.
+// Rolls without a character are pending until the owner's next character claims them.
+type AbilityScoreRoll struct {
+	ID          int
+	OwnerId     int
+	CharacterId int
+	RollIndex   int
+	Breakdown   string
+	Total       int
+}
@@ -16,33 +16,67 @@ var (
 	ErrInvalidCharacterClass      = errors.New("character class cannot be empty")
 	ErrInvalidCharacterRace       = errors.New("character race cannot be empty")
 	ErrInvalidCharacterSubrace    = errors.New("character subrace cannot be empty if provided")
+	ErrInvalidBackgroundSkill     = errors.New("character background skill choices are invalid")
+	ErrInvalidDraconicAncestry    = errors.New("character draconic ancestry is invalid")
+	ErrInvalidCharacterRuleset    = errors.New("character ruleset must be 2014 or 2024")
+	ErrInvalidCharacterAlignment  = errors.New("character alignment is invalid")
 )
 
 type Character struct {
-	ID                      int
-	OwnerId                 int
-	Name                    string
-	Bio                     string
-	Background              string
-	Class                   string
-	Level                   int
-	RaceType                string
-	SubraceType             sql.NullString
-	RaceMoveSpeed           int
-	Strength                int
-	Dexterity               int
-	Constitution            int
-	Intelligence            int
-	Wisdom                  int
-	Charisma                int
-	CurrentHealthPoints     int
-	BackgroundProficiencies []string
+	ID                       int
+	OwnerId                  int
+	Name                     string
+	Bio                      string
+	PersonalityTraits        string
+	Ideals                   string
+	Bonds                    string
+	Flaws                    string
+	Appearance               string
+	Alignment                string
+	Background               string
+	Class                    string
+	Classes                  []CharacterClass
+	Level                    int
+	Experience               int
+	RaceType                 string
+	SubraceType              sql.NullString
+	RaceMoveSpeed            int
+	DraconicAncestry         string
+	Strength                 int
+	Dexterity                int
+	Constitution             int
+	Intelligence             int
+	Wisdom                   int
+	Charisma                 int
+	AbilityScoreMethod       string
+	VariantEncumbrance       bool
+	Ruleset                  string
+	AbilityScoreRolls        []AbilityScoreRoll
+	CurrentHealthPoints      int
+	TemporaryHealthPoints    int
+	MaxHealthPointsOverride  int
+	DeathSaveSuccesses       int
+	DeathSaveFailures        int
+	ExhaustionLevel          int
+	Conditions               []CharacterCondition
+	AbilityScoreImprovements []CharacterAbilityScoreImprovement
+	HitPoints                []CharacterHitPoints
+	Proficiencies            []CharacterProficiency
+	RaceStatChoices          []string
+	Spells                   []CharacterSpell
+	SpellSlots               []CharacterSpellSlots
+	FeatureUses              []CharacterFeatureUses
+	HitDice                  []CharacterHitDice
+	Items                    []CharacterItem
 }
 
 func (c *Character) Validate() error {
 	if strings.TrimSpace(c.Name) == "" {
 		return ErrInvalidCharacterName
 	}
+	if !character.RulesetVersion(c.Ruleset).IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidCharacterRuleset, c.Ruleset)
+	}
 	if strings.TrimSpace(c.Background) == "" {
 		return ErrInvalidCharacterBackground
 	}
@@ -55,15 +89,185 @@ func (c *Character) Validate() error {
 	if c.SubraceType.Valid && strings.TrimSpace(c.SubraceType.String) == "" {
 		return ErrInvalidCharacterSubrace
 	}
+	if c.Alignment != "" && !character.Alignment(c.Alignment).IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidCharacterAlignment, c.Alignment)
+	}
+	if err := c.validateRaceStatChoices(); err != nil {
+		return err
+	}
+	if err := c.validateDraconicAncestry(); err != nil {
+		return err
+	}
+	if err := c.validateBackgroundSkills(); err != nil {
+		return err
+	}
+	if err := c.ToCharacterSheet().ValidateClassSkills(); err != nil {
+		return err
+	}
+	if err := c.validateAbilityScores(); err != nil {
+		return err
+	}
+	if err := c.validateClasses(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Character) validateClasses() error {
+	sheet := c.ToCharacterSheet()
+	if sheet.GetStartingClass() != character.ClassName(c.Class) {
+		return fmt.Errorf("%w: starting class %s does not match %s", ErrInvalidCharacterClass, sheet.GetStartingClass(), c.Class)
+	}
+	if err := sheet.ValidateClasses(); err != nil {
+		return err
+	}
+	total := 0
+	for _, classLevel := range sheet.Classes {
+		total += classLevel.Level
+	}
+	if total != c.Level {
+		return fmt.Errorf("%w: class levels add up to %d, not %d", character.ErrInvalidClassLevel, total, c.Level)
+	}
+	return nil
+}
+
+func (c *Character) validateAbilityScores() error {
+	rolls := make([]int, len(c.AbilityScoreRolls))
+	for i, roll := range c.AbilityScoreRolls {
+		rolls[i] = roll.Total
+	}
+	return character.AbilityScoreMethod(c.AbilityScoreMethod).ValidateScores(c.ToCharacterSheet().StatBlock, rolls)
+}
+
+// validateRaceStatChoices checks the ability scores picked for the race or background, whichever
+// grants them under the character's ruleset.
+func (c *Character) validateRaceStatChoices() error {
+	return c.ToCharacterSheet().ValidateOriginStatChoices()
+}
+
+// validateDraconicAncestry checks the ancestry of a Dragonborn. Other races don't have one.
+func (c *Character) validateDraconicAncestry() error {
+	if c.DraconicAncestry == "" {
+		return nil
+	}
+	if character.RaceName(c.RaceType) != character.RaceDragonborn {
+		return fmt.Errorf("%w: only a Dragonborn has a draconic ancestry", ErrInvalidDraconicAncestry)
+	}
+	if !character.DraconicAncestry(c.DraconicAncestry).IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidDraconicAncestry, c.DraconicAncestry)
+	}
+	return nil
+}
+
+// validateBackgroundSkills checks the skills chosen for a custom background. Standard backgrounds
+// come with their own skills.
+func (c *Character) validateBackgroundSkills() error {
+	sheet := c.ToCharacterSheet()
+	background := sheet.Background
+	seen := make(map[string]bool)
+	for _, proficiency := range c.Proficiencies {
+		if !proficiency.IsBackgroundSkill() {
+			continue
+		}
+		if background.IsStandard() {
+			return fmt.Errorf("%w: %s already grants its skills", ErrInvalidBackgroundSkill, c.Background)
+		}
+		if !sheet.IsDefinedSkill(character.SkillName(proficiency.Name)) {
+			return fmt.Errorf("%w: %s is not a skill", ErrInvalidBackgroundSkill, proficiency.Name)
+		}
+		if seen[proficiency.Name] {
+			return fmt.Errorf("%w: %s was chosen more than once", ErrInvalidBackgroundSkill, proficiency.Name)
+		}
+		seen[proficiency.Name] = true
+	}
 	return nil
 }
 
+// GetBackgroundSkills returns the names of the skills chosen for a custom background.
+func (c *Character) GetBackgroundSkills() []string {
+	output := []string{}
+	for _, proficiency := range c.Proficiencies {
+		if proficiency.IsBackgroundSkill() {
+			output = append(output, proficiency.Name)
+		}
+	}
+	return output
+}
+
+// GetClassSkills returns the names of the skills chosen from the starting class's list.
+func (c *Character) GetClassSkills() []string {
+	output := []string{}
+	for _, proficiency := range c.Proficiencies {
+		if proficiency.IsClassSkill() {
+			output = append(output, proficiency.Name)
+		}
+	}
+	return output
+}
+
 func (c *Character) ToCharacterSheet() *character.Character {
-	proficiencies := make([]character.SkillName, len(c.BackgroundProficiencies))
-	for i := 0; i < len(c.BackgroundProficiencies); i++ {
-		proficiencies[i] = character.SkillName(c.BackgroundProficiencies[i])
+	proficiencies := make([]character.Proficiency, len(c.Proficiencies))
+	for i := 0; i < len(c.Proficiencies); i++ {
+		proficiencies[i] = c.Proficiencies[i].ToProficiency()
+	}
+	statChoices := make([]character.StatName, len(c.RaceStatChoices))
+	for i := 0; i < len(c.RaceStatChoices); i++ {
+		statChoices[i] = character.StatName(c.RaceStatChoices[i])
+	}
+	// The ability scores picked for the character's origin go to their race under the 2014 rules
+	// and their background under the 2024 rules.
+	ruleset := character.RulesetVersion(c.Ruleset)
+	var raceStatChoices, backgroundStatChoices []character.StatName
+	if ruleset == character.Ruleset2024 {
+		backgroundStatChoices = statChoices
+	} else {
+		raceStatChoices = statChoices
+	}
+	spells := make([]character.KnownSpell, len(c.Spells))
+	for i := 0; i < len(c.Spells); i++ {
+		spells[i] = c.Spells[i].ToKnownSpell()
+	}
+	inventory := make([]character.Item, len(c.Items))
+	for i := 0; i < len(c.Items); i++ {
+		inventory[i] = c.Items[i].ToItem()
+	}
+	classes := make([]character.ClassLevel, len(c.Classes))
+	for i := 0; i < len(c.Classes); i++ {
+		classes[i] = c.Classes[i].ToClassLevel()
+	}
+	if len(classes) == 0 {
+		classes = []character.ClassLevel{{Class: character.ClassName(c.Class), Level: c.Level}}
+	}
+	hitPointHistory := make([]character.LevelHitPoints, len(c.HitPoints))
+	for i := 0; i < len(c.HitPoints); i++ {
+		hitPointHistory[i] = c.HitPoints[i].ToLevelHitPoints()
+	}
+	var expendedSpellSlots [9]int
+	var expendedPactSlots int
+	for _, slots := range c.SpellSlots {
+		if slots.Pact {
+			expendedPactSlots = slots.Expended
+		} else if slots.SlotLevel >= 1 && slots.SlotLevel <= 9 {
+			expendedSpellSlots[slots.SlotLevel-1] = slots.Expended
+		}
+	}
+	expendedFeatureUses := make(map[string]int, len(c.FeatureUses))
+	for _, uses := range c.FeatureUses {
+		expendedFeatureUses[uses.Feature] = uses.Expended
+	}
+	conditions := make([]character.ActiveCondition, len(c.Conditions))
+	for i := 0; i < len(c.Conditions); i++ {
+		conditions[i] = character.ActiveCondition{Name: character.ConditionName(c.Conditions[i].Condition), Rounds: c.Conditions[i].Rounds}
+	}
+	abilityScoreImprovements := make([]character.AbilityScoreImprovement, len(c.AbilityScoreImprovements))
+	for i := 0; i < len(c.AbilityScoreImprovements); i++ {
+		abilityScoreImprovements[i] = c.AbilityScoreImprovements[i].ToAbilityScoreImprovement()
+	}
+	expendedHitDice := make(map[character.HitDie]int, len(c.HitDice))
+	for _, hitDice := range c.HitDice {
+		expendedHitDice[character.HitDie(hitDice.Die)] = hitDice.Expended
 	}
-	return &character.Character{
+	sheet := &character.Character{
 		StatBlock: &character.StatBlock{
 			Strength:     c.Strength,
 			Dexterity:    c.Dexterity,
@@ -72,21 +276,72 @@ func (c *Character) ToCharacterSheet() *character.Character {
 			Wisdom:       c.Wisdom,
 			Charisma:     c.Charisma,
 		},
-		Class: character.ClassName(c.Class),
+		Classes: classes,
 		Race: character.Race{
-			Type:      character.RaceName(c.RaceType),
-			Subrace:   character.SubraceName(c.SubraceType.String),
-			MoveSpeed: c.RaceMoveSpeed,
+			Type:        character.RaceName(c.RaceType),
+			Subrace:     character.SubraceName(c.SubraceType.String),
+			MoveSpeed:   c.RaceMoveSpeed,
+			StatChoices: raceStatChoices,
+			Ancestry:    character.DraconicAncestry(c.DraconicAncestry),
 		},
-		Name:  c.Name,
-		Level: c.Level,
+		Name:       c.Name,
+		Level:      c.Level,
+		Experience: c.Experience,
 		Background: character.Background{
-			Name:          character.BackgroundName(c.Background),
-			Proficiencies: proficiencies,
+			Name:           character.BackgroundName(c.Background),
+			Proficiencies:  []character.SkillName{},
+			AbilityChoices: backgroundStatChoices,
 		},
-		Bio:                 c.Bio,
-		CurrentHealthPoints: c.CurrentHealthPoints,
+		Bio:                      c.Bio,
+		Personality:              c.GetPersonality(),
+		CurrentHealthPoints:      c.CurrentHealthPoints,
+		TemporaryHitPoints:       c.TemporaryHealthPoints,
+		MaxHitPointsOverride:     c.MaxHealthPointsOverride,
+		DeathSaveSuccesses:       c.DeathSaveSuccesses,
+		DeathSaveFailures:        c.DeathSaveFailures,
+		Conditions:               conditions,
+		ExhaustionLevel:          c.ExhaustionLevel,
+		AbilityScoreImprovements: abilityScoreImprovements,
+		Proficiencies:            proficiencies,
+		HitPointHistory:          hitPointHistory,
+		Spells:                   spells,
+		ExpendedSpellSlots:       expendedSpellSlots,
+		ExpendedPactSlots:        expendedPactSlots,
+		ExpendedFeatureUses:      expendedFeatureUses,
+		ExpendedHitDice:          expendedHitDice,
+		Inventory:                inventory,
+		VariantEncumbrance:       c.VariantEncumbrance,
 	}
+	return sheet.SetRuleset(ruleset).SetOwner(c.OwnerId)
+}
+
+// GetPersonality returns the personality fields of the character, with one trait per line of
+// PersonalityTraits.
+func (c *Character) GetPersonality() character.Personality {
+	traits := []string{}
+	for _, trait := range strings.Split(c.PersonalityTraits, "\n") {
+		if trait = strings.TrimSpace(trait); trait != "" {
+			traits = append(traits, trait)
+		}
+	}
+	return character.Personality{
+		Traits:     traits,
+		Ideal:      c.Ideals,
+		Bond:       c.Bonds,
+		Flaw:       c.Flaws,
+		Appearance: c.Appearance,
+		Alignment:  character.Alignment(c.Alignment),
+	}
+}
+
+// SetPersonality fills in the personality fields of the character.
+func (c *Character) SetPersonality(personality character.Personality) {
+	c.PersonalityTraits = strings.Join(personality.Traits, "\n")
+	c.Ideals = personality.Ideal
+	c.Bonds = personality.Bond
+	c.Flaws = personality.Flaw
+	c.Appearance = personality.Appearance
+	c.Alignment = string(personality.Alignment)
 }
 
 func CharacterFromForm(r *http.Request) (*Character, error) {
@@ -131,22 +386,91 @@ func CharacterFromForm(r *http.Request) (*Character, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid value was passed for charisma: %s", r.FormValue("Charisma"))
 	}
+	abilityScoreMethod := r.FormValue("AbilityScoreMethod")
+	ruleset := r.FormValue("Ruleset")
+	raceStatChoices := []string{}
+	for _, choice := range r.Form["RaceStatChoice"] {
+		if choice != "" {
+			raceStatChoices = append(raceStatChoices, choice)
+		}
+	}
+	// The ancestry select is only shown for a Dragonborn.
+	ancestry := ""
+	if character.RaceName(race) == character.RaceDragonborn {
+		ancestry = r.FormValue("DraconicAncestry")
+	}
+	proficiencies := SkillProficienciesFromForm(r.Form["ClassSkill"], character.SourceClass)
+	// Only custom backgrounds choose their skills; the checkboxes are ignored for standard ones.
+	sheetBackground := character.Background{Name: character.BackgroundName(background), Ruleset: character.RulesetVersion(ruleset)}
+	if !sheetBackground.IsStandard() {
+		proficiencies = append(proficiencies, SkillProficienciesFromForm(r.Form["BackgroundProficiency"], character.SourceBackground)...)
+	}
 
 	return &Character{
-		Name:                    name,
-		Bio:                     bio,
-		Background:              background,
-		Level:                   level,
-		Class:                   class,
-		RaceType:                race,
-		SubraceType:             sql.NullString{String: subrace, Valid: true},
-		RaceMoveSpeed:           moveSpeed,
-		Strength:                strength,
-		Dexterity:               dexterity,
-		Constitution:            constitution,
-		Intelligence:            intelligence,
-		Wisdom:                  wisdom,
-		Charisma:                charisma,
-		BackgroundProficiencies: []string{},
+		Name:               name,
+		Bio:                bio,
+		PersonalityTraits:  strings.TrimSpace(r.FormValue("PersonalityTraits")),
+		Ideals:             strings.TrimSpace(r.FormValue("Ideals")),
+		Bonds:              strings.TrimSpace(r.FormValue("Bonds")),
+		Flaws:              strings.TrimSpace(r.FormValue("Flaws")),
+		Appearance:         strings.TrimSpace(r.FormValue("Appearance")),
+		Alignment:          r.FormValue("Alignment"),
+		Background:         background,
+		Level:              level,
+		Class:              class,
+		RaceType:           race,
+		SubraceType:        sql.NullString{String: subrace, Valid: true},
+		RaceMoveSpeed:      moveSpeed,
+		DraconicAncestry:   ancestry,
+		Strength:           strength,
+		Dexterity:          dexterity,
+		Constitution:       constitution,
+		Intelligence:       intelligence,
+		Wisdom:             wisdom,
+		Charisma:           charisma,
+		AbilityScoreMethod: abilityScoreMethod,
+		VariantEncumbrance: r.FormValue("VariantEncumbrance") == "on",
+		Ruleset:            ruleset,
+		Proficiencies:      proficiencies,
+		RaceStatChoices:    raceStatChoices,
 	}, nil
 }
+
+// CharacterDraftFromForm reads the character form as far as it has been filled in, for features
+// like bio generation that work on an unfinished character. Missing or invalid numbers are left at zero.
+func CharacterDraftFromForm(r *http.Request) *Character {
+	number := func(key string) int {
+		value, _ := strconv.Atoi(r.FormValue(key))
+		return value
+	}
+	raceStatChoices := []string{}
+	for _, choice := range r.Form["RaceStatChoice"] {
+		if choice != "" {
+			raceStatChoices = append(raceStatChoices, choice)
+		}
+	}
+	return &Character{
+		Name:              r.FormValue("Name"),
+		Bio:               r.FormValue("Bio"),
+		PersonalityTraits: r.FormValue("PersonalityTraits"),
+		Ideals:            r.FormValue("Ideals"),
+		Bonds:             r.FormValue("Bonds"),
+		Flaws:             r.FormValue("Flaws"),
+		Appearance:        r.FormValue("Appearance"),
+		Alignment:         r.FormValue("Alignment"),
+		Background:        r.FormValue("Background"),
+		Level:             number("Level"),
+		Class:             r.FormValue("ClassSelect"),
+		RaceType:          r.FormValue("RaceType"),
+		SubraceType:       sql.NullString{String: r.FormValue("SubraceType"), Valid: true},
+		DraconicAncestry:  r.FormValue("DraconicAncestry"),
+		Strength:          number("Strength"),
+		Dexterity:         number("Dexterity"),
+		Constitution:      number("Constitution"),
+		Intelligence:      number("Intelligence"),
+		Wisdom:            number("Wisdom"),
+		Charisma:          number("Charisma"),
+		Ruleset:           r.FormValue("Ruleset"),
+		RaceStatChoices:   raceStatChoices,
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import "dndcc/internal/character"
+
+// CharacterClass is the number of levels a character has in one class.
+// ClassIndex keeps the order the classes were taken in; index 0 is the starting class.
+type CharacterClass struct {
+	CharacterId int
+	ClassIndex  int
+	Class       string
+	Level       int
+	Subclass    string
+}
+
+func (c *CharacterClass) ToClassLevel() character.ClassLevel {
+	return character.ClassLevel{
+		Class:    character.ClassName(c.Class),
+		Level:    c.Level,
+		Subclass: character.SubclassName(c.Subclass),
+	}
+}
+
+func CharacterClassesFromSheet(characterId int, classes []character.ClassLevel) []CharacterClass {
+	output := make([]CharacterClass, len(classes))
+	for i, classLevel := range classes {
+		output[i] = CharacterClass{
+			CharacterId: characterId,
+			ClassIndex:  i,
+			Class:       string(classLevel.Class),
+			Level:       classLevel.Level,
+			Subclass:    string(classLevel.Subclass),
+		}
+	}
+	return output
+}
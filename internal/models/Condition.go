@@ -0,0 +1,43 @@
+package models
+
+import "dndcc/internal/character"
+
+// CharacterCondition is a condition affecting a character, with the rounds it has left or 0 if it
+// lasts until removed.
+type CharacterCondition struct {
+	CharacterId int
+	Condition   string
+	Rounds      int
+}
+
+// CharacterCombatState is the death saving throws, exhaustion and conditions of a character,
+// along with any death saving throws rolled.
+type CharacterCombatState struct {
+	CharacterId         int
+	CurrentHealthPoints int
+	DeathSaveSuccesses  int
+	DeathSaveFailures   int
+	ExhaustionLevel     int
+	Conditions          []CharacterCondition
+	Rolls               []CharacterRoll
+}
+
+func CharacterCombatStateFromSheet(characterId int, sheet *character.Character, rolls []CharacterRoll) *CharacterCombatState {
+	state := &CharacterCombatState{
+		CharacterId:         characterId,
+		CurrentHealthPoints: sheet.CurrentHealthPoints,
+		DeathSaveSuccesses:  sheet.DeathSaveSuccesses,
+		DeathSaveFailures:   sheet.DeathSaveFailures,
+		ExhaustionLevel:     sheet.ExhaustionLevel,
+		Conditions:          []CharacterCondition{},
+		Rolls:               rolls,
+	}
+	for _, condition := range sheet.Conditions {
+		state.Conditions = append(state.Conditions, CharacterCondition{
+			CharacterId: characterId,
+			Condition:   string(condition.Name),
+			Rounds:      condition.Rounds,
+		})
+	}
+	return state
+}
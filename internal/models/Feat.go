@@ -0,0 +1,40 @@
+package models
+
+import "dndcc/internal/character"
+
+// CharacterAbilityScoreImprovement is the choice a character made for the Ability Score
+// Improvement at one class level. Feat is empty when the character raised their scores instead.
+type CharacterAbilityScoreImprovement struct {
+	CharacterId int
+	Class       string
+	Level       int
+	Feat        string
+	Stats       []string
+}
+
+func (a *CharacterAbilityScoreImprovement) ToAbilityScoreImprovement() character.AbilityScoreImprovement {
+	stats := make([]character.StatName, len(a.Stats))
+	for i, stat := range a.Stats {
+		stats[i] = character.StatName(stat)
+	}
+	return character.AbilityScoreImprovement{
+		Class: character.ClassName(a.Class),
+		Level: a.Level,
+		Feat:  character.FeatName(a.Feat),
+		Stats: stats,
+	}
+}
+
+func CharacterAbilityScoreImprovementFromSheet(characterId int, choice character.AbilityScoreImprovement) *CharacterAbilityScoreImprovement {
+	stats := make([]string, len(choice.Stats))
+	for i, stat := range choice.Stats {
+		stats[i] = string(stat)
+	}
+	return &CharacterAbilityScoreImprovement{
+		CharacterId: characterId,
+		Class:       string(choice.Class),
+		Level:       choice.Level,
+		Feat:        string(choice.Feat),
+		Stats:       stats,
+	}
+}
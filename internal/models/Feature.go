@@ -0,0 +1,8 @@
+package models
+
+// CharacterFeatureUses is the number of uses of a limited-use class feature a character has spent.
+type CharacterFeatureUses struct {
+	CharacterId int
+	Feature     string
+	Expended    int
+}
@@ -0,0 +1,24 @@
+package models
+
+import "dndcc/internal/character"
+
+// CharacterHealth is the hit point state that changes during play.
+type CharacterHealth struct {
+	CharacterId             int
+	CurrentHealthPoints     int
+	TemporaryHealthPoints   int
+	MaxHealthPointsOverride int
+	DeathSaveSuccesses      int
+	DeathSaveFailures       int
+}
+
+func CharacterHealthFromSheet(characterId int, sheet *character.Character) *CharacterHealth {
+	return &CharacterHealth{
+		CharacterId:             characterId,
+		CurrentHealthPoints:     sheet.CurrentHealthPoints,
+		TemporaryHealthPoints:   sheet.TemporaryHitPoints,
+		MaxHealthPointsOverride: sheet.MaxHitPointsOverride,
+		DeathSaveSuccesses:      sheet.DeathSaveSuccesses,
+		DeathSaveFailures:       sheet.DeathSaveFailures,
+	}
+}
@@ -0,0 +1,206 @@
+package models
+
+import (
+	"bytes"
+	"dndcc/internal/character"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidHomebrewKind = errors.New("homebrew must be a race, subrace, background, class or item")
+	ErrInvalidHomebrewName = errors.New("homebrew content must have a name")
+)
+
+type HomebrewKind string
+
+const (
+	HomebrewRace       HomebrewKind = "Race"
+	HomebrewSubrace    HomebrewKind = "Subrace"
+	HomebrewBackground HomebrewKind = "Background"
+	HomebrewClass      HomebrewKind = "Class"
+	HomebrewItem       HomebrewKind = "Item"
+)
+
+var HomebrewKinds = []HomebrewKind{HomebrewRace, HomebrewSubrace, HomebrewBackground, HomebrewClass, HomebrewItem}
+
+func (k HomebrewKind) IsValid() bool {
+	for _, kind := range HomebrewKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// HomebrewTemplates are the starting YAML the editor offers for each kind of homebrew. They use
+// the same fields as the rules content packs.
+var HomebrewTemplates = map[HomebrewKind]string{
+	HomebrewRace: `name: Minotaur
+size: Medium
+speed: 30
+ability-increases:
+  - {stat: Strength, amount: 2}
+  - {stat: Constitution, amount: 1}
+languages: [Common, Minotaur]
+skills: [Intimidation]
+traits:
+  - name: Goring Rush
+    level: 1
+    description: After you Dash, make a melee attack with your horns as a bonus action.
+    uses: {by-level: {1: 1}, recharge: Short Rest}
+`,
+	HomebrewSubrace: `name: Sea Elf
+race: Elf
+ability-increases:
+  - {stat: Constitution, amount: 1}
+languages: [Aquan]
+`,
+	HomebrewBackground: `name: Smuggler
+skills: [Athletics, Deception]
+tools: [Vehicles (Water)]
+`,
+	HomebrewClass: `name: Blood Hunter
+hit-die: 10
+saving-throws: [Strength, Wisdom]
+skill-choices:
+  count: 3
+  options: [Acrobatics, Arcana, Athletics, History, Insight, Investigation, Religion, Survival]
+proficiencies:
+  armor: [Light, Medium, Shields]
+  weapon-categories: [Simple, Martial]
+ability-score-improvements: [4, 8, 12, 16, 19]
+`,
+	HomebrewItem: `name: Sunblade
+type: Weapon
+weight: 3
+weapon:
+  category: Martial
+  damage: 1d8
+  damage-type: radiant
+  versatile-damage: 1d10
+  properties: [Finesse, Versatile]
+`,
+}
+
+// Homebrew is one race, subrace, background, class or item a user wrote in the content pack YAML
+// format. Shared homebrew can be picked by every user, the rest only by its owner.
+type Homebrew struct {
+	ID        int
+	OwnerId   int
+	OwnerName string
+	Kind      HomebrewKind
+	Name      string
+	Content   string
+	Shared    bool
+}
+
+// Validate checks that the content decodes as the homebrew's kind and takes the name from it.
+// Whether the content fits with the rest of the rules is checked against the registry.
+func (h *Homebrew) Validate() error {
+	if !h.Kind.IsValid() {
+		return fmt.Errorf("%w: %s", ErrInvalidHomebrewKind, h.Kind)
+	}
+
+	var name string
+	if h.Kind == HomebrewItem {
+		item, err := h.ToItem()
+		if err != nil {
+			return err
+		}
+		if err := item.Validate(); err != nil {
+			return err
+		}
+		name = item.Name
+	} else {
+		pack, err := h.ToContentPack()
+		if err != nil {
+			return err
+		}
+		switch {
+		case len(pack.Races) > 0:
+			name = string(pack.Races[0].Name)
+		case len(pack.Subraces) > 0:
+			name = string(pack.Subraces[0].Name)
+		case len(pack.Backgrounds) > 0:
+			name = string(pack.Backgrounds[0].Name)
+		case len(pack.Classes) > 0:
+			name = string(pack.Classes[0].Name)
+		}
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return ErrInvalidHomebrewName
+	}
+	h.Name = name
+	return nil
+}
+
+// decodeHomebrew decodes YAML content into output, rejecting fields the content format doesn't have.
+func decodeHomebrew(content string, output any) error {
+	decoder := yaml.NewDecoder(bytes.NewReader([]byte(content)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(output); err != nil {
+		return fmt.Errorf("%w: %v", character.ErrInvalidContent, err)
+	}
+	return nil
+}
+
+// ToContentPack returns a content pack holding the homebrew definition. Items aren't rules content,
+// so their pack is empty.
+func (h *Homebrew) ToContentPack() (character.ContentPack, error) {
+	pack := character.ContentPack{}
+	var err error
+	switch h.Kind {
+	case HomebrewRace:
+		pack.Races = make([]character.RaceDefinition, 1)
+		err = decodeHomebrew(h.Content, &pack.Races[0])
+	case HomebrewSubrace:
+		pack.Subraces = make([]character.SubraceDefinition, 1)
+		err = decodeHomebrew(h.Content, &pack.Subraces[0])
+	case HomebrewBackground:
+		pack.Backgrounds = make([]character.BackgroundDefinition, 1)
+		err = decodeHomebrew(h.Content, &pack.Backgrounds[0])
+	case HomebrewClass:
+		pack.Classes = make([]character.ClassDefinition, 1)
+		err = decodeHomebrew(h.Content, &pack.Classes[0])
+	case HomebrewItem:
+	default:
+		return pack, fmt.Errorf("%w: %s", ErrInvalidHomebrewKind, h.Kind)
+	}
+	if err != nil {
+		return character.ContentPack{}, err
+	}
+	return pack, nil
+}
+
+// ToItem decodes a homebrew item. One of it is added to an inventory when no quantity is given.
+func (h *Homebrew) ToItem() (character.Item, error) {
+	item := character.Item{}
+	if h.Kind != HomebrewItem {
+		return item, fmt.Errorf("%w: %s is not an item", ErrInvalidHomebrewKind, h.Kind)
+	}
+	if err := decodeHomebrew(h.Content, &item); err != nil {
+		return item, err
+	}
+	if item.Quantity == 0 {
+		item.Quantity = 1
+	}
+	return item, nil
+}
+
+func HomebrewFromForm(r *http.Request) (*Homebrew, error) {
+	content := r.FormValue("Content")
+	if strings.TrimSpace(content) == "" {
+		return nil, fmt.Errorf("content is required to save homebrew")
+	}
+	return &Homebrew{
+		Kind:    HomebrewKind(r.FormValue("Kind")),
+		Content: content,
+		Shared:  r.FormValue("Shared") == "on",
+	}, nil
+}
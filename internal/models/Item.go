@@ -0,0 +1,159 @@
+package models
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidItemName     = errors.New("item name cannot be empty")
+	ErrInvalidItemQuantity = errors.New("item quantity must be at least 1")
+)
+
+type CharacterItem struct {
+	ID                  int
+	CharacterId         int
+	Name                string
+	ItemType            string
+	Quantity            int
+	Weight              float64
+	Equipped            bool
+	ArmorCategory       string
+	ArmorClass          int
+	StrengthRequirement int
+	StealthDisadvantage bool
+	WeaponCategory      string
+	Damage              string
+	DamageType          string
+	VersatileDamage     string
+	Ranged              bool
+	WeaponProperties    []string
+}
+
+func (i *CharacterItem) Validate() error {
+	if strings.TrimSpace(i.Name) == "" {
+		return ErrInvalidItemName
+	}
+	if i.Quantity < 1 {
+		return ErrInvalidItemQuantity
+	}
+	item := i.ToItem()
+	return item.Validate()
+}
+
+func (i *CharacterItem) ToItem() character.Item {
+	properties := make([]character.WeaponProperty, len(i.WeaponProperties))
+	for j := 0; j < len(i.WeaponProperties); j++ {
+		properties[j] = character.WeaponProperty(i.WeaponProperties[j])
+	}
+	return character.Item{
+		ID:       i.ID,
+		Name:     i.Name,
+		Type:     character.ItemType(i.ItemType),
+		Quantity: i.Quantity,
+		Weight:   i.Weight,
+		Equipped: i.Equipped,
+		Armor: character.ArmorStats{
+			Category:            character.ArmorCategory(i.ArmorCategory),
+			ArmorClass:          i.ArmorClass,
+			StrengthRequirement: i.StrengthRequirement,
+			StealthDisadvantage: i.StealthDisadvantage,
+		},
+		Weapon: character.WeaponStats{
+			Category:        character.WeaponCategory(i.WeaponCategory),
+			Damage:          i.Damage,
+			DamageType:      i.DamageType,
+			VersatileDamage: i.VersatileDamage,
+			Ranged:          i.Ranged,
+			Properties:      properties,
+		},
+	}
+}
+
+func CharacterItemFromForm(r *http.Request) (*CharacterItem, error) {
+	name := r.FormValue("ItemName")
+	if name == "" {
+		return nil, fmt.Errorf("name is required to add an item")
+	}
+	quantity, err := strconv.Atoi(r.FormValue("ItemQuantity"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid value was passed for quantity: %s", r.FormValue("ItemQuantity"))
+	}
+	weight := 0.0
+	if r.FormValue("ItemWeight") != "" {
+		weight, err = strconv.ParseFloat(r.FormValue("ItemWeight"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value was passed for weight: %s", r.FormValue("ItemWeight"))
+		}
+	}
+	armorClass := 0
+	if r.FormValue("ArmorClass") != "" {
+		armorClass, err = strconv.Atoi(r.FormValue("ArmorClass"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value was passed for armor class: %s", r.FormValue("ArmorClass"))
+		}
+	}
+	strengthRequirement := 0
+	if r.FormValue("StrengthRequirement") != "" {
+		strengthRequirement, err = strconv.Atoi(r.FormValue("StrengthRequirement"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value was passed for strength requirement: %s", r.FormValue("StrengthRequirement"))
+		}
+	}
+
+	item := &CharacterItem{
+		Name:     name,
+		ItemType: r.FormValue("ItemType"),
+		Quantity: quantity,
+		Weight:   weight,
+		Equipped: r.FormValue("ItemEquipped") == "on",
+	}
+	switch character.ItemType(item.ItemType) {
+	case character.ItemArmor, character.ItemShield:
+		item.ArmorCategory = r.FormValue("ArmorCategory")
+		item.ArmorClass = armorClass
+		item.StrengthRequirement = strengthRequirement
+		item.StealthDisadvantage = r.FormValue("StealthDisadvantage") == "on"
+		if item.ItemType == string(character.ItemShield) {
+			item.ArmorCategory = ""
+		}
+	case character.ItemWeapon:
+		item.WeaponCategory = r.FormValue("WeaponCategory")
+		item.Damage = r.FormValue("Damage")
+		item.DamageType = r.FormValue("DamageType")
+		item.VersatileDamage = r.FormValue("VersatileDamage")
+		item.Ranged = r.FormValue("Ranged") == "on"
+		item.WeaponProperties = r.Form["WeaponProperty"]
+	}
+
+	return item, nil
+}
+
+// CharacterItemFromItem copies an item definition, such as a homebrew item, into an inventory row.
+func CharacterItemFromItem(item character.Item) *CharacterItem {
+	properties := make([]string, len(item.Weapon.Properties))
+	for i, property := range item.Weapon.Properties {
+		properties[i] = string(property)
+	}
+	return &CharacterItem{
+		Name:                item.Name,
+		ItemType:            string(item.Type),
+		Quantity:            item.Quantity,
+		Weight:              item.Weight,
+		Equipped:            item.Equipped,
+		ArmorCategory:       string(item.Armor.Category),
+		ArmorClass:          item.Armor.ArmorClass,
+		StrengthRequirement: item.Armor.StrengthRequirement,
+		StealthDisadvantage: item.Armor.StealthDisadvantage,
+		WeaponCategory:      string(item.Weapon.Category),
+		Damage:              item.Weapon.Damage,
+		DamageType:          item.Weapon.DamageType,
+		VersatileDamage:     item.Weapon.VersatileDamage,
+		Ranged:              item.Weapon.Ranged,
+		WeaponProperties:    properties,
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import "dndcc/internal/character"
+
+// CharacterHitPoints is the hit die result recorded when a character reached a level.
+type CharacterHitPoints struct {
+	CharacterId int
+	Level       int
+	Class       string
+	HitDieRoll  int
+	Rolled      bool
+}
+
+func (h *CharacterHitPoints) ToLevelHitPoints() character.LevelHitPoints {
+	return character.LevelHitPoints{
+		Level:      h.Level,
+		Class:      character.ClassName(h.Class),
+		HitDieRoll: h.HitDieRoll,
+		Rolled:     h.Rolled,
+	}
+}
+
+func CharacterHitPointsFromLevel(characterId int, entry character.LevelHitPoints) *CharacterHitPoints {
+	return &CharacterHitPoints{
+		CharacterId: characterId,
+		Level:       entry.Level,
+		Class:       string(entry.Class),
+		HitDieRoll:  entry.HitDieRoll,
+		Rolled:      entry.Rolled,
+	}
+}
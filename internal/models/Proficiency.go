@@ -0,0 +1,66 @@
+package models
+
+import "dndcc/internal/character"
+
+// CharacterProficiency is a proficiency chosen for a character on top of the ones their class,
+// background, race and feats grant. Level holds a character.ProficiencyLevel.
+type CharacterProficiency struct {
+	CharacterId int
+	Kind        string
+	Name        string
+	Level       int
+	Source      string
+}
+
+func (p *CharacterProficiency) ToProficiency() character.Proficiency {
+	return character.Proficiency{
+		Kind:   character.ProficiencyKind(p.Kind),
+		Name:   p.Name,
+		Level:  character.ProficiencyLevel(p.Level),
+		Source: character.ProficiencySource(p.Source),
+	}
+}
+
+// IsBackgroundSkill reports whether the proficiency is one of a custom background's skills.
+func (p *CharacterProficiency) IsBackgroundSkill() bool {
+	return p.isSkillFrom(character.SourceBackground)
+}
+
+// IsClassSkill reports whether the proficiency is one of the starting class's skill choices.
+func (p *CharacterProficiency) IsClassSkill() bool {
+	return p.isSkillFrom(character.SourceClass)
+}
+
+// IsFormChoice reports whether the proficiency is set on the character form rather than the sheet.
+func (p *CharacterProficiency) IsFormChoice() bool {
+	return p.IsBackgroundSkill() || p.IsClassSkill()
+}
+
+func (p *CharacterProficiency) isSkillFrom(source character.ProficiencySource) bool {
+	return p.Kind == string(character.ProficiencySkill) && p.Source == string(source) &&
+		p.Level == int(character.ProficiencyProficient)
+}
+
+// SkillProficienciesFromForm turns skill names ticked on the character form into proficiencies from the source.
+func SkillProficienciesFromForm(skills []string, source character.ProficiencySource) []CharacterProficiency {
+	output := make([]CharacterProficiency, len(skills))
+	for i, skill := range skills {
+		output[i] = CharacterProficiency{
+			Kind:   string(character.ProficiencySkill),
+			Name:   skill,
+			Level:  int(character.ProficiencyProficient),
+			Source: string(source),
+		}
+	}
+	return output
+}
+
+func CharacterProficiencyFromSheet(characterId int, proficiency character.Proficiency) *CharacterProficiency {
+	return &CharacterProficiency{
+		CharacterId: characterId,
+		Kind:        string(proficiency.Kind),
+		Name:        proficiency.Name,
+		Level:       int(proficiency.Level),
+		Source:      string(proficiency.Source),
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import "dndcc/internal/character"
+
+// CharacterHitDice is the number of hit dice of one size a character has spent.
+type CharacterHitDice struct {
+	CharacterId int
+	Die         int
+	Expended    int
+}
+
+// CharacterRest is the state a character is left in after a rest, along with any hit dice rolled.
+type CharacterRest struct {
+	CharacterId           int
+	CurrentHealthPoints   int
+	TemporaryHealthPoints int
+	DeathSaveSuccesses    int
+	DeathSaveFailures     int
+	ExhaustionLevel       int
+	HitDice               []CharacterHitDice
+	FeatureUses           []CharacterFeatureUses
+	ExpendedSpellSlots    [9]int
+	ExpendedPactSlots     int
+	Rolls                 []CharacterRoll
+}
+
+func CharacterRestFromSheet(characterId int, sheet *character.Character, rolls []CharacterRoll) *CharacterRest {
+	rest := &CharacterRest{
+		CharacterId:           characterId,
+		CurrentHealthPoints:   sheet.CurrentHealthPoints,
+		TemporaryHealthPoints: sheet.TemporaryHitPoints,
+		DeathSaveSuccesses:    sheet.DeathSaveSuccesses,
+		DeathSaveFailures:     sheet.DeathSaveFailures,
+		ExhaustionLevel:       sheet.ExhaustionLevel,
+		HitDice:               []CharacterHitDice{},
+		FeatureUses:           []CharacterFeatureUses{},
+		ExpendedSpellSlots:    sheet.ExpendedSpellSlots,
+		ExpendedPactSlots:     sheet.ExpendedPactSlots,
+		Rolls:                 rolls,
+	}
+	for die, expended := range sheet.ExpendedHitDice {
+		rest.HitDice = append(rest.HitDice, CharacterHitDice{CharacterId: characterId, Die: int(die), Expended: expended})
+	}
+	for feature, expended := range sheet.ExpendedFeatureUses {
+		rest.FeatureUses = append(rest.FeatureUses, CharacterFeatureUses{CharacterId: characterId, Feature: feature, Expended: expended})
+	}
+	return rest
+}
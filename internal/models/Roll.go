@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+type CharacterRoll struct {
+	ID          int
+	CharacterId int
+	Label       string
+	Expression  string
+	Breakdown   string
+	Total       int
+	CreatedAt   time.Time
+}
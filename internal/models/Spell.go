@@ -0,0 +1,71 @@
+package models
+
+import (
+	"dndcc/internal/character"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidSpellName = errors.New("spell name cannot be empty")
+)
+
+type CharacterSpell struct {
+	ID          int
+	CharacterId int
+	Name        string
+	Level       int
+	School      string
+	Prepared    bool
+	Description string
+}
+
+type CharacterSpellSlots struct {
+	CharacterId int
+	SlotLevel   int
+	Pact        bool
+	Expended    int
+}
+
+func (s *CharacterSpell) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return ErrInvalidSpellName
+	}
+	spell := s.ToKnownSpell().Spell
+	return spell.Validate()
+}
+
+func (s *CharacterSpell) ToKnownSpell() character.KnownSpell {
+	return character.KnownSpell{
+		ID: s.ID,
+		Spell: character.Spell{
+			Name:        s.Name,
+			Level:       s.Level,
+			School:      character.SpellSchool(s.School),
+			Description: s.Description,
+		},
+		Prepared: s.Prepared,
+	}
+}
+
+func CharacterSpellFromForm(r *http.Request) (*CharacterSpell, error) {
+	name := r.FormValue("SpellName")
+	if name == "" {
+		return nil, fmt.Errorf("name is required to add a spell")
+	}
+	level, err := strconv.Atoi(r.FormValue("SpellLevel"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid value was passed for spell level: %s", r.FormValue("SpellLevel"))
+	}
+
+	return &CharacterSpell{
+		Name:        name,
+		Level:       level,
+		School:      r.FormValue("SpellSchool"),
+		Prepared:    r.FormValue("SpellPrepared") == "on",
+		Description: r.FormValue("SpellDescription"),
+	}, nil
+}
@@ -6,74 +6,225 @@ import (
 )
 
 type CharacterEditPageData struct {
-	Method            string
-	Action            string
+	Method    string
+	Action    string
+	Error     string
+	Character *models.Character
+	// LLMEnabled shows the bio generation controls, which need an LLM provider. Set by the caller.
+	LLMEnabled          bool
+	Bio                 *BioData
+	Personality         *PersonalityData
+	Describe            *DescribeData
+	Ruleset             character.Ruleset
+	RulesetOptions      []character.RulesetVersion
+	BackgroundOptions   []character.BackgroundName
+	SkillOptions        []character.SkillName
+	ClassOptions        []character.ClassName
+	RaceOptions         []character.RaceName
+	SubraceOptions      []character.SubraceName
+	RaceBonuses         *RaceBonusesData
+	ClassSkills         *ClassSkillsData
+	AbilityScoreMethods []character.AbilityScoreMethod
+	AbilityScores       *AbilityScoresData
+	Inventory           *InventoryData
+}
+
+// BioData is the bio field of the character form, with the error of a failed generation or save.
+type BioData struct {
+	Bio   string
+	Error string
+}
+
+// DescribeData is the description a new character is created from by the LLM, with the error of a
+// failed creation.
+type DescribeData struct {
+	Description string
+	Error       string
+}
+
+// PersonalityData is the personality fields of the character form, with the error of a failed
+// generation. Without LLMEnabled the personality is rolled on the background's tables instead.
+type PersonalityData struct {
+	LLMEnabled        bool
+	PersonalityTraits string
+	Ideals            string
+	Bonds             string
+	Flaws             string
+	Appearance        string
+	Alignment         string
+	AlignmentOptions  []character.Alignment
 	Error             string
-	Character         *models.Character
-	BackgroundOptions []character.BackgroundName
-	ClassOptions      []character.ClassName
-	RaceOptions       []character.RaceName
-	SubraceOptions    []character.SubraceName
 }
 
-func NewCharacterEditPageData(method, action, errorMessage string, characterModel *models.Character) *CharacterEditPageData {
+func NewPersonalityData(characterModel *models.Character) *PersonalityData {
+	output := &PersonalityData{AlignmentOptions: character.Alignments}
+	if characterModel == nil {
+		return output
+	}
+
+	output.PersonalityTraits = characterModel.PersonalityTraits
+	output.Ideals = characterModel.Ideals
+	output.Bonds = characterModel.Bonds
+	output.Flaws = characterModel.Flaws
+	output.Appearance = characterModel.Appearance
+	output.Alignment = characterModel.Alignment
+	return output
+}
+
+type AbilityScoresData struct {
+	CharacterID    int
+	Method         string
+	Error          string
+	PointBuyTotal  int
+	PointBuyBudget int
+	StandardArray  []int
+	Rolls          []models.AbilityScoreRoll
+}
+
+// RaceBonusesData holds the ability score increases of the character's origin: their race under the
+// 2014 rules and their background under the 2024 rules.
+type RaceBonusesData struct {
+	Ruleset     character.RulesetVersion
+	Bonuses     []character.AbilityBonus
+	ChoiceSlots []string
+	StatOptions []character.StatName
+	// AncestryOptions is only set for a Dragonborn, who picks a draconic ancestry.
+	AncestryOptions []character.DraconicAncestry
+	Ancestry        string
+}
+
+type ClassSkillsData struct {
+	Class   character.ClassName
+	Count   int
+	Options []character.SkillName
+	Chosen  []string
+}
+
+type InventoryData struct {
+	CharacterID int
+	Error       string
+	ArmorClass  int
+	Items       []character.Item
+	// HomebrewItems are the homebrew items the user can add, set by the caller.
+	HomebrewItems []models.Homebrew
+}
+
+func NewInventoryData(characterModel *models.Character) *InventoryData {
+	if characterModel == nil {
+		return &InventoryData{Items: []character.Item{}}
+	}
+
+	sheet := characterModel.ToCharacterSheet()
+	return &InventoryData{
+		CharacterID: characterModel.ID,
+		ArmorClass:  sheet.GetArmorClass(),
+		Items:       sheet.Inventory,
+	}
+}
+
+func NewAbilityScoresData(characterModel *models.Character) *AbilityScoresData {
+	output := &AbilityScoresData{
+		PointBuyBudget: character.PointBuyBudget,
+		StandardArray:  character.StandardArray,
+		Rolls:          []models.AbilityScoreRoll{},
+	}
+	if characterModel == nil {
+		return output
+	}
+
+	output.CharacterID = characterModel.ID
+	output.Method = characterModel.AbilityScoreMethod
+	if characterModel.AbilityScoreRolls != nil {
+		output.Rolls = characterModel.AbilityScoreRolls
+	}
+
+	method := character.AbilityScoreMethod(characterModel.AbilityScoreMethod)
+	statBlock := characterModel.ToCharacterSheet().StatBlock
+	if method == character.AbilityScoreMethodPointBuy {
+		output.PointBuyTotal, _ = statBlock.GetPointBuyTotal()
+	}
+	rolls := make([]int, len(output.Rolls))
+	for i, roll := range output.Rolls {
+		rolls[i] = roll.Total
+	}
+	if err := method.ValidateScores(statBlock, rolls); err != nil {
+		output.Error = err.Error()
+	}
+	return output
+}
+
+func NewRaceBonusesData(characterModel *models.Character) *RaceBonusesData {
+	output := &RaceBonusesData{
+		Bonuses:     []character.AbilityBonus{},
+		ChoiceSlots: []string{},
+		StatOptions: character.StatNames,
+	}
+	if characterModel == nil {
+		return output
+	}
+
+	sheet := characterModel.ToCharacterSheet()
+	ruleset := sheet.GetRuleset()
+	output.Ruleset = ruleset.Version()
+	output.Bonuses = ruleset.GetOriginAbilityBonuses(sheet)
+	output.StatOptions = ruleset.GetOriginStatOptions(sheet)
+	for i := 0; i < ruleset.GetOriginStatChoiceCount(sheet); i++ {
+		choice := ""
+		if i < len(characterModel.RaceStatChoices) {
+			choice = characterModel.RaceStatChoices[i]
+		}
+		output.ChoiceSlots = append(output.ChoiceSlots, choice)
+	}
+	if sheet.Race.Type == character.RaceDragonborn {
+		output.AncestryOptions = character.DraconicAncestries
+		output.Ancestry = characterModel.DraconicAncestry
+	}
+	return output
+}
+
+func NewClassSkillsData(characterModel *models.Character) *ClassSkillsData {
+	if characterModel == nil {
+		return &ClassSkillsData{Options: []character.SkillName{}, Chosen: []string{}}
+	}
+
+	class := character.ClassName(characterModel.Class)
+	choices := characterModel.ToCharacterSheet().GetClassDefinition(class).GetSkillChoices()
+	return &ClassSkillsData{
+		Class:   class,
+		Count:   choices.Count,
+		Options: choices.Options,
+		Chosen:  characterModel.GetClassSkills(),
+	}
+}
+
+// NewCharacterEditPageData builds the edit form with options from rules, which should be the
+// registry of the character's ruleset with the homebrew the user can pick.
+func NewCharacterEditPageData(method, action, errorMessage string, characterModel *models.Character, rules *character.Registry) *CharacterEditPageData {
+	var ruleset character.RulesetVersion
+	bio := &BioData{}
+	if characterModel != nil {
+		ruleset = character.RulesetVersion(characterModel.Ruleset)
+		bio.Bio = characterModel.Bio
+	}
 	return &CharacterEditPageData{
-		Method:    method,
-		Action:    action,
-		Error:     errorMessage,
-		Character: characterModel,
-		BackgroundOptions: []character.BackgroundName{
-			character.BackgroundAcolyte,
-			character.BackgroundCharlatan,
-			character.BackgroundCriminal,
-			character.BackgroundEntertainer,
-			character.BackgroundFolkHero,
-			character.BackgroundGuildArtisan,
-			character.BackgroundHermit,
-			character.BackgroundNoble,
-			character.BackgroundOutlander,
-			character.BackgroundSage,
-			character.BackgroundSailor,
-			character.BackgroundSoldier,
-			character.BackgroundUrchin,
-		},
-		ClassOptions: []character.ClassName{
-			character.ClassBarbarian,
-			character.ClassBard,
-			character.ClassCleric,
-			character.ClassDruid,
-			character.ClassFighter,
-			character.ClassMonk,
-			character.ClassPaladin,
-			character.ClassRanger,
-			character.ClassRogue,
-			character.ClassSorcerer,
-			character.ClassWarlock,
-			character.ClassWizard,
-			character.ClassCommoner,
-		},
-		RaceOptions: []character.RaceName{
-			character.RaceDwarf,
-			character.RaceElf,
-			character.RaceHalfling,
-			character.RaceHuman,
-			character.RaceDragonborn,
-			character.RaceGnome,
-			character.RaceHalfElf,
-			character.RaceHalfOrc,
-			character.RaceTiefling,
-		},
-		SubraceOptions: []character.SubraceName{
-			character.SubraceNone,
-			character.SubraceHillDwarf,
-			character.SubraceMountainDwarf,
-			character.SubraceHighElf,
-			character.SubraceWoodElf,
-			character.SubraceDrow,
-			character.SubraceLightfoot,
-			character.SubraceStout,
-			character.SubraceForestGnome,
-			character.SubraceRockGnome,
-		},
+		Method:              method,
+		Action:              action,
+		Error:               errorMessage,
+		Character:           characterModel,
+		Bio:                 bio,
+		Personality:         NewPersonalityData(characterModel),
+		Describe:            &DescribeData{},
+		Ruleset:             character.GetRuleset(ruleset),
+		RulesetOptions:      character.RulesetVersions,
+		BackgroundOptions:   rules.BackgroundNames(),
+		SkillOptions:        rules.SkillNames(),
+		ClassOptions:        rules.ClassNames(),
+		RaceOptions:         rules.RaceNames(),
+		SubraceOptions:      append([]character.SubraceName{character.SubraceNone}, rules.SubraceNames()...),
+		RaceBonuses:         NewRaceBonusesData(characterModel),
+		ClassSkills:         NewClassSkillsData(characterModel),
+		AbilityScoreMethods: character.AbilityScoreMethods,
+		AbilityScores:       NewAbilityScoresData(characterModel),
+		Inventory:           NewInventoryData(characterModel),
 	}
 }
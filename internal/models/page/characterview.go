@@ -3,13 +3,18 @@ package page
 import "dndcc/internal/character"
 
 type CharacterViewPageData struct {
-	ID int
+	ID    int
+	Error string
+	// LevelUpClass is the class picked in the level up panel; empty means the starting class.
+	LevelUpClass character.ClassName
+	DamageTypes  []character.DamageType
 	*character.Character
 }
 
 func NewCharacterViewPageData(id int, char *character.Character) *CharacterViewPageData {
 	return &CharacterViewPageData{
-		ID:        id,
-		Character: char,
+		ID:          id,
+		DamageTypes: character.DamageTypes,
+		Character:   char,
 	}
 }
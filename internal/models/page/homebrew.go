@@ -0,0 +1,48 @@
+package page
+
+import (
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type HomebrewPageData struct {
+	UserID  int
+	Entries []models.Homebrew
+	Editor  *HomebrewEditorData
+}
+
+type HomebrewEditorData struct {
+	Method   string
+	Action   string
+	Error    string
+	Homebrew *models.Homebrew
+	Kinds    []models.HomebrewKind
+	// ReadOnly is set for homebrew another user shared, which can be viewed but not changed.
+	ReadOnly bool
+}
+
+// NewHomebrewEditorData builds the editor for homebrew. A homebrew without an ID is new and is
+// created on save; otherwise only its owner can save or delete it.
+func NewHomebrewEditorData(homebrew *models.Homebrew, userId int, errorMessage string) *HomebrewEditorData {
+	output := &HomebrewEditorData{
+		Method:   "post",
+		Action:   "/homebrew",
+		Error:    errorMessage,
+		Homebrew: homebrew,
+		Kinds:    models.HomebrewKinds,
+	}
+	if homebrew.ID != 0 {
+		output.Method = "put"
+		output.Action = fmt.Sprintf("/homebrew/%d", homebrew.ID)
+		output.ReadOnly = homebrew.OwnerId != userId
+	}
+	return output
+}
+
+// NewHomebrew returns a new homebrew of the kind, starting from the kind's template.
+func NewHomebrew(kind models.HomebrewKind) *models.Homebrew {
+	if !kind.IsValid() {
+		kind = models.HomebrewRace
+	}
+	return &models.Homebrew{Kind: kind, Content: models.HomebrewTemplates[kind]}
+}
@@ -0,0 +1,9 @@
+package page
+
+import "dndcc/internal/models"
+
+type RollLogData struct {
+	CharacterID int
+	Error       string
+	Rolls       []models.CharacterRoll
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type AbilityScoreRepository struct {
+	db *sql.DB
+}
+
+func NewAbilityScoreRepository(db *sql.DB) *AbilityScoreRepository {
+	return &AbilityScoreRepository{db}
+}
+
+func queryAbilityScoreRolls(db *sql.DB, query string, args ...any) ([]models.AbilityScoreRoll, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ability score rolls: %w", err)
+	}
+	defer rows.Close()
+
+	var rolls []models.AbilityScoreRoll
+	for rows.Next() {
+		var roll models.AbilityScoreRoll
+		var characterId sql.NullInt64
+		if err := rows.Scan(&roll.ID, &roll.OwnerId, &characterId, &roll.RollIndex, &roll.Breakdown, &roll.Total); err != nil {
+			return nil, fmt.Errorf("failed to scan ability score roll row: %w", err)
+		}
+		roll.CharacterId = int(characterId.Int64)
+		rolls = append(rolls, roll)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during ability score roll rows iteration: %w", err)
+	}
+
+	return rolls, nil
+}
+
+func getCharacterAbilityScoreRolls(db *sql.DB, characterId int) ([]models.AbilityScoreRoll, error) {
+	query := `
+		SELECT id, owner_id, character_id, roll_index, breakdown, total
+		FROM ability_score_rolls WHERE character_id = ? ORDER BY roll_index;
+	`
+	return queryAbilityScoreRolls(db, query, characterId)
+}
+
+// getPendingAbilityScoreRolls returns the rolls the owner has made that no character has claimed yet.
+func getPendingAbilityScoreRolls(db *sql.DB, ownerId int) ([]models.AbilityScoreRoll, error) {
+	query := `
+		SELECT id, owner_id, character_id, roll_index, breakdown, total
+		FROM ability_score_rolls WHERE owner_id = ? AND character_id IS NULL ORDER BY roll_index;
+	`
+	return queryAbilityScoreRolls(db, query, ownerId)
+}
+
+// claimPendingAbilityScoreRolls attaches the owner's pending rolls to the character unless it already has rolls.
+func claimPendingAbilityScoreRolls(tx *sql.Tx, characterId, ownerId int) error {
+	query := `
+		UPDATE ability_score_rolls SET character_id = ?
+		WHERE owner_id = ? AND character_id IS NULL
+			AND NOT EXISTS (SELECT 1 FROM ability_score_rolls WHERE character_id = ?);
+	`
+	if _, err := tx.Exec(query, characterId, ownerId, characterId); err != nil {
+		return fmt.Errorf("failed to claim ability score rolls for character %d: %w", characterId, err)
+	}
+	return nil
+}
+
+// CreatePending records a new set of rolls for the owner. If the owner already has pending rolls
+// those are returned instead, so a set cannot be thrown away and rerolled.
+func (r *AbilityScoreRepository) CreatePending(ownerId int, rolls []models.AbilityScoreRoll) ([]models.AbilityScoreRoll, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM ability_score_rolls WHERE owner_id = ? AND character_id IS NULL)", ownerId).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for pending ability score rolls: %w", err)
+	}
+
+	if !exists {
+		insertStmt, err := tx.Prepare("INSERT INTO ability_score_rolls (owner_id, roll_index, breakdown, total) VALUES (?, ?, ?, ?);")
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare ability score roll insert statement: %w", err)
+		}
+		defer insertStmt.Close()
+
+		for i, roll := range rolls {
+			if _, err := insertStmt.Exec(ownerId, i, roll.Breakdown, roll.Total); err != nil {
+				return nil, fmt.Errorf("failed to insert ability score roll for owner %d: %w", ownerId, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit ability score roll transaction: %w", err)
+	}
+
+	return getPendingAbilityScoreRolls(r.db, ownerId)
+}
+
+func (r *AbilityScoreRepository) GetPending(ownerId int) ([]models.AbilityScoreRoll, error) {
+	return getPendingAbilityScoreRolls(r.db, ownerId)
+}
+
+func (r *AbilityScoreRepository) GetForCharacter(characterId, ownerId int) ([]models.AbilityScoreRoll, error) {
+	if err := ownsCharacter(r.db, characterId, ownerId); err != nil {
+		return nil, err
+	}
+	return getCharacterAbilityScoreRolls(r.db, characterId)
+}
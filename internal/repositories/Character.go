@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"database/sql"
+	"dndcc/internal/character"
 	"dndcc/internal/models"
 	"errors"
 	"fmt"
@@ -15,29 +16,143 @@ func NewCharacterRepository(db *sql.DB) *CharacterRepository {
 	return &CharacterRepository{db}
 }
 
-func (r *CharacterRepository) getCharacterProficiencies(db *sql.DB, characterId int) ([]string, error) {
-	profQuery := `SELECT proficiency FROM character_proficiencies WHERE character_id = ?`
+// ownsCharacter returns an error if the character does not exist or belongs to someone else.
+func ownsCharacter(db *sql.DB, characterId, ownerId int) error {
+	var exists bool
+	err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM characters WHERE id = ? AND owner_id = ?)", characterId, ownerId).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to check character existence: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("character with ID %d for owner %d not found", characterId, ownerId)
+	}
+	return nil
+}
+
+// loadCharacterDetails fills in the data stored in tables alongside the character row.
+func (r *CharacterRepository) loadCharacterDetails(character *models.Character) error {
+	proficiencies, err := getCharacterProficiencies(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting proficiency for %d: %w", character.ID, err)
+	}
+	character.Proficiencies = proficiencies
+
+	raceStatChoices, err := r.getCharacterRaceStatChoices(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting race stat choices for %d: %w", character.ID, err)
+	}
+	character.RaceStatChoices = raceStatChoices
 
-	result, err := db.Query(profQuery, characterId)
+	spells, err := getCharacterSpells(r.db, character.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get character proficiencies: %w", err)
+		return fmt.Errorf("error getting spells for %d: %w", character.ID, err)
+	}
+	character.Spells = spells
+
+	spellSlots, err := getCharacterSpellSlots(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting spell slots for %d: %w", character.ID, err)
+	}
+	character.SpellSlots = spellSlots
+
+	items, err := getCharacterItems(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting items for %d: %w", character.ID, err)
+	}
+	character.Items = items
+
+	classes, err := getCharacterClasses(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting classes for %d: %w", character.ID, err)
+	}
+	character.Classes = classes
+
+	featureUses, err := getCharacterFeatureUses(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting feature uses for %d: %w", character.ID, err)
+	}
+	character.FeatureUses = featureUses
+
+	hitDice, err := getCharacterHitDice(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting hit dice for %d: %w", character.ID, err)
+	}
+	character.HitDice = hitDice
+
+	conditions, err := getCharacterConditions(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting conditions for %d: %w", character.ID, err)
+	}
+	character.Conditions = conditions
+
+	abilityScoreImprovements, err := getCharacterAbilityScoreImprovements(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting ability score improvements for %d: %w", character.ID, err)
+	}
+	character.AbilityScoreImprovements = abilityScoreImprovements
+
+	hitPoints, err := getCharacterHitPoints(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting hit points for %d: %w", character.ID, err)
+	}
+	character.HitPoints = hitPoints
+
+	abilityScoreRolls, err := getCharacterAbilityScoreRolls(r.db, character.ID)
+	if err != nil {
+		return fmt.Errorf("error getting ability score rolls for %d: %w", character.ID, err)
+	}
+	character.AbilityScoreRolls = abilityScoreRolls
+
+	return nil
+}
+
+// GetPendingAbilityScoreRolls returns the rolls waiting to be claimed by the owner's next rolled character.
+func (r *CharacterRepository) GetPendingAbilityScoreRolls(ownerId int) ([]models.AbilityScoreRoll, error) {
+	return getPendingAbilityScoreRolls(r.db, ownerId)
+}
+
+func (r *CharacterRepository) getCharacterRaceStatChoices(db *sql.DB, characterId int) ([]string, error) {
+	choiceQuery := `SELECT stat FROM character_race_stat_choices WHERE character_id = ? ORDER BY choice_index`
+
+	result, err := db.Query(choiceQuery, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character race stat choices: %w", err)
 	}
 	defer result.Close()
 
-	var proficiencies []string
+	var choices []string
 	for result.Next() {
-		var name string
-		if err := result.Scan(&name); err != nil {
+		var stat string
+		if err := result.Scan(&stat); err != nil {
 			return nil, err
 		}
-		proficiencies = append(proficiencies, name)
+		choices = append(choices, stat)
 	}
 
 	if err := result.Err(); err != nil {
 		return nil, err
 	}
 
-	return proficiencies, nil
+	return choices, nil
+}
+
+func (r *CharacterRepository) insertCharacterRaceStatChoices(tx *sql.Tx, characterId int, choices []string) error {
+	if len(choices) == 0 {
+		return nil
+	}
+
+	choiceInsertStmt, err := tx.Prepare("INSERT INTO character_race_stat_choices (character_id, choice_index, stat) VALUES (?, ?, ?);")
+	if err != nil {
+		return fmt.Errorf("failed to prepare race stat choice insert statement: %w", err)
+	}
+	defer choiceInsertStmt.Close()
+
+	for i, stat := range choices {
+		if _, err := choiceInsertStmt.Exec(characterId, i, stat); err != nil {
+			return fmt.Errorf("failed to insert race stat choice for character %d, stat %s: %w", characterId, stat, err)
+		}
+	}
+	return nil
 }
 
 func (r *CharacterRepository) Create(data *models.Character) (*models.Character, error) {
@@ -54,15 +169,19 @@ func (r *CharacterRepository) Create(data *models.Character) (*models.Character,
 
 	charQuery := `
 		INSERT INTO characters (
-			owner_id, name, bio, background, class, level, race_type, subrace_type, race_move_speed,
-			strength, dexterity, constitution, intelligence, wisdom, charisma, current_health_points
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+			owner_id, name, bio, personality_traits, ideals, bonds, flaws, appearance, alignment, background, class, level,
+			experience, race_type, subrace_type, race_move_speed,
+			draconic_ancestry, strength, dexterity, constitution, intelligence, wisdom, charisma, ability_score_method,
+			variant_encumbrance, ruleset, current_health_points
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 	`
 	result, err := tx.Exec(
 		charQuery,
-		data.OwnerId, data.Name, data.Bio, data.Background, data.Class, data.Level, data.RaceType,
-		subraceType, data.RaceMoveSpeed, data.Strength, data.Dexterity, data.Constitution,
-		data.Intelligence, data.Wisdom, data.Charisma, data.CurrentHealthPoints,
+		data.OwnerId, data.Name, data.Bio, data.PersonalityTraits, data.Ideals, data.Bonds, data.Flaws, data.Appearance,
+		data.Alignment, data.Background, data.Class, data.Level, data.Experience, data.RaceType,
+		subraceType, data.RaceMoveSpeed, data.DraconicAncestry, data.Strength, data.Dexterity, data.Constitution,
+		data.Intelligence, data.Wisdom, data.Charisma, data.AbilityScoreMethod, data.VariantEncumbrance,
+		data.Ruleset, data.CurrentHealthPoints,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert character: %w", err)
@@ -74,17 +193,21 @@ func (r *CharacterRepository) Create(data *models.Character) (*models.Character,
 	}
 	data.ID = int(charID)
 
-	if len(data.BackgroundProficiencies) > 0 {
-		profInsertStmt, err := tx.Prepare("INSERT INTO character_proficiencies (character_id, proficiency) VALUES (?, ?);")
-		if err != nil {
-			return nil, fmt.Errorf("failed to prepare proficiencies insert statement: %w", err)
-		}
-		defer profInsertStmt.Close()
+	if err := replaceCharacterProficiencies(tx, data.ID, data.Proficiencies); err != nil {
+		return nil, err
+	}
+
+	if err := r.insertCharacterRaceStatChoices(tx, data.ID, data.RaceStatChoices); err != nil {
+		return nil, err
+	}
 
-		for _, profName := range data.BackgroundProficiencies {
-			if _, err := profInsertStmt.Exec(charID, profName); err != nil {
-				return nil, fmt.Errorf("failed to insert character proficiency for character %d, proficiency %s: %w", charID, profName, err)
-			}
+	if err := replaceCharacterClasses(tx, data.ID, data.Classes); err != nil {
+		return nil, err
+	}
+
+	if data.AbilityScoreMethod == string(character.AbilityScoreMethodRolled) {
+		if err := claimPendingAbilityScoreRolls(tx, data.ID, data.OwnerId); err != nil {
+			return nil, err
 		}
 	}
 
@@ -100,16 +223,23 @@ func (r *CharacterRepository) Get(id int, ownerId int) (*models.Character, error
 
 	charQuery := `
 		SELECT
-			id, owner_id, name, bio, background, class, level, race_type, subrace_type, race_move_speed,
-			strength, dexterity, constitution, intelligence, wisdom, charisma, current_health_points
+			id, owner_id, name, bio, personality_traits, ideals, bonds, flaws, appearance, alignment, background, class, level,
+			experience, race_type, subrace_type, race_move_speed,
+			draconic_ancestry, strength, dexterity, constitution, intelligence, wisdom, charisma, ability_score_method, variant_encumbrance,
+			ruleset, current_health_points, temporary_health_points, max_health_points_override, death_save_successes, death_save_failures,
+			exhaustion_level
 		FROM characters WHERE id = ? AND owner_id = ?;
 	`
 	row := r.db.QueryRow(charQuery, id, ownerId)
 	err := row.Scan(
-		&character.ID, &character.OwnerId, &character.Name, &character.Bio, &character.Background, &character.Class,
-		&character.Level, &character.RaceType, &character.SubraceType, &character.RaceMoveSpeed,
-		&character.Strength, &character.Dexterity, &character.Constitution, &character.Intelligence,
-		&character.Wisdom, &character.Charisma, &character.CurrentHealthPoints,
+		&character.ID, &character.OwnerId, &character.Name, &character.Bio, &character.PersonalityTraits, &character.Ideals,
+		&character.Bonds, &character.Flaws, &character.Appearance, &character.Alignment, &character.Background, &character.Class,
+		&character.Level, &character.Experience, &character.RaceType, &character.SubraceType, &character.RaceMoveSpeed,
+		&character.DraconicAncestry, &character.Strength, &character.Dexterity, &character.Constitution, &character.Intelligence,
+		&character.Wisdom, &character.Charisma, &character.AbilityScoreMethod, &character.VariantEncumbrance,
+		&character.Ruleset, &character.CurrentHealthPoints,
+		&character.TemporaryHealthPoints, &character.MaxHealthPointsOverride, &character.DeathSaveSuccesses,
+		&character.DeathSaveFailures, &character.ExhaustionLevel,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -118,11 +248,9 @@ func (r *CharacterRepository) Get(id int, ownerId int) (*models.Character, error
 		return nil, fmt.Errorf("failed to get character by ID %d: %w", id, err)
 	}
 
-	proficiencies, err := r.getCharacterProficiencies(r.db, character.ID)
-	if err != nil {
-		return nil, fmt.Errorf("error getting proficiency for %d: %w", character.ID, err)
+	if err := r.loadCharacterDetails(&character); err != nil {
+		return nil, err
 	}
-	character.BackgroundProficiencies = proficiencies
 
 	return &character, nil
 }
@@ -130,8 +258,11 @@ func (r *CharacterRepository) Get(id int, ownerId int) (*models.Character, error
 func (r *CharacterRepository) GetAll(ownerId int) ([]models.Character, error) {
 	query := `
 		SELECT
-			c.id, c.owner_id, c.name, c.bio, c.background, c.class, c.level, c.race_type, c.subrace_type, c.race_move_speed,
-			c.strength, c.dexterity, c.constitution, c.intelligence, c.wisdom, c.charisma, c.current_health_points
+			c.id, c.owner_id, c.name, c.bio, c.personality_traits, c.ideals, c.bonds, c.flaws, c.appearance, c.alignment, c.background,
+			c.class, c.level, c.experience, c.race_type, c.subrace_type, c.race_move_speed,
+			c.draconic_ancestry, c.strength, c.dexterity, c.constitution, c.intelligence, c.wisdom, c.charisma, c.ability_score_method, c.variant_encumbrance,
+			c.ruleset, c.current_health_points, c.temporary_health_points, c.max_health_points_override, c.death_save_successes, c.death_save_failures,
+			c.exhaustion_level
 		FROM characters c
 		WHERE c.owner_id = ?
 		ORDER BY c.id;
@@ -148,20 +279,22 @@ func (r *CharacterRepository) GetAll(ownerId int) ([]models.Character, error) {
 		var char models.Character
 
 		err := rows.Scan(
-			&char.ID, &char.OwnerId, &char.Name, &char.Bio, &char.Background, &char.Class,
-			&char.Level, &char.RaceType, &char.SubraceType, &char.RaceMoveSpeed,
-			&char.Strength, &char.Dexterity, &char.Constitution, &char.Intelligence,
-			&char.Wisdom, &char.Charisma, &char.CurrentHealthPoints,
+			&char.ID, &char.OwnerId, &char.Name, &char.Bio, &char.PersonalityTraits, &char.Ideals,
+			&char.Bonds, &char.Flaws, &char.Appearance, &char.Alignment, &char.Background, &char.Class,
+			&char.Level, &char.Experience, &char.RaceType, &char.SubraceType, &char.RaceMoveSpeed,
+			&char.DraconicAncestry, &char.Strength, &char.Dexterity, &char.Constitution, &char.Intelligence,
+			&char.Wisdom, &char.Charisma, &char.AbilityScoreMethod, &char.VariantEncumbrance,
+			&char.Ruleset, &char.CurrentHealthPoints,
+			&char.TemporaryHealthPoints, &char.MaxHealthPointsOverride, &char.DeathSaveSuccesses,
+			&char.DeathSaveFailures, &char.ExhaustionLevel,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan character row for owner %d: %w", ownerId, err)
 		}
 
-		proficiencies, err := r.getCharacterProficiencies(r.db, char.ID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get character proficiencies: %w", err)
+		if err := r.loadCharacterDetails(&char); err != nil {
+			return nil, err
 		}
-		char.BackgroundProficiencies = proficiencies
 
 		allCharacters = append(allCharacters, char)
 	}
@@ -195,37 +328,44 @@ func (r *CharacterRepository) Update(data *models.Character, id, ownerId int) (*
 
 	charUpdateQuery := `
 		UPDATE characters SET
-			name = ?, bio = ?, background = ?, class = ?, level = ?, race_type = ?, subrace_type = ?, race_move_speed = ?,
-			strength = ?, dexterity = ?, constitution = ?, intelligence = ?, wisdom = ?, charisma = ?, current_health_points = ?
+			name = ?, bio = ?, personality_traits = ?, ideals = ?, bonds = ?, flaws = ?, appearance = ?, alignment = ?, background = ?,
+			class = ?, level = ?, experience = ?, race_type = ?, subrace_type = ?, race_move_speed = ?,
+			draconic_ancestry = ?, strength = ?, dexterity = ?, constitution = ?, intelligence = ?, wisdom = ?, charisma = ?, ability_score_method = ?,
+			variant_encumbrance = ?, current_health_points = ?
 		WHERE id = ? AND owner_id = ?;
 	`
 	_, err = tx.Exec(
 		charUpdateQuery,
-		data.Name, data.Bio, data.Background, data.Class, data.Level, data.RaceType,
-		subraceType, data.RaceMoveSpeed, data.Strength, data.Dexterity, data.Constitution,
-		data.Intelligence, data.Wisdom, data.Charisma, data.CurrentHealthPoints,
-		id, ownerId,
+		data.Name, data.Bio, data.PersonalityTraits, data.Ideals, data.Bonds, data.Flaws, data.Appearance,
+		data.Alignment, data.Background, data.Class, data.Level, data.Experience, data.RaceType,
+		subraceType, data.RaceMoveSpeed, data.DraconicAncestry, data.Strength, data.Dexterity, data.Constitution,
+		data.Intelligence, data.Wisdom, data.Charisma, data.AbilityScoreMethod, data.VariantEncumbrance,
+		data.CurrentHealthPoints, id, ownerId,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update character ID %d: %w", id, err)
 	}
 
-	_, err = tx.Exec("DELETE FROM character_proficiencies WHERE character_id = ?;", id)
+	if err := replaceCharacterProficiencies(tx, id, data.Proficiencies); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec("DELETE FROM character_race_stat_choices WHERE character_id = ?;", id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to delete existing proficiencies for character ID %d: %w", id, err)
+		return nil, fmt.Errorf("failed to delete existing race stat choices for character ID %d: %w", id, err)
 	}
 
-	if len(data.BackgroundProficiencies) > 0 {
-		profInsertStmt, err := tx.Prepare("INSERT INTO character_proficiencies (character_id, proficiency) VALUES (?, ?);")
-		if err != nil {
-			return nil, fmt.Errorf("failed to prepare proficiencies insert statement for update: %w", err)
-		}
-		defer profInsertStmt.Close()
+	if err := r.insertCharacterRaceStatChoices(tx, id, data.RaceStatChoices); err != nil {
+		return nil, err
+	}
+
+	if err := replaceCharacterClasses(tx, id, data.Classes); err != nil {
+		return nil, err
+	}
 
-		for _, profName := range data.BackgroundProficiencies {
-			if _, err := profInsertStmt.Exec(id, profName); err != nil {
-				return nil, fmt.Errorf("failed to insert character proficiency for character %d, proficiency %s during update: %w", id, profName, err)
-			}
+	if data.AbilityScoreMethod == string(character.AbilityScoreMethodRolled) {
+		if err := claimPendingAbilityScoreRolls(tx, id, ownerId); err != nil {
+			return nil, err
 		}
 	}
 
@@ -236,6 +376,39 @@ func (r *CharacterRepository) Update(data *models.Character, id, ownerId int) (*
 	return r.Get(id, ownerId)
 }
 
+func (r *CharacterRepository) UpdateBio(id, ownerId int, bio string) error {
+	result, err := r.db.Exec("UPDATE characters SET bio = ? WHERE id = ? AND owner_id = ?;", bio, id, ownerId)
+	if err != nil {
+		return fmt.Errorf("failed to update the bio of character ID %d: %w", id, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected for bio update on character %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("character with ID %d for owner %d not found", id, ownerId)
+	}
+	return nil
+}
+
+// characterChildTables hold rows keyed by character_id that are deleted with the character.
+// ability_score_rolls is left out: the rolls stay used by the deleted character, so deleting it
+// doesn't give its owner a fresh set to roll.
+var characterChildTables = []string{
+	"character_race_stat_choices",
+	"character_spells",
+	"character_spell_slots",
+	"character_items",
+	"character_rolls",
+	"character_hit_points",
+	"character_classes",
+	"character_feature_uses",
+	"character_hit_dice",
+	"character_conditions",
+	"character_ability_score_improvements",
+	"character_proficiencies",
+}
+
 func (r *CharacterRepository) Delete(id, ownerId int) error {
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -256,6 +429,18 @@ func (r *CharacterRepository) Delete(id, ownerId int) error {
 		return fmt.Errorf("no character found with ID %d for owner %d to delete", id, ownerId)
 	}
 
+	// The child tables declare ON DELETE CASCADE, but SQLite only enforces it with foreign keys on,
+	// so the rows are removed here as well.
+	_, err = tx.Exec("DELETE FROM character_item_properties WHERE item_id IN (SELECT id FROM character_items WHERE character_id = ?);", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete item properties for character ID %d: %w", id, err)
+	}
+	for _, table := range characterChildTables {
+		if _, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE character_id = ?;", table), id); err != nil {
+			return fmt.Errorf("failed to delete %s for character ID %d: %w", table, id, err)
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit character deletion transaction: %w", err)
 	}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+func getCharacterClasses(db *sql.DB, characterId int) ([]models.CharacterClass, error) {
+	query := `SELECT character_id, class_index, class, level, subclass FROM character_classes WHERE character_id = ? ORDER BY class_index;`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character classes: %w", err)
+	}
+	defer rows.Close()
+
+	var classes []models.CharacterClass
+	for rows.Next() {
+		var class models.CharacterClass
+		if err := rows.Scan(&class.CharacterId, &class.ClassIndex, &class.Class, &class.Level, &class.Subclass); err != nil {
+			return nil, fmt.Errorf("failed to scan character class row: %w", err)
+		}
+		classes = append(classes, class)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during character class rows iteration for character %d: %w", characterId, err)
+	}
+
+	return classes, nil
+}
+
+// replaceCharacterClasses rewrites the class list and keeps characters.class in step with the starting class.
+func replaceCharacterClasses(tx *sql.Tx, characterId int, classes []models.CharacterClass) error {
+	if _, err := tx.Exec("DELETE FROM character_classes WHERE character_id = ?;", characterId); err != nil {
+		return fmt.Errorf("failed to delete existing classes for character ID %d: %w", characterId, err)
+	}
+	if len(classes) == 0 {
+		return nil
+	}
+
+	insertStmt, err := tx.Prepare("INSERT INTO character_classes (character_id, class_index, class, level, subclass) VALUES (?, ?, ?, ?, ?);")
+	if err != nil {
+		return fmt.Errorf("failed to prepare class insert statement: %w", err)
+	}
+	defer insertStmt.Close()
+
+	for i, class := range classes {
+		if _, err := insertStmt.Exec(characterId, i, class.Class, class.Level, class.Subclass); err != nil {
+			return fmt.Errorf("failed to insert class for character %d, class %s: %w", characterId, class.Class, err)
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE characters SET class = ? WHERE id = ?;", classes[0].Class, characterId); err != nil {
+		return fmt.Errorf("failed to update starting class for character %d: %w", characterId, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type ConditionRepository struct {
+	db *sql.DB
+}
+
+func NewConditionRepository(db *sql.DB) *ConditionRepository {
+	return &ConditionRepository{db}
+}
+
+func getCharacterConditions(db *sql.DB, characterId int) ([]models.CharacterCondition, error) {
+	query := `SELECT character_id, condition, rounds FROM character_conditions WHERE character_id = ? ORDER BY condition;`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character conditions: %w", err)
+	}
+	defer rows.Close()
+
+	var conditions []models.CharacterCondition
+	for rows.Next() {
+		var condition models.CharacterCondition
+		if err := rows.Scan(&condition.CharacterId, &condition.Condition, &condition.Rounds); err != nil {
+			return nil, fmt.Errorf("failed to scan character condition row: %w", err)
+		}
+		conditions = append(conditions, condition)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during character conditions rows iteration for character %d: %w", characterId, err)
+	}
+
+	return conditions, nil
+}
+
+// SetCombatState saves the character's death saving throws, exhaustion and conditions, replacing
+// the conditions already stored, along with any death saving throws rolled.
+func (r *ConditionRepository) SetCombatState(data *models.CharacterCombatState, ownerId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`UPDATE characters SET current_health_points = ?, death_save_successes = ?, death_save_failures = ?,
+			exhaustion_level = ?
+		WHERE id = ? AND owner_id = ?;`,
+		data.CurrentHealthPoints, data.DeathSaveSuccesses, data.DeathSaveFailures, data.ExhaustionLevel,
+		data.CharacterId, ownerId,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update combat state for character %d: %w", data.CharacterId, err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to check combat state update for character %d: %w", data.CharacterId, err)
+	} else if rows == 0 {
+		return fmt.Errorf("character with ID %d for owner %d not found", data.CharacterId, ownerId)
+	}
+
+	if _, err := tx.Exec("DELETE FROM character_conditions WHERE character_id = ?;", data.CharacterId); err != nil {
+		return fmt.Errorf("failed to clear conditions for character %d: %w", data.CharacterId, err)
+	}
+	for _, condition := range data.Conditions {
+		query := `INSERT INTO character_conditions (character_id, condition, rounds) VALUES (?, ?, ?);`
+		if _, err := tx.Exec(query, data.CharacterId, condition.Condition, condition.Rounds); err != nil {
+			return fmt.Errorf("failed to insert condition %s for character %d: %w", condition.Condition, data.CharacterId, err)
+		}
+	}
+
+	for _, roll := range data.Rolls {
+		query := `INSERT INTO character_rolls (character_id, label, expression, breakdown, total) VALUES (?, ?, ?, ?, ?);`
+		if _, err := tx.Exec(query, data.CharacterId, roll.Label, roll.Expression, roll.Breakdown, roll.Total); err != nil {
+			return fmt.Errorf("failed to insert death saving throw roll for character %d: %w", data.CharacterId, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit combat state transaction: %w", err)
+	}
+	return nil
+}
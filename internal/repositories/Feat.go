@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type FeatRepository struct {
+	db *sql.DB
+}
+
+func NewFeatRepository(db *sql.DB) *FeatRepository {
+	return &FeatRepository{db}
+}
+
+func getCharacterAbilityScoreImprovements(db *sql.DB, characterId int) ([]models.CharacterAbilityScoreImprovement, error) {
+	query := `
+		SELECT character_id, class, level, feat, first_stat, second_stat
+		FROM character_ability_score_improvements WHERE character_id = ? ORDER BY class, level;
+	`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character ability score improvements: %w", err)
+	}
+	defer rows.Close()
+
+	var improvements []models.CharacterAbilityScoreImprovement
+	for rows.Next() {
+		var improvement models.CharacterAbilityScoreImprovement
+		var firstStat, secondStat string
+		if err := rows.Scan(&improvement.CharacterId, &improvement.Class, &improvement.Level, &improvement.Feat, &firstStat, &secondStat); err != nil {
+			return nil, fmt.Errorf("failed to scan character ability score improvement row: %w", err)
+		}
+		improvement.Stats = []string{}
+		for _, stat := range []string{firstStat, secondStat} {
+			if stat != "" {
+				improvement.Stats = append(improvement.Stats, stat)
+			}
+		}
+		improvements = append(improvements, improvement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during character ability score improvement rows iteration for character %d: %w", characterId, err)
+	}
+
+	return improvements, nil
+}
+
+func (r *FeatRepository) CreateAbilityScoreImprovement(data *models.CharacterAbilityScoreImprovement, ownerId int) error {
+	if err := ownsCharacter(r.db, data.CharacterId, ownerId); err != nil {
+		return err
+	}
+
+	stats := [2]string{}
+	copy(stats[:], data.Stats)
+	query := `
+		INSERT INTO character_ability_score_improvements (character_id, class, level, feat, first_stat, second_stat)
+		VALUES (?, ?, ?, ?, ?, ?);
+	`
+	if _, err := r.db.Exec(query, data.CharacterId, data.Class, data.Level, data.Feat, stats[0], stats[1]); err != nil {
+		return fmt.Errorf("failed to save ability score improvement at %s %d for character %d: %w", data.Class, data.Level, data.CharacterId, err)
+	}
+	return nil
+}
+
+func (r *FeatRepository) DeleteAbilityScoreImprovement(characterId, ownerId int, class string, level int) error {
+	if err := ownsCharacter(r.db, characterId, ownerId); err != nil {
+		return err
+	}
+
+	query := "DELETE FROM character_ability_score_improvements WHERE character_id = ? AND class = ? AND level = ?;"
+	if _, err := r.db.Exec(query, characterId, class, level); err != nil {
+		return fmt.Errorf("failed to delete ability score improvement at %s %d for character %d: %w", class, level, characterId, err)
+	}
+	return nil
+}
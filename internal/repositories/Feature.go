@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type FeatureRepository struct {
+	db *sql.DB
+}
+
+func NewFeatureRepository(db *sql.DB) *FeatureRepository {
+	return &FeatureRepository{db}
+}
+
+func getCharacterFeatureUses(db *sql.DB, characterId int) ([]models.CharacterFeatureUses, error) {
+	query := `SELECT character_id, feature, expended FROM character_feature_uses WHERE character_id = ?;`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character feature uses: %w", err)
+	}
+	defer rows.Close()
+
+	var uses []models.CharacterFeatureUses
+	for rows.Next() {
+		var entry models.CharacterFeatureUses
+		if err := rows.Scan(&entry.CharacterId, &entry.Feature, &entry.Expended); err != nil {
+			return nil, fmt.Errorf("failed to scan character feature uses row: %w", err)
+		}
+		uses = append(uses, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during character feature uses rows iteration for character %d: %w", characterId, err)
+	}
+
+	return uses, nil
+}
+
+func (r *FeatureRepository) SetSubclass(characterId, ownerId int, class, subclass string) error {
+	if err := ownsCharacter(r.db, characterId, ownerId); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec("UPDATE character_classes SET subclass = ? WHERE character_id = ? AND class = ?;", subclass, characterId, class)
+	if err != nil {
+		return fmt.Errorf("failed to update subclass for character %d: %w", characterId, err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to check subclass update for character %d: %w", characterId, err)
+	} else if rows == 0 {
+		return fmt.Errorf("character %d has no levels in %s", characterId, class)
+	}
+	return nil
+}
+
+func (r *FeatureRepository) SetExpendedUses(data *models.CharacterFeatureUses, ownerId int) error {
+	if err := ownsCharacter(r.db, data.CharacterId, ownerId); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO character_feature_uses (character_id, feature, expended) VALUES (?, ?, ?)
+		ON CONFLICT (character_id, feature) DO UPDATE SET expended = excluded.expended;
+	`
+	if _, err := r.db.Exec(query, data.CharacterId, data.Feature, data.Expended); err != nil {
+		return fmt.Errorf("failed to update expended uses of %s for character %d: %w", data.Feature, data.CharacterId, err)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type HealthRepository struct {
+	db *sql.DB
+}
+
+func NewHealthRepository(db *sql.DB) *HealthRepository {
+	return &HealthRepository{db}
+}
+
+func (r *HealthRepository) SetHealth(data *models.CharacterHealth, ownerId int) error {
+	query := `
+		UPDATE characters SET current_health_points = ?, temporary_health_points = ?, max_health_points_override = ?,
+			death_save_successes = ?, death_save_failures = ?
+		WHERE id = ? AND owner_id = ?;
+	`
+	result, err := r.db.Exec(
+		query, data.CurrentHealthPoints, data.TemporaryHealthPoints, data.MaxHealthPointsOverride,
+		data.DeathSaveSuccesses, data.DeathSaveFailures, data.CharacterId, ownerId,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update hit points for character %d: %w", data.CharacterId, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected for hit point update on character %d: %w", data.CharacterId, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("character with ID %d for owner %d not found", data.CharacterId, ownerId)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"errors"
+	"fmt"
+)
+
+type HomebrewRepository struct {
+	db *sql.DB
+}
+
+func NewHomebrewRepository(db *sql.DB) *HomebrewRepository {
+	return &HomebrewRepository{db}
+}
+
+const homebrewSelect = `
+	SELECT h.id, h.owner_id, a.username, h.kind, h.name, h.content, h.shared
+	FROM homebrew h JOIN auth a ON a.id = h.owner_id
+`
+
+func scanHomebrew(rows *sql.Rows) ([]models.Homebrew, error) {
+	defer rows.Close()
+
+	output := []models.Homebrew{}
+	for rows.Next() {
+		var homebrew models.Homebrew
+		err := rows.Scan(
+			&homebrew.ID, &homebrew.OwnerId, &homebrew.OwnerName, &homebrew.Kind, &homebrew.Name,
+			&homebrew.Content, &homebrew.Shared,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan homebrew row: %w", err)
+		}
+		output = append(output, homebrew)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// GetAll returns every user's homebrew, oldest first, for building the rules registry.
+func (r *HomebrewRepository) GetAll() ([]models.Homebrew, error) {
+	rows, err := r.db.Query(homebrewSelect + " ORDER BY h.id;")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get homebrew: %w", err)
+	}
+	return scanHomebrew(rows)
+}
+
+// GetVisible returns the user's own homebrew followed by what other users have shared.
+func (r *HomebrewRepository) GetVisible(userId int) ([]models.Homebrew, error) {
+	query := homebrewSelect + `
+		WHERE h.owner_id = ? OR h.shared = 1
+		ORDER BY h.owner_id != ?, h.kind, h.name;
+	`
+	rows, err := r.db.Query(query, userId, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get homebrew for user %d: %w", userId, err)
+	}
+	return scanHomebrew(rows)
+}
+
+// Get returns the homebrew if it belongs to the user or is shared.
+func (r *HomebrewRepository) Get(id, userId int) (*models.Homebrew, error) {
+	var homebrew models.Homebrew
+	row := r.db.QueryRow(homebrewSelect+" WHERE h.id = ? AND (h.owner_id = ? OR h.shared = 1);", id, userId)
+	err := row.Scan(
+		&homebrew.ID, &homebrew.OwnerId, &homebrew.OwnerName, &homebrew.Kind, &homebrew.Name,
+		&homebrew.Content, &homebrew.Shared,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("homebrew with ID %d for user %d not found", id, userId)
+		}
+		return nil, fmt.Errorf("failed to get homebrew by ID %d: %w", id, err)
+	}
+	return &homebrew, nil
+}
+
+func (r *HomebrewRepository) Create(data *models.Homebrew, ownerId int) (*models.Homebrew, error) {
+	result, err := r.db.Exec(
+		"INSERT INTO homebrew (owner_id, kind, name, content, shared) VALUES (?, ?, ?, ?, ?);",
+		ownerId, data.Kind, data.Name, data.Content, data.Shared,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert homebrew: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get homebrew ID: %w", err)
+	}
+	data.ID = int(id)
+	data.OwnerId = ownerId
+	return data, nil
+}
+
+func (r *HomebrewRepository) Update(data *models.Homebrew, id, ownerId int) (*models.Homebrew, error) {
+	result, err := r.db.Exec(
+		"UPDATE homebrew SET kind = ?, name = ?, content = ?, shared = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND owner_id = ?;",
+		data.Kind, data.Name, data.Content, data.Shared, id, ownerId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update homebrew ID %d: %w", id, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected for homebrew update ID %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("no homebrew found with ID %d for owner %d to update", id, ownerId)
+	}
+	data.ID = id
+	data.OwnerId = ownerId
+	return data, nil
+}
+
+func (r *HomebrewRepository) Delete(id, ownerId int) error {
+	result, err := r.db.Exec("DELETE FROM homebrew WHERE id = ? AND owner_id = ?;", id, ownerId)
+	if err != nil {
+		return fmt.Errorf("failed to delete homebrew ID %d for owner %d: %w", id, ownerId, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected for homebrew deletion ID %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no homebrew found with ID %d for owner %d to delete", id, ownerId)
+	}
+	return nil
+}
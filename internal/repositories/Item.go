@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type ItemRepository struct {
+	db *sql.DB
+}
+
+func NewItemRepository(db *sql.DB) *ItemRepository {
+	return &ItemRepository{db}
+}
+
+func getItemProperties(db *sql.DB, itemId int) ([]string, error) {
+	rows, err := db.Query("SELECT property FROM character_item_properties WHERE item_id = ?;", itemId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item properties: %w", err)
+	}
+	defer rows.Close()
+
+	var properties []string
+	for rows.Next() {
+		var property string
+		if err := rows.Scan(&property); err != nil {
+			return nil, err
+		}
+		properties = append(properties, property)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return properties, nil
+}
+
+func getCharacterItems(db *sql.DB, characterId int) ([]models.CharacterItem, error) {
+	query := `
+		SELECT
+			id, character_id, name, item_type, quantity, weight, equipped, armor_category, armor_class,
+			strength_requirement, stealth_disadvantage, weapon_category, damage, damage_type, versatile_damage, ranged
+		FROM character_items WHERE character_id = ? ORDER BY id;
+	`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.CharacterItem
+	for rows.Next() {
+		var item models.CharacterItem
+		err := rows.Scan(
+			&item.ID, &item.CharacterId, &item.Name, &item.ItemType, &item.Quantity, &item.Weight, &item.Equipped,
+			&item.ArmorCategory, &item.ArmorClass, &item.StrengthRequirement, &item.StealthDisadvantage,
+			&item.WeaponCategory, &item.Damage, &item.DamageType, &item.VersatileDamage, &item.Ranged,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan character item row: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		properties, err := getItemProperties(db, items[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting properties for item %d: %w", items[i].ID, err)
+		}
+		items[i].WeaponProperties = properties
+	}
+
+	return items, nil
+}
+
+func (r *ItemRepository) Create(data *models.CharacterItem, ownerId int) (*models.CharacterItem, error) {
+	if err := ownsCharacter(r.db, data.CharacterId, ownerId); err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO character_items (
+			character_id, name, item_type, quantity, weight, equipped, armor_category, armor_class,
+			strength_requirement, stealth_disadvantage, weapon_category, damage, damage_type, versatile_damage, ranged
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+	result, err := tx.Exec(
+		query,
+		data.CharacterId, data.Name, data.ItemType, data.Quantity, data.Weight, data.Equipped,
+		data.ArmorCategory, data.ArmorClass, data.StrengthRequirement, data.StealthDisadvantage,
+		data.WeaponCategory, data.Damage, data.DamageType, data.VersatileDamage, data.Ranged,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert item for character %d: %w", data.CharacterId, err)
+	}
+
+	itemId, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert ID for item: %w", err)
+	}
+	data.ID = int(itemId)
+
+	if len(data.WeaponProperties) > 0 {
+		propertyInsertStmt, err := tx.Prepare("INSERT INTO character_item_properties (item_id, property) VALUES (?, ?);")
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare item property insert statement: %w", err)
+		}
+		defer propertyInsertStmt.Close()
+
+		for _, property := range data.WeaponProperties {
+			if _, err := propertyInsertStmt.Exec(itemId, property); err != nil {
+				return nil, fmt.Errorf("failed to insert property %s for item %d: %w", property, itemId, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit item creation transaction: %w", err)
+	}
+
+	return data, nil
+}
+
+func (r *ItemRepository) SetEquipped(id, characterId, ownerId int, equipped bool) error {
+	if err := ownsCharacter(r.db, characterId, ownerId); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec("UPDATE character_items SET equipped = ? WHERE id = ? AND character_id = ?;", equipped, id, characterId)
+	if err != nil {
+		return fmt.Errorf("failed to update equipped state of item %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected for item update ID %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no item found with ID %d for character %d", id, characterId)
+	}
+
+	return nil
+}
+
+func (r *ItemRepository) Delete(id, characterId, ownerId int) error {
+	if err := ownsCharacter(r.db, characterId, ownerId); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for item delete: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM character_items WHERE id = ? AND character_id = ?;", id, characterId)
+	if err != nil {
+		return fmt.Errorf("failed to delete item %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected for item deletion ID %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no item found with ID %d for character %d to delete", id, characterId)
+	}
+
+	if _, err := tx.Exec("DELETE FROM character_item_properties WHERE item_id = ?;", id); err != nil {
+		return fmt.Errorf("failed to delete properties for item %d: %w", id, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit item deletion transaction: %w", err)
+	}
+
+	return nil
+}
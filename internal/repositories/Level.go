@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type LevelRepository struct {
+	db *sql.DB
+}
+
+func NewLevelRepository(db *sql.DB) *LevelRepository {
+	return &LevelRepository{db}
+}
+
+func getCharacterHitPoints(db *sql.DB, characterId int) ([]models.CharacterHitPoints, error) {
+	query := `SELECT character_id, level, class, hit_die_roll, rolled FROM character_hit_points WHERE character_id = ? ORDER BY level;`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character hit points: %w", err)
+	}
+	defer rows.Close()
+
+	var history []models.CharacterHitPoints
+	for rows.Next() {
+		var entry models.CharacterHitPoints
+		if err := rows.Scan(&entry.CharacterId, &entry.Level, &entry.Class, &entry.HitDieRoll, &entry.Rolled); err != nil {
+			return nil, fmt.Errorf("failed to scan character hit points row: %w", err)
+		}
+		history = append(history, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during character hit points rows iteration for character %d: %w", characterId, err)
+	}
+
+	return history, nil
+}
+
+func (r *LevelRepository) SetExperience(characterId, ownerId, experience int) error {
+	if err := ownsCharacter(r.db, characterId, ownerId); err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec("UPDATE characters SET experience = ? WHERE id = ?;", experience, characterId); err != nil {
+		return fmt.Errorf("failed to update experience for character %d: %w", characterId, err)
+	}
+	return nil
+}
+
+// AddLevel records the hit points for a new level and moves the character to it with the updated class list.
+func (r *LevelRepository) AddLevel(data *models.CharacterHitPoints, classes []models.CharacterClass, ownerId, currentHealthPoints int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		"UPDATE characters SET level = ?, current_health_points = ? WHERE id = ? AND owner_id = ? AND level = ?;",
+		data.Level, currentHealthPoints, data.CharacterId, ownerId, data.Level-1,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update level for character %d: %w", data.CharacterId, err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected for level up of character %d: %w", data.CharacterId, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("character with ID %d for owner %d not found at level %d", data.CharacterId, ownerId, data.Level-1)
+	}
+
+	query := `INSERT INTO character_hit_points (character_id, level, class, hit_die_roll, rolled) VALUES (?, ?, ?, ?, ?);`
+	if _, err := tx.Exec(query, data.CharacterId, data.Level, data.Class, data.HitDieRoll, data.Rolled); err != nil {
+		return fmt.Errorf("failed to insert hit points for character %d level %d: %w", data.CharacterId, data.Level, err)
+	}
+
+	if err := replaceCharacterClasses(tx, data.CharacterId, classes); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit level up transaction: %w", err)
+	}
+	return nil
+}
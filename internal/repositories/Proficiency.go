@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type ProficiencyRepository struct {
+	db *sql.DB
+}
+
+func NewProficiencyRepository(db *sql.DB) *ProficiencyRepository {
+	return &ProficiencyRepository{db}
+}
+
+func getCharacterProficiencies(db *sql.DB, characterId int) ([]models.CharacterProficiency, error) {
+	query := `
+		SELECT character_id, kind, name, level, source
+		FROM character_proficiencies WHERE character_id = ? ORDER BY kind, name;
+	`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character proficiencies: %w", err)
+	}
+	defer rows.Close()
+
+	var proficiencies []models.CharacterProficiency
+	for rows.Next() {
+		var proficiency models.CharacterProficiency
+		if err := rows.Scan(&proficiency.CharacterId, &proficiency.Kind, &proficiency.Name, &proficiency.Level, &proficiency.Source); err != nil {
+			return nil, fmt.Errorf("failed to scan character proficiency row: %w", err)
+		}
+		proficiencies = append(proficiencies, proficiency)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during character proficiency rows iteration for character %d: %w", characterId, err)
+	}
+
+	return proficiencies, nil
+}
+
+// replaceCharacterProficiencies swaps the character's stored proficiencies for the given list.
+func replaceCharacterProficiencies(tx *sql.Tx, characterId int, proficiencies []models.CharacterProficiency) error {
+	if _, err := tx.Exec("DELETE FROM character_proficiencies WHERE character_id = ?;", characterId); err != nil {
+		return fmt.Errorf("failed to delete existing proficiencies for character ID %d: %w", characterId, err)
+	}
+	if len(proficiencies) == 0 {
+		return nil
+	}
+
+	insertStmt, err := tx.Prepare("INSERT INTO character_proficiencies (character_id, kind, name, level, source) VALUES (?, ?, ?, ?, ?);")
+	if err != nil {
+		return fmt.Errorf("failed to prepare proficiencies insert statement: %w", err)
+	}
+	defer insertStmt.Close()
+
+	for _, proficiency := range proficiencies {
+		if _, err := insertStmt.Exec(characterId, proficiency.Kind, proficiency.Name, proficiency.Level, proficiency.Source); err != nil {
+			return fmt.Errorf("failed to insert character proficiency for character %d, proficiency %s: %w", characterId, proficiency.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *ProficiencyRepository) Create(data *models.CharacterProficiency, ownerId int) error {
+	if err := ownsCharacter(r.db, data.CharacterId, ownerId); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO character_proficiencies (character_id, kind, name, level, source) VALUES (?, ?, ?, ?, ?);"
+	if _, err := r.db.Exec(query, data.CharacterId, data.Kind, data.Name, data.Level, data.Source); err != nil {
+		return fmt.Errorf("failed to save %s proficiency %s for character %d: %w", data.Kind, data.Name, data.CharacterId, err)
+	}
+	return nil
+}
+
+func (r *ProficiencyRepository) Delete(data *models.CharacterProficiency, ownerId int) error {
+	if err := ownsCharacter(r.db, data.CharacterId, ownerId); err != nil {
+		return err
+	}
+
+	query := "DELETE FROM character_proficiencies WHERE character_id = ? AND kind = ? AND name = ? AND level = ? AND source = ?;"
+	if _, err := r.db.Exec(query, data.CharacterId, data.Kind, data.Name, data.Level, data.Source); err != nil {
+		return fmt.Errorf("failed to delete %s proficiency %s for character %d: %w", data.Kind, data.Name, data.CharacterId, err)
+	}
+	return nil
+}
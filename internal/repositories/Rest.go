@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type RestRepository struct {
+	db *sql.DB
+}
+
+func NewRestRepository(db *sql.DB) *RestRepository {
+	return &RestRepository{db}
+}
+
+func getCharacterHitDice(db *sql.DB, characterId int) ([]models.CharacterHitDice, error) {
+	query := `SELECT character_id, die, expended FROM character_hit_dice WHERE character_id = ?;`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character hit dice: %w", err)
+	}
+	defer rows.Close()
+
+	var hitDice []models.CharacterHitDice
+	for rows.Next() {
+		var entry models.CharacterHitDice
+		if err := rows.Scan(&entry.CharacterId, &entry.Die, &entry.Expended); err != nil {
+			return nil, fmt.Errorf("failed to scan character hit dice row: %w", err)
+		}
+		hitDice = append(hitDice, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during character hit dice rows iteration for character %d: %w", characterId, err)
+	}
+
+	return hitDice, nil
+}
+
+// Rest saves the hit points, hit dice, feature uses and spell slots left after a rest, along with
+// the hit dice rolled during it.
+func (r *RestRepository) Rest(data *models.CharacterRest, ownerId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`UPDATE characters SET current_health_points = ?, temporary_health_points = ?, death_save_successes = ?,
+			death_save_failures = ?, exhaustion_level = ?
+		WHERE id = ? AND owner_id = ?;`,
+		data.CurrentHealthPoints, data.TemporaryHealthPoints, data.DeathSaveSuccesses, data.DeathSaveFailures,
+		data.ExhaustionLevel, data.CharacterId, ownerId,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update hit points for character %d: %w", data.CharacterId, err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to check rest update for character %d: %w", data.CharacterId, err)
+	} else if rows == 0 {
+		return fmt.Errorf("character with ID %d for owner %d not found", data.CharacterId, ownerId)
+	}
+
+	for _, hitDice := range data.HitDice {
+		query := `
+			INSERT INTO character_hit_dice (character_id, die, expended) VALUES (?, ?, ?)
+			ON CONFLICT (character_id, die) DO UPDATE SET expended = excluded.expended;
+		`
+		if _, err := tx.Exec(query, data.CharacterId, hitDice.Die, hitDice.Expended); err != nil {
+			return fmt.Errorf("failed to update d%d hit dice for character %d: %w", hitDice.Die, data.CharacterId, err)
+		}
+	}
+
+	for _, uses := range data.FeatureUses {
+		query := `
+			INSERT INTO character_feature_uses (character_id, feature, expended) VALUES (?, ?, ?)
+			ON CONFLICT (character_id, feature) DO UPDATE SET expended = excluded.expended;
+		`
+		if _, err := tx.Exec(query, data.CharacterId, uses.Feature, uses.Expended); err != nil {
+			return fmt.Errorf("failed to update expended uses of %s for character %d: %w", uses.Feature, data.CharacterId, err)
+		}
+	}
+
+	for i, expended := range data.ExpendedSpellSlots {
+		query := "UPDATE character_spell_slots SET expended = ? WHERE character_id = ? AND slot_level = ? AND pact = 0;"
+		if _, err := tx.Exec(query, expended, data.CharacterId, i+1); err != nil {
+			return fmt.Errorf("failed to update level %d spell slots for character %d: %w", i+1, data.CharacterId, err)
+		}
+	}
+	if _, err := tx.Exec("UPDATE character_spell_slots SET expended = ? WHERE character_id = ? AND pact = 1;", data.ExpendedPactSlots, data.CharacterId); err != nil {
+		return fmt.Errorf("failed to update pact slots for character %d: %w", data.CharacterId, err)
+	}
+
+	for _, roll := range data.Rolls {
+		query := `INSERT INTO character_rolls (character_id, label, expression, breakdown, total) VALUES (?, ?, ?, ?, ?);`
+		if _, err := tx.Exec(query, data.CharacterId, roll.Label, roll.Expression, roll.Breakdown, roll.Total); err != nil {
+			return fmt.Errorf("failed to insert hit die roll for character %d: %w", data.CharacterId, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rest transaction: %w", err)
+	}
+	return nil
+}
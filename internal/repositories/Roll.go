@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type RollRepository struct {
+	db *sql.DB
+}
+
+func NewRollRepository(db *sql.DB) *RollRepository {
+	return &RollRepository{db}
+}
+
+func (r *RollRepository) Create(data *models.CharacterRoll, ownerId int) (*models.CharacterRoll, error) {
+	if err := ownsCharacter(r.db, data.CharacterId, ownerId); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO character_rolls (character_id, label, expression, breakdown, total) VALUES (?, ?, ?, ?, ?);`
+	result, err := r.db.Exec(query, data.CharacterId, data.Label, data.Expression, data.Breakdown, data.Total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert roll for character %d: %w", data.CharacterId, err)
+	}
+
+	rollId, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert ID for roll: %w", err)
+	}
+	data.ID = int(rollId)
+
+	return data, nil
+}
+
+// GetRecent returns the latest rolls for a character, newest first.
+func (r *RollRepository) GetRecent(characterId, ownerId, limit int) ([]models.CharacterRoll, error) {
+	if err := ownsCharacter(r.db, characterId, ownerId); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT id, character_id, label, expression, breakdown, total, created_at
+		FROM character_rolls WHERE character_id = ? ORDER BY id DESC LIMIT ?;
+	`
+	rows, err := r.db.Query(query, characterId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rolls for character %d: %w", characterId, err)
+	}
+	defer rows.Close()
+
+	var rolls []models.CharacterRoll
+	for rows.Next() {
+		var roll models.CharacterRoll
+		if err := rows.Scan(&roll.ID, &roll.CharacterId, &roll.Label, &roll.Expression, &roll.Breakdown, &roll.Total, &roll.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan roll row: %w", err)
+		}
+		rolls = append(rolls, roll)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during roll rows iteration for character %d: %w", characterId, err)
+	}
+
+	return rolls, nil
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"database/sql"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+type SpellRepository struct {
+	db *sql.DB
+}
+
+func NewSpellRepository(db *sql.DB) *SpellRepository {
+	return &SpellRepository{db}
+}
+
+func getCharacterSpells(db *sql.DB, characterId int) ([]models.CharacterSpell, error) {
+	query := `
+		SELECT id, character_id, name, level, school, prepared, description
+		FROM character_spells WHERE character_id = ? ORDER BY level, name;
+	`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character spells: %w", err)
+	}
+	defer rows.Close()
+
+	var spells []models.CharacterSpell
+	for rows.Next() {
+		var spell models.CharacterSpell
+		if err := rows.Scan(&spell.ID, &spell.CharacterId, &spell.Name, &spell.Level, &spell.School, &spell.Prepared, &spell.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan character spell row: %w", err)
+		}
+		spells = append(spells, spell)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return spells, nil
+}
+
+func getCharacterSpellSlots(db *sql.DB, characterId int) ([]models.CharacterSpellSlots, error) {
+	query := `SELECT character_id, slot_level, pact, expended FROM character_spell_slots WHERE character_id = ?;`
+	rows, err := db.Query(query, characterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character spell slots: %w", err)
+	}
+	defer rows.Close()
+
+	var slots []models.CharacterSpellSlots
+	for rows.Next() {
+		var slot models.CharacterSpellSlots
+		if err := rows.Scan(&slot.CharacterId, &slot.SlotLevel, &slot.Pact, &slot.Expended); err != nil {
+			return nil, fmt.Errorf("failed to scan character spell slot row: %w", err)
+		}
+		slots = append(slots, slot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return slots, nil
+}
+
+func (r *SpellRepository) Create(data *models.CharacterSpell, ownerId int) (*models.CharacterSpell, error) {
+	if err := ownsCharacter(r.db, data.CharacterId, ownerId); err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO character_spells (character_id, name, level, school, prepared, description)
+		VALUES (?, ?, ?, ?, ?, ?);
+	`
+	result, err := r.db.Exec(query, data.CharacterId, data.Name, data.Level, data.School, data.Prepared, data.Description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert spell for character %d: %w", data.CharacterId, err)
+	}
+
+	spellId, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert ID for spell: %w", err)
+	}
+	data.ID = int(spellId)
+
+	return data, nil
+}
+
+func (r *SpellRepository) SetPrepared(id, characterId, ownerId int, prepared bool) error {
+	if err := ownsCharacter(r.db, characterId, ownerId); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec("UPDATE character_spells SET prepared = ? WHERE id = ? AND character_id = ?;", prepared, id, characterId)
+	if err != nil {
+		return fmt.Errorf("failed to update prepared state of spell %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected for spell update ID %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no spell found with ID %d for character %d", id, characterId)
+	}
+
+	return nil
+}
+
+func (r *SpellRepository) Delete(id, characterId, ownerId int) error {
+	if err := ownsCharacter(r.db, characterId, ownerId); err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec("DELETE FROM character_spells WHERE id = ? AND character_id = ?;", id, characterId)
+	if err != nil {
+		return fmt.Errorf("failed to delete spell %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected for spell deletion ID %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no spell found with ID %d for character %d to delete", id, characterId)
+	}
+
+	return nil
+}
+
+func (r *SpellRepository) SetExpendedSlots(data *models.CharacterSpellSlots, ownerId int) error {
+	if err := ownsCharacter(r.db, data.CharacterId, ownerId); err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for spell slot update: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Pact slots change level as the warlock levels up, so only the current level is kept.
+	if data.Pact {
+		_, err := tx.Exec("DELETE FROM character_spell_slots WHERE character_id = ? AND pact = 1 AND slot_level != ?;", data.CharacterId, data.SlotLevel)
+		if err != nil {
+			return fmt.Errorf("failed to clear old pact slots for character %d: %w", data.CharacterId, err)
+		}
+	}
+
+	query := `
+		INSERT INTO character_spell_slots (character_id, slot_level, pact, expended) VALUES (?, ?, ?, ?)
+		ON CONFLICT (character_id, slot_level, pact) DO UPDATE SET expended = excluded.expended;
+	`
+	if _, err := tx.Exec(query, data.CharacterId, data.SlotLevel, data.Pact, data.Expended); err != nil {
+		return fmt.Errorf("failed to update expended spell slots for character %d: %w", data.CharacterId, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit spell slot update transaction: %w", err)
+	}
+
+	return nil
+}
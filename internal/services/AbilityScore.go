@@ -0,0 +1,59 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/dice"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+)
+
+type AbilityScoreService struct {
+	repo   *repositories.AbilityScoreRepository
+	roller *dice.Roller
+}
+
+func NewAbilityScoreService(repo *repositories.AbilityScoreRepository, roller *dice.Roller) *AbilityScoreService {
+	return &AbilityScoreService{repo: repo, roller: roller}
+}
+
+// Roll rolls 4d6-drop-lowest for each ability score and records the results. An owner with
+// rolls that haven't been used by a character yet gets those back instead of a fresh set.
+func (s *AbilityScoreService) Roll(userId int) ([]models.AbilityScoreRoll, error) {
+	pending, err := s.repo.GetPending(userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return pending, nil
+	}
+
+	rolls := make([]models.AbilityScoreRoll, character.AbilityRollCount)
+	for i := range rolls {
+		result, err := s.roller.RollString(character.AbilityRollExpression)
+		if err != nil {
+			return nil, err
+		}
+		rolls[i] = models.AbilityScoreRoll{
+			OwnerId:   userId,
+			RollIndex: i,
+			Breakdown: result.Breakdown(),
+			Total:     result.Total,
+		}
+	}
+	return s.repo.CreatePending(userId, rolls)
+}
+
+// GetRolls returns the rolls a character was created with, or the owner's pending rolls when
+// characterId is zero or the character has none.
+func (s *AbilityScoreService) GetRolls(characterId, userId int) ([]models.AbilityScoreRoll, error) {
+	if characterId != 0 {
+		rolls, err := s.repo.GetForCharacter(characterId, userId)
+		if err != nil {
+			return nil, err
+		}
+		if len(rolls) > 0 {
+			return rolls, nil
+		}
+	}
+	return s.repo.GetPending(userId)
+}
@@ -1,10 +1,15 @@
 package services
 
 import (
+	"dndcc/internal/character"
 	"dndcc/internal/models"
 	"dndcc/internal/repositories"
+	"errors"
+	"strings"
 )
 
+var ErrManualAbilityScores = errors.New("manual ability scores are only kept by characters created before generation methods")
+
 type CharacterService struct {
 	repo *repositories.CharacterRepository
 }
@@ -14,9 +19,26 @@ func NewCharacterService(repo *repositories.CharacterRepository) *CharacterServi
 }
 
 func (s *CharacterService) Create(data *models.Character) (*models.Character, error) {
+	// New characters use the latest rules unless an older ruleset was picked.
+	if data.Ruleset == "" {
+		data.Ruleset = string(character.Ruleset2024)
+	}
+	switch character.AbilityScoreMethod(data.AbilityScoreMethod) {
+	case character.AbilityScoreMethodManual:
+		return nil, ErrManualAbilityScores
+	case character.AbilityScoreMethodRolled:
+		rolls, err := s.repo.GetPendingAbilityScoreRolls(data.OwnerId)
+		if err != nil {
+			return nil, err
+		}
+		data.AbilityScoreRolls = rolls
+	}
 	if err := data.Validate(); err != nil {
 		return nil, err
 	}
+	data.Classes = []models.CharacterClass{{Class: data.Class, Level: data.Level}}
+	data.Experience = character.GetExperienceForLevel(data.Level)
+	data.CurrentHealthPoints = data.ToCharacterSheet().GetMaxHealthPoints()
 	return s.repo.Create(data)
 }
 
@@ -29,12 +51,56 @@ func (s *CharacterService) List(userId int) ([]models.Character, error) {
 }
 
 func (s *CharacterService) Update(data *models.Character, id, userId int) (*models.Character, error) {
+	existing, err := s.repo.Get(id, userId)
+	if err != nil {
+		return nil, err
+	}
+	// Level, experience and hit points only change through the level up and hit point workflows.
+	data.Level = existing.Level
+	data.Experience = existing.Experience
+	data.CurrentHealthPoints = existing.CurrentHealthPoints
+	// The ruleset is pinned when the character is created, and the owner's homebrew is what it
+	// is validated against.
+	data.Ruleset = existing.Ruleset
+	data.OwnerId = existing.OwnerId
+	// A single-class character may still swap class, losing its subclass if it does; multiclassed
+	// characters keep their class list.
+	data.Classes = existing.Classes
+	if len(existing.Classes) <= 1 && (len(existing.Classes) == 0 || existing.Classes[0].Class != data.Class) {
+		data.Classes = []models.CharacterClass{{Class: data.Class, Level: data.Level}}
+	}
+	// The form only sets class and custom background skills; proficiencies chosen on the sheet are kept.
+	for _, proficiency := range existing.Proficiencies {
+		if !proficiency.IsFormChoice() {
+			data.Proficiencies = append(data.Proficiencies, proficiency)
+		}
+	}
+	switch character.AbilityScoreMethod(data.AbilityScoreMethod) {
+	case character.AbilityScoreMethodManual:
+		if existing.AbilityScoreMethod != data.AbilityScoreMethod {
+			return nil, ErrManualAbilityScores
+		}
+	case character.AbilityScoreMethodRolled:
+		data.AbilityScoreRolls = existing.AbilityScoreRolls
+		if len(data.AbilityScoreRolls) == 0 {
+			rolls, err := s.repo.GetPendingAbilityScoreRolls(userId)
+			if err != nil {
+				return nil, err
+			}
+			data.AbilityScoreRolls = rolls
+		}
+	}
 	if err := data.Validate(); err != nil {
 		return nil, err
 	}
 	return s.repo.Update(data, id, userId)
 }
 
+// SetBio saves an accepted bio draft without touching the rest of the character.
+func (s *CharacterService) SetBio(id, userId int, bio string) error {
+	return s.repo.UpdateBio(id, userId, strings.TrimSpace(bio))
+}
+
 func (s *CharacterService) Delete(id, userId int) error {
 	return s.repo.Delete(id, userId)
 }
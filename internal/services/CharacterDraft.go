@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrEmptyCharacterDescription   = errors.New("describe the character to create it")
+	ErrCharacterDescriptionTooLong = errors.New("the character description is too long")
+	ErrInvalidCharacterDraft       = errors.New("the character draft is invalid")
+)
+
+// maxCharacterDescriptionLength caps the description sent to the LLM.
+const maxCharacterDescriptionLength = 1000
+
+// characterDraft is the JSON object the LLM fills in for a described character.
+type characterDraft struct {
+	Name                string         `json:"name"`
+	Race                string         `json:"race"`
+	Subrace             string         `json:"subrace"`
+	Class               string         `json:"class"`
+	Background          string         `json:"background"`
+	AbilityScores       map[string]int `json:"ability_scores"`
+	AbilityScoreChoices []string       `json:"ability_score_choices"`
+	ClassSkills         []string       `json:"class_skills"`
+	DraconicAncestry    string         `json:"draconic_ancestry"`
+	Bio                 string         `json:"bio"`
+}
+
+// toCharacter maps the draft onto a level 1 character of the user using the standard array,
+// checking it against rules the same way a character from the form is checked. Names are matched regardless of case,
+// and a draconic ancestry is dropped for anyone but a Dragonborn; anything else that doesn't fit
+// the rules is an error that says what to fix.
+func (d *characterDraft) toCharacter(rules *character.Registry, version character.RulesetVersion, userId int) (*models.Character, error) {
+	race, ok := matchName(rules.RaceNames(), d.Race)
+	if !ok {
+		return nil, fmt.Errorf("%w: race %q is not one of the listed races", ErrInvalidCharacterDraft, d.Race)
+	}
+	subrace := character.SubraceNone
+	if subraces := rules.RaceSubraceNames(race); len(subraces) > 0 {
+		if subrace, ok = matchName(subraces, d.Subrace); !ok {
+			return nil, fmt.Errorf("%w: a %s needs one of the subraces %s, not %q", ErrInvalidCharacterDraft, race, joinNames(subraces), d.Subrace)
+		}
+	}
+	class, ok := matchName(playerClassNames(rules), d.Class)
+	if !ok {
+		return nil, fmt.Errorf("%w: class %q is not one of the listed classes", ErrInvalidCharacterDraft, d.Class)
+	}
+	background, ok := matchName(rules.BackgroundNames(), d.Background)
+	if !ok {
+		return nil, fmt.Errorf("%w: background %q is not one of the listed backgrounds", ErrInvalidCharacterDraft, d.Background)
+	}
+
+	scores := map[character.StatName]int{}
+	for name, score := range d.AbilityScores {
+		stat, ok := matchName(character.StatNames, name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not an ability score", ErrInvalidCharacterDraft, name)
+		}
+		scores[stat] = score
+	}
+	if len(scores) != len(character.StatNames) {
+		return nil, fmt.Errorf("%w: ability_scores needs a score for each of %s", ErrInvalidCharacterDraft, joinNames(character.StatNames))
+	}
+	choices := []string{}
+	for _, name := range d.AbilityScoreChoices {
+		stat, ok := matchName(character.StatNames, name)
+		if !ok {
+			return nil, fmt.Errorf("%w: ability score choice %q is not an ability score", ErrInvalidCharacterDraft, name)
+		}
+		choices = append(choices, string(stat))
+	}
+	skills := []string{}
+	for _, name := range d.ClassSkills {
+		skill, ok := matchName(rules.SkillNames(), name)
+		if !ok {
+			return nil, fmt.Errorf("%w: class skill %q is not a skill", ErrInvalidCharacterDraft, name)
+		}
+		skills = append(skills, string(skill))
+	}
+	ancestry := ""
+	if race == character.RaceDragonborn && d.DraconicAncestry != "" {
+		match, ok := matchName(character.DraconicAncestries, d.DraconicAncestry)
+		if !ok {
+			return nil, fmt.Errorf("%w: draconic ancestry %q is not one of %s", ErrInvalidCharacterDraft, d.DraconicAncestry, joinNames(character.DraconicAncestries))
+		}
+		ancestry = string(match)
+	}
+
+	data := &models.Character{
+		OwnerId:            userId,
+		Name:               strings.TrimSpace(d.Name),
+		Bio:                strings.TrimSpace(d.Bio),
+		Background:         string(background),
+		Class:              string(class),
+		Level:              1,
+		RaceType:           string(race),
+		SubraceType:        sql.NullString{String: string(subrace), Valid: true},
+		DraconicAncestry:   ancestry,
+		Strength:           scores[character.StatStrength],
+		Dexterity:          scores[character.StatDexterity],
+		Constitution:       scores[character.StatConstitution],
+		Intelligence:       scores[character.StatIntelligence],
+		Wisdom:             scores[character.StatWisdom],
+		Charisma:           scores[character.StatCharisma],
+		AbilityScoreMethod: string(character.AbilityScoreMethodStandardArray),
+		Ruleset:            string(version),
+		Proficiencies:      models.SkillProficienciesFromForm(skills, character.SourceClass),
+		RaceStatChoices:    choices,
+	}
+
+	// The form lets a player leave choices for later, but a draft should come with all of them made.
+	sheet := data.ToCharacterSheet()
+	if count := sheet.GetRuleset().GetOriginStatChoiceCount(sheet); len(choices) != count {
+		return nil, fmt.Errorf("%w: ability_score_choices needs %d abilities, got %d", ErrInvalidCharacterDraft, count, len(choices))
+	}
+	definition, _ := rules.Class(class)
+	if count := definition.SkillChoices.Count; len(skills) != count {
+		return nil, fmt.Errorf("%w: a %s picks %d class skills, got %d", ErrInvalidCharacterDraft, class, count, len(skills))
+	}
+	if err := data.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCharacterDraft, err)
+	}
+	return data, nil
+}
+
+// matchName returns the option that value names, ignoring case and surrounding space.
+func matchName[T ~string](options []T, value string) (T, bool) {
+	value = strings.TrimSpace(value)
+	for _, option := range options {
+		if strings.EqualFold(string(option), value) {
+			return option, true
+		}
+	}
+	var empty T
+	return empty, false
+}
+
+func joinNames[T ~string](names []T) string {
+	output := make([]string, len(names))
+	for i, name := range names {
+		output[i] = string(name)
+	}
+	return strings.Join(output, ", ")
+}
+
+// playerClassNames returns the classes a player character can start in.
+func playerClassNames(rules *character.Registry) []character.ClassName {
+	return slices.DeleteFunc(rules.ClassNames(), func(name character.ClassName) bool {
+		definition, _ := rules.Class(name)
+		return definition.NonPlayer
+	})
+}
+
+// CharacterDraftService creates characters from a player's description of them, drafted by the LLM
+// and saved like a character made on the form.
+type CharacterDraftService struct {
+	llm        *LLMService
+	characters *CharacterService
+	homebrew   *HomebrewService
+}
+
+func NewCharacterDraftService(llm *LLMService, characters *CharacterService, homebrew *HomebrewService) *CharacterDraftService {
+	return &CharacterDraftService{llm: llm, characters: characters, homebrew: homebrew}
+}
+
+// Create drafts a level 1 character from the description with the rules and homebrew the user can
+// pick, and saves it. A draft the LLM can't get right is never saved.
+func (s *CharacterDraftService) Create(ctx context.Context, userId int, version character.RulesetVersion, description string) (*models.Character, error) {
+	if !s.llm.IsConfigured() {
+		return nil, ErrLLMNotConfigured
+	}
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil, ErrEmptyCharacterDescription
+	}
+	if len(description) > maxCharacterDescriptionLength {
+		return nil, fmt.Errorf("%w: keep it under %d characters", ErrCharacterDescriptionTooLong, maxCharacterDescriptionLength)
+	}
+	// New characters use the latest rules unless an older ruleset was picked.
+	if !version.IsValid() {
+		version = character.Ruleset2024
+	}
+	rules, err := s.homebrew.Rules(userId, version)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.llm.GenerateCharacter(ctx, userId, description, rules, version)
+	if err != nil {
+		return nil, err
+	}
+	return s.characters.Create(data)
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/dice"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+)
+
+type ConditionService struct {
+	repo          *repositories.ConditionRepository
+	characterRepo *repositories.CharacterRepository
+	roller        *dice.Roller
+}
+
+func NewConditionService(repo *repositories.ConditionRepository, characterRepo *repositories.CharacterRepository, roller *dice.Roller) *ConditionService {
+	return &ConditionService{repo: repo, characterRepo: characterRepo, roller: roller}
+}
+
+// RollDeathSave rolls a death saving throw on the server and records it in the roll log.
+func (s *ConditionService) RollDeathSave(characterId, userId int) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if !sheet.IsDying() {
+		return character.ErrNotDying
+	}
+	result, err := s.roller.RollString(withRollMode("1d20", sheet.GetSavingThrowMode("")))
+	if err != nil {
+		return err
+	}
+	if err := sheet.RollDeathSave(result.Groups[0].Subtotal); err != nil {
+		return err
+	}
+
+	roll := models.CharacterRoll{
+		CharacterId: characterId,
+		Label:       "Death saving throw",
+		Expression:  result.Expression,
+		Breakdown:   result.Breakdown(),
+		Total:       result.Total,
+	}
+	return s.repo.SetCombatState(models.CharacterCombatStateFromSheet(characterId, sheet, []models.CharacterRoll{roll}), userId)
+}
+
+// AddCondition applies the condition for a number of rounds, or until removed when rounds is 0.
+func (s *ConditionService) AddCondition(characterId, userId int, condition character.ConditionName, rounds int) error {
+	return s.update(characterId, userId, func(sheet *character.Character) error {
+		return sheet.AddCondition(condition, rounds)
+	})
+}
+
+func (s *ConditionService) RemoveCondition(characterId, userId int, condition character.ConditionName) error {
+	return s.update(characterId, userId, func(sheet *character.Character) error {
+		return sheet.RemoveCondition(condition)
+	})
+}
+
+// EndRound counts down the character's conditions, removing the ones that run out.
+func (s *ConditionService) EndRound(characterId, userId int) error {
+	return s.update(characterId, userId, func(sheet *character.Character) error {
+		sheet.EndRound()
+		return nil
+	})
+}
+
+func (s *ConditionService) SetExhaustionLevel(characterId, userId, level int) error {
+	return s.update(characterId, userId, func(sheet *character.Character) error {
+		return sheet.SetExhaustionLevel(level)
+	})
+}
+
+func (s *ConditionService) update(characterId, userId int, apply func(sheet *character.Character) error) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if err := apply(sheet); err != nil {
+		return err
+	}
+	return s.repo.SetCombatState(models.CharacterCombatStateFromSheet(characterId, sheet, []models.CharacterRoll{}), userId)
+}
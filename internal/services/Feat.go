@@ -0,0 +1,45 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+	"fmt"
+)
+
+type FeatService struct {
+	repo          *repositories.FeatRepository
+	characterRepo *repositories.CharacterRepository
+}
+
+func NewFeatService(repo *repositories.FeatRepository, characterRepo *repositories.CharacterRepository) *FeatService {
+	return &FeatService{repo: repo, characterRepo: characterRepo}
+}
+
+// ChooseAbilityScoreImprovement records the score increases or feat taken for an Ability Score Improvement.
+func (s *FeatService) ChooseAbilityScoreImprovement(characterId, userId int, choice character.AbilityScoreImprovement) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if err := sheet.ChooseAbilityScoreImprovement(choice); err != nil {
+		return fmt.Errorf("failed to choose ability score improvement: %w", err)
+	}
+	chosen := sheet.AbilityScoreImprovements[len(sheet.AbilityScoreImprovements)-1]
+	return s.repo.CreateAbilityScoreImprovement(models.CharacterAbilityScoreImprovementFromSheet(characterId, chosen), userId)
+}
+
+// RemoveAbilityScoreImprovement clears the choice made at a class level so it can be made again.
+func (s *FeatService) RemoveAbilityScoreImprovement(characterId, userId int, class character.ClassName, level int) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := data.ToCharacterSheet().RemoveAbilityScoreImprovement(class, level); err != nil {
+		return fmt.Errorf("failed to remove ability score improvement: %w", err)
+	}
+	return s.repo.DeleteAbilityScoreImprovement(characterId, userId, string(class), level)
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+	"fmt"
+)
+
+type FeatureService struct {
+	repo          *repositories.FeatureRepository
+	characterRepo *repositories.CharacterRepository
+}
+
+func NewFeatureService(repo *repositories.FeatureRepository, characterRepo *repositories.CharacterRepository) *FeatureService {
+	return &FeatureService{repo: repo, characterRepo: characterRepo}
+}
+
+// SetSubclass chooses the subclass for one of the character's classes.
+func (s *FeatureService) SetSubclass(characterId, userId int, class character.ClassName, subclass character.SubclassName) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := data.ToCharacterSheet().SetSubclass(class, subclass); err != nil {
+		return fmt.Errorf("failed to choose subclass: %w", err)
+	}
+	return s.repo.SetSubclass(characterId, userId, string(class), string(subclass))
+}
+
+// UseFeature spends one use of a limited-use feature, failing if none remain.
+func (s *FeatureService) UseFeature(characterId, userId int, feature string) error {
+	return s.updateUses(characterId, userId, feature, true)
+}
+
+// RestoreFeature regains one expended use of a limited-use feature.
+func (s *FeatureService) RestoreFeature(characterId, userId int, feature string) error {
+	return s.updateUses(characterId, userId, feature, false)
+}
+
+func (s *FeatureService) updateUses(characterId, userId int, feature string, use bool) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if use {
+		err = sheet.UseFeature(feature)
+	} else {
+		err = sheet.RestoreFeature(feature)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update feature uses: %w", err)
+	}
+
+	return s.repo.SetExpendedUses(&models.CharacterFeatureUses{
+		CharacterId: characterId,
+		Feature:     feature,
+		Expended:    sheet.ExpendedFeatureUses[feature],
+	}, userId)
+}
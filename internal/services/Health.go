@@ -0,0 +1,65 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+)
+
+type HealthService struct {
+	repo          *repositories.HealthRepository
+	characterRepo *repositories.CharacterRepository
+}
+
+func NewHealthService(repo *repositories.HealthRepository, characterRepo *repositories.CharacterRepository) *HealthService {
+	return &HealthService{repo: repo, characterRepo: characterRepo}
+}
+
+// Damage applies damage to the character, halving it first if they resist the damage type and
+// spending temporary hit points before current hit points.
+func (s *HealthService) Damage(characterId, userId, amount int, damageType character.DamageType, critical bool) (character.DamageResult, error) {
+	var result character.DamageResult
+	err := s.update(characterId, userId, func(sheet *character.Character) error {
+		resisted, err := sheet.ResistDamage(amount, damageType)
+		if err != nil {
+			return err
+		}
+		result, err = sheet.TakeDamage(resisted, critical)
+		result.Resisted = amount - resisted
+		return err
+	})
+	return result, err
+}
+
+func (s *HealthService) Heal(characterId, userId, amount int) error {
+	return s.update(characterId, userId, func(sheet *character.Character) error {
+		_, err := sheet.Heal(amount)
+		return err
+	})
+}
+
+func (s *HealthService) SetTemporaryHitPoints(characterId, userId, amount int) error {
+	return s.update(characterId, userId, func(sheet *character.Character) error {
+		return sheet.SetTemporaryHitPoints(amount)
+	})
+}
+
+// SetMaxHitPointsOverride replaces the calculated hit point maximum; 0 clears the override.
+func (s *HealthService) SetMaxHitPointsOverride(characterId, userId, value int) error {
+	return s.update(characterId, userId, func(sheet *character.Character) error {
+		return sheet.SetMaxHitPointsOverride(value)
+	})
+}
+
+func (s *HealthService) update(characterId, userId int, apply func(sheet *character.Character) error) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if err := apply(sheet); err != nil {
+		return err
+	}
+	return s.repo.SetHealth(models.CharacterHealthFromSheet(characterId, sheet), userId)
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+var ErrHomebrewNameTaken = errors.New("the name is already used by the rules or homebrew you can use")
+
+type HomebrewService struct {
+	repo *repositories.HomebrewRepository
+	// base is the rules content of each ruleset version that homebrew is added on top of.
+	base map[character.RulesetVersion]*character.Registry
+	// mu keeps two saves from rebuilding the rules registry at the same time and losing one of them.
+	mu sync.Mutex
+}
+
+func NewHomebrewService(repo *repositories.HomebrewRepository, base map[character.RulesetVersion]*character.Registry) *HomebrewService {
+	return &HomebrewService{repo: repo, base: base}
+}
+
+// extend adds the homebrew to the base rules of the ruleset version one entry at a time, races
+// before the subraces that use them. An entry that doesn't fit the version, like a subrace whose
+// race was deleted or only exists in the other version, is left out.
+func (s *HomebrewService) extend(version character.RulesetVersion, entries []models.Homebrew) *character.Registry {
+	entries = slices.Clone(entries)
+	slices.SortStableFunc(entries, func(a, b models.Homebrew) int {
+		return slices.Index(models.HomebrewKinds, a.Kind) - slices.Index(models.HomebrewKinds, b.Kind)
+	})
+
+	registry := s.base[version]
+	for _, entry := range entries {
+		pack, err := entry.ToContentPack()
+		if err != nil || entry.Kind == models.HomebrewItem {
+			continue
+		}
+		extended := registry.Extend(pack)
+		if err := extended.Validate(); err != nil {
+			continue
+		}
+		registry = extended
+	}
+	return registry
+}
+
+// LoadRules rebuilds the registries of every ruleset version from the base content and the
+// homebrew. The registry every character shares only adds shared homebrew. Each user with private
+// homebrew gets a registry of their own with it added first, so their characters resolve their own
+// entry even when another user has a private entry with the same name.
+func (s *HomebrewService) LoadRules() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.repo.GetAll()
+	if err != nil {
+		return err
+	}
+	shared := []models.Homebrew{}
+	owned := map[int][]models.Homebrew{}
+	private := map[int]bool{}
+	for _, entry := range entries {
+		if entry.Shared {
+			shared = append(shared, entry)
+		} else {
+			private[entry.OwnerId] = true
+		}
+		owned[entry.OwnerId] = append(owned[entry.OwnerId], entry)
+	}
+	for version := range s.base {
+		character.UseRuleset(version, s.extend(version, firstByName(shared)))
+		registries := map[int]*character.Registry{}
+		for ownerId := range private {
+			registries[ownerId] = s.extend(version, firstByName(slices.Concat(owned[ownerId], shared)))
+		}
+		character.UseOwnerRulesets(version, registries)
+	}
+	return nil
+}
+
+// Rules returns the base rules of the ruleset version with the homebrew the user can pick: their
+// own and what others have shared. Names are only unique among what a user can see, so the user's
+// own entry wins over a shared one with the same name. An empty version is the 2014 rules.
+func (s *HomebrewService) Rules(userId int, version character.RulesetVersion) (*character.Registry, error) {
+	if !version.IsValid() {
+		version = character.Ruleset2014
+	}
+	entries, err := s.repo.GetVisible(userId)
+	if err != nil {
+		return nil, err
+	}
+	return s.extend(version, firstByName(entries)), nil
+}
+
+// firstByName drops entries with the kind and name of an earlier one. GetVisible lists the user's
+// own homebrew first.
+func firstByName(entries []models.Homebrew) []models.Homebrew {
+	type key struct {
+		kind models.HomebrewKind
+		name string
+	}
+	seen := map[key]bool{}
+	output := []models.Homebrew{}
+	for _, entry := range entries {
+		if seen[key{entry.Kind, entry.Name}] {
+			continue
+		}
+		seen[key{entry.Kind, entry.Name}] = true
+		output = append(output, entry)
+	}
+	return output
+}
+
+func (s *HomebrewService) List(userId int) ([]models.Homebrew, error) {
+	return s.repo.GetVisible(userId)
+}
+
+// ListItems returns the homebrew items the user can add to an inventory.
+func (s *HomebrewService) ListItems(userId int) ([]models.Homebrew, error) {
+	entries, err := s.repo.GetVisible(userId)
+	if err != nil {
+		return nil, err
+	}
+	items := []models.Homebrew{}
+	for _, entry := range entries {
+		if entry.Kind == models.HomebrewItem {
+			items = append(items, entry)
+		}
+	}
+	return items, nil
+}
+
+func (s *HomebrewService) Get(id, userId int) (*models.Homebrew, error) {
+	return s.repo.Get(id, userId)
+}
+
+func hasDefinition(registry *character.Registry, kind models.HomebrewKind, name string) bool {
+	var ok bool
+	switch kind {
+	case models.HomebrewRace:
+		_, ok = registry.Race(character.RaceName(name))
+	case models.HomebrewSubrace:
+		_, ok = registry.Subrace(character.SubraceName(name))
+	case models.HomebrewBackground:
+		_, ok = registry.Background(character.BackgroundName(name))
+	case models.HomebrewClass:
+		_, ok = registry.Class(character.ClassName(name))
+	}
+	return ok
+}
+
+// validate checks the homebrew on its own, then that its name is free among the rules of every
+// ruleset version and the homebrew the user can see, and that it fits with those rules in at least
+// one version. Other users' private homebrew is left out, so it neither blocks a name nor gives
+// away that it exists.
+func (s *HomebrewService) validate(data *models.Homebrew, userId int) error {
+	if err := data.Validate(); err != nil {
+		return err
+	}
+	if data.Kind == models.HomebrewItem {
+		return nil
+	}
+
+	for _, base := range s.base {
+		if hasDefinition(base, data.Kind, data.Name) {
+			return fmt.Errorf("%w: %s", ErrHomebrewNameTaken, data.Name)
+		}
+	}
+	entries, err := s.repo.GetVisible(userId)
+	if err != nil {
+		return err
+	}
+	visible := []models.Homebrew{}
+	for _, entry := range entries {
+		if entry.ID == data.ID {
+			continue
+		}
+		if entry.Kind == data.Kind && entry.Name == data.Name {
+			return fmt.Errorf("%w: %s", ErrHomebrewNameTaken, data.Name)
+		}
+		visible = append(visible, entry)
+	}
+
+	pack, err := data.ToContentPack()
+	if err != nil {
+		return err
+	}
+	var validateErr error
+	for _, version := range character.RulesetVersions {
+		if validateErr = s.extend(version, firstByName(visible)).Extend(pack).Validate(); validateErr == nil {
+			return nil
+		}
+	}
+	return validateErr
+}
+
+func (s *HomebrewService) Create(data *models.Homebrew, userId int) (*models.Homebrew, error) {
+	data.ID = 0
+	if err := s.validate(data, userId); err != nil {
+		return nil, err
+	}
+	created, err := s.repo.Create(data, userId)
+	if err != nil {
+		return nil, err
+	}
+	return created, s.LoadRules()
+}
+
+func (s *HomebrewService) Update(data *models.Homebrew, id, userId int) (*models.Homebrew, error) {
+	data.ID = id
+	if err := s.validate(data, userId); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.Update(data, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	return updated, s.LoadRules()
+}
+
+func (s *HomebrewService) Delete(id, userId int) error {
+	if err := s.repo.Delete(id, userId); err != nil {
+		return err
+	}
+	return s.LoadRules()
+}
@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/database"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+	"dndcc/internal/services"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// newHomebrewService migrates a new database with two users, 1 and 2, and returns a homebrew
+// service on top of the SRD rules. The registries it loads homebrew into are put back afterwards.
+func newHomebrewService(t *testing.T) *services.HomebrewService {
+	// Migrations are read from the repository root.
+	t.Chdir("../..")
+	db, err := database.CreateDatabaseConnection(filepath.Join(t.TempDir(), "homebrew.db"))
+	if err != nil {
+		t.Fatalf("failed to create the database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec("INSERT INTO auth (id, username) VALUES (1, 'first'), (2, 'second');"); err != nil {
+		t.Fatalf("failed to create the users: %v", err)
+	}
+	base := map[character.RulesetVersion]*character.Registry{}
+	for _, version := range character.RulesetVersions {
+		base[version] = character.RulesFor(version)
+	}
+	t.Cleanup(func() {
+		for version, registry := range base {
+			character.UseRuleset(version, registry)
+			character.UseOwnerRulesets(version, nil)
+		}
+	})
+	return services.NewHomebrewService(repositories.NewHomebrewRepository(db), base)
+}
+
+func newHomebrewBackground(tool string, shared bool) *models.Homebrew {
+	return &models.Homebrew{
+		Kind:    models.HomebrewBackground,
+		Content: "name: Tidewatcher\nskills: [Perception, Survival]\ntools: [" + tool + "]\n",
+		Shared:  shared,
+	}
+}
+
+func TestHomebrewNamesAreScopedToTheUser(t *testing.T) {
+	service := newHomebrewService(t)
+	first, err := service.Create(newHomebrewBackground("Navigator's Tools", false), 1)
+	if err != nil {
+		t.Fatalf("unexpected error creating the first user's homebrew: %v", err)
+	}
+	// The first user's background is private, so the second user can use the name too, without
+	// learning that it is taken.
+	if _, err := service.Create(newHomebrewBackground("Cartographer's Tools", false), 2); err != nil {
+		t.Fatalf("got error %v creating a private name another user has; want none", err)
+	}
+	if _, err := service.Create(newHomebrewBackground("Navigator's Tools", false), 1); !errors.Is(err, services.ErrHomebrewNameTaken) {
+		t.Fatalf("got error %v reusing a name of the user's own homebrew; want %v", err, services.ErrHomebrewNameTaken)
+	}
+	if _, err := service.Update(newHomebrewBackground("Navigator's Tools", false), first.ID, 1); err != nil {
+		t.Fatalf("unexpected error saving homebrew under its own name: %v", err)
+	}
+
+	for userId, tool := range map[int]string{1: "Navigator's Tools", 2: "Cartographer's Tools"} {
+		rules, err := service.Rules(userId, character.Ruleset2014)
+		if err != nil {
+			t.Fatalf("unexpected error loading the rules of user %d: %v", userId, err)
+		}
+		definition, ok := rules.Background("Tidewatcher")
+		if !ok || !slices.Equal(definition.Tools, []string{tool}) {
+			t.Fatalf("got background %+v for user %d; want their own with %s", definition, userId, tool)
+		}
+	}
+
+	// Characters resolve through the registries of their owner, and the registry every character
+	// shares leaves private homebrew out.
+	if definition, ok := character.RulesFor(character.Ruleset2014).Background("Tidewatcher"); ok {
+		t.Fatalf("got background %+v in the shared rules; want private homebrew left out", definition)
+	}
+	for userId, tool := range map[int]string{1: "Navigator's Tools", 2: "Cartographer's Tools"} {
+		sheet := character.NewCharacter()
+		sheet.Background = character.Background{Name: "Tidewatcher"}
+		tools := sheet.SetOwner(userId).GetProficiencies(character.ProficiencyTool)
+		if len(tools) != 1 || tools[0].Name != tool {
+			t.Fatalf("got tools %v for a character of user %d; want their own %s", tools, userId, tool)
+		}
+	}
+
+	// Once the first user shares theirs, the second user keeps their own.
+	if _, err := service.Update(newHomebrewBackground("Navigator's Tools", true), first.ID, 1); err != nil {
+		t.Fatalf("unexpected error sharing homebrew: %v", err)
+	}
+	rules, err := service.Rules(2, character.Ruleset2014)
+	if err != nil {
+		t.Fatalf("unexpected error loading the rules of user 2: %v", err)
+	}
+	if definition, _ := rules.Background("Tidewatcher"); !slices.Equal(definition.Tools, []string{"Cartographer's Tools"}) {
+		t.Fatalf("got background %+v for user 2; want their own over the shared one", definition)
+	}
+	if _, err := service.Create(newHomebrewBackground("Navigator's Tools", false), 2); !errors.Is(err, services.ErrHomebrewNameTaken) {
+		t.Fatalf("got error %v reusing a name the user can see; want %v", err, services.ErrHomebrewNameTaken)
+	}
+	if definition, _ := character.RulesFor(character.Ruleset2014).Background("Tidewatcher"); !slices.Equal(definition.Tools, []string{"Navigator's Tools"}) {
+		t.Fatalf("got background %+v in the shared rules; want the one the first user shared", definition)
+	}
+	if definition, _ := character.RulesForOwner(character.Ruleset2014, 2).Background("Tidewatcher"); !slices.Equal(definition.Tools, []string{"Cartographer's Tools"}) {
+		t.Fatalf("got background %+v for the characters of user 2; want their own over the shared one", definition)
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+)
+
+type ItemService struct {
+	repo *repositories.ItemRepository
+}
+
+func NewItemService(repo *repositories.ItemRepository) *ItemService {
+	return &ItemService{repo: repo}
+}
+
+func (s *ItemService) Create(data *models.CharacterItem, characterId, userId int) (*models.CharacterItem, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	data.CharacterId = characterId
+	return s.repo.Create(data, userId)
+}
+
+func (s *ItemService) SetEquipped(id, characterId, userId int, equipped bool) error {
+	return s.repo.SetEquipped(id, characterId, userId, equipped)
+}
+
+func (s *ItemService) Delete(id, characterId, userId int) error {
+	return s.repo.Delete(id, characterId, userId)
+}
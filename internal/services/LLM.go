@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"dndcc/internal"
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrLLMRequest         = errors.New("the llm request failed")
+	ErrLLMEmptyResponse   = errors.New("the llm returned an empty response")
+	ErrLLMInvalidResponse = errors.New("the llm did not return valid output")
+	ErrLLMNotConfigured   = errors.New("no llm is configured")
+)
+
+// maxLLMResponseSize caps how much of a chat completion response is read.
+const maxLLMResponseSize = 1 << 20
+
+const bioSystemPrompt = `You write backstories for Dungeons & Dragons 5e player characters.
+Write a bio of two to four short paragraphs in plain text, without headings or markdown.
+Stay consistent with the character's race, class, background and ability scores, and build on any notes the player already wrote.`
+
+const personalitySystemPrompt = `You write personalities for Dungeons & Dragons 5e player characters.
+Reply with a single JSON object and nothing else, with these keys:
+"traits": an array of one or two personality traits,
+"ideal": what the character believes in,
+"bond": the person, place or cause the character is tied to,
+"flaw": the character's weakness or vice,
+"appearance": how the character looks, in a sentence or two,
+"alignment": one of %s.
+Keep each value to a sentence or two, written in the first person except the appearance.
+Stay consistent with the character, and pick or adapt entries from the background's tables when they are given.`
+
+const characterSystemPrompt = `You create Dungeons & Dragons 5e player characters at 1st level from a player's description.
+Reply with a single JSON object and nothing else, with these keys:
+"name": a name that suits the character,
+"race": the race,
+"subrace": the subrace, or "" when the race has none,
+"class": the class,
+"background": the background,
+"ability_scores": an object giving each of %s one of the standard array scores %s, using each score once,
+"ability_score_choices": an array of the abilities picked for the origin ability score increases,
+"class_skills": an array of exactly as many class skills as the class picks, none of them granted by the background,
+"draconic_ancestry": the dragon a Dragonborn descends from, or "" for other races,
+"bio": a backstory of one or two short paragraphs in plain text.
+Only use the names from the options given, spelled exactly as they are, and stay true to the description.`
+
+// jsonRepairPrompt asks the LLM to fix a reply that could not be parsed or failed validation.
+const jsonRepairPrompt = "That reply could not be used: %v. Reply again with only the corrected JSON object."
+
+// maxJSONAttempts is how many times the LLM is asked for structured output before giving up.
+const maxJSONAttempts = 3
+
+type ChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// LLMTask names the feature a chat request is for.
+type LLMTask string
+
+const (
+	LLMTaskBio         LLMTask = "bio"
+	LLMTaskPersonality LLMTask = "personality"
+	LLMTaskCharacter   LLMTask = "character"
+)
+
+// ChatRequest is what a feature asks of an LLM provider. JSON asks for a JSON object instead of
+// free text. Task, Character, Description and Rules are what the messages were written from, for
+// providers that don't read the messages, like the template provider.
+type ChatRequest struct {
+	Task        LLMTask
+	Messages    []ChatMessage
+	JSON        bool
+	Character   *models.Character
+	Description string
+	Rules       *character.Registry
+}
+
+// LLMProvider sends chat requests to a language model. Requests are cancelled with ctx.
+type LLMProvider interface {
+	// Complete returns the whole reply.
+	Complete(ctx context.Context, request ChatRequest) (string, error)
+	// Stream calls onToken with each piece of the reply as it is written, stopping with the error
+	// onToken returns.
+	Stream(ctx context.Context, request ChatRequest, onToken func(token string) error) error
+}
+
+// LLMService writes character content with the configured LLM provider. Without a provider the
+// LLM features are off and return ErrLLMNotConfigured.
+type LLMService struct {
+	config   *internal.LLMConfig
+	provider LLMProvider
+}
+
+func NewLLMService(config *internal.LLMConfig) *LLMService {
+	var provider LLMProvider
+	switch config.Provider {
+	case internal.LLMProviderOpenAI:
+		provider = newOpenAIProvider(config)
+	case internal.LLMProviderOllama:
+		provider = newOllamaProvider(config)
+	case internal.LLMProviderTemplate:
+		provider = newTemplateProvider()
+	}
+	return &LLMService{config: config, provider: provider}
+}
+
+// IsConfigured reports whether there is an LLM to talk to. Features that can do without one, like
+// personality generation, fall back to something else when there isn't.
+func (s *LLMService) IsConfigured() bool {
+	return s != nil && s.provider != nil
+}
+
+// Complete sends the request and returns the trimmed reply. The request is cancelled with ctx or
+// when the configured timeout runs out.
+func (s *LLMService) Complete(ctx context.Context, request ChatRequest) (string, error) {
+	if !s.IsConfigured() {
+		return "", ErrLLMNotConfigured
+	}
+	content, err := s.provider.Complete(ctx, request)
+	if err != nil {
+		return "", err
+	}
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", ErrLLMEmptyResponse
+	}
+	return content, nil
+}
+
+// Stream sends the request and calls onToken with each piece of the reply as the provider sends
+// it. It stops when ctx is cancelled, the configured timeout runs out or onToken returns an error,
+// which is passed back.
+func (s *LLMService) Stream(ctx context.Context, request ChatRequest, onToken func(token string) error) error {
+	if !s.IsConfigured() {
+		return ErrLLMNotConfigured
+	}
+	received := false
+	err := s.provider.Stream(ctx, request, func(token string) error {
+		received = true
+		return onToken(token)
+	})
+	if err != nil {
+		return err
+	}
+	if !received {
+		return ErrLLMEmptyResponse
+	}
+	return nil
+}
+
+// completeJSON asks the LLM for a JSON object, decodes it into a T and checks it with validate. A
+// reply that can't be decoded or fails validation is sent back with the error so the LLM can repair
+// it, up to maxJSONAttempts times, after which ErrLLMInvalidResponse is returned.
+func completeJSON[T any](ctx context.Context, s *LLMService, request ChatRequest, validate func(*T) error) (*T, error) {
+	request.JSON = true
+	request.Messages = slices.Clone(request.Messages)
+	var lastErr error
+	for range maxJSONAttempts {
+		content, err := s.Complete(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		output := new(T)
+		if err := json.Unmarshal([]byte(stripCodeFence(content)), output); err != nil {
+			lastErr = fmt.Errorf("invalid JSON: %v", err)
+		} else if err := validate(output); err != nil {
+			lastErr = err
+		} else {
+			return output, nil
+		}
+		request.Messages = append(request.Messages,
+			ChatMessage{Role: "assistant", Content: content},
+			ChatMessage{Role: "user", Content: fmt.Sprintf(jsonRepairPrompt, lastErr)},
+		)
+	}
+	return nil, fmt.Errorf("%w: %v", ErrLLMInvalidResponse, lastErr)
+}
+
+// stripCodeFence removes the markdown code fence some models wrap JSON replies in.
+func stripCodeFence(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "```") {
+		return content
+	}
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimPrefix(content, "json")
+	return strings.TrimSpace(strings.TrimSuffix(content, "```"))
+}
+
+// systemPrompt adds the configured AdditionalSystemPrompt to the prompt of a feature.
+func (s *LLMService) systemPrompt(prompt string) string {
+	if s.config == nil || s.config.AdditionalSystemPrompt == "" {
+		return prompt
+	}
+	return prompt + "\n\n" + s.config.AdditionalSystemPrompt
+}
+
+// GenerateBio drafts a bio for the character from what has been filled in on the form so far.
+func (s *LLMService) GenerateBio(ctx context.Context, data *models.Character) (string, error) {
+	return s.Complete(ctx, s.bioRequest(data))
+}
+
+// StreamBio drafts a bio like GenerateBio, passing it to onToken as it is written.
+func (s *LLMService) StreamBio(ctx context.Context, data *models.Character, onToken func(token string) error) error {
+	return s.Stream(ctx, s.bioRequest(data), onToken)
+}
+
+func (s *LLMService) bioRequest(data *models.Character) ChatRequest {
+	return ChatRequest{
+		Task: LLMTaskBio,
+		Messages: []ChatMessage{
+			{Role: "system", Content: s.systemPrompt(bioSystemPrompt)},
+			{Role: "user", Content: describeCharacter(data)},
+		},
+		Character: data,
+	}
+}
+
+// GeneratePersonality writes personality traits, an ideal, bond and flaw, an appearance and an
+// alignment for the character, using its background's tables as examples.
+func (s *LLMService) GeneratePersonality(ctx context.Context, data *models.Character) (character.Personality, error) {
+	alignments := make([]string, len(character.Alignments))
+	for i, alignment := range character.Alignments {
+		alignments[i] = strconv.Quote(string(alignment))
+	}
+	request := ChatRequest{
+		Task: LLMTaskPersonality,
+		Messages: []ChatMessage{
+			{Role: "system", Content: s.systemPrompt(fmt.Sprintf(personalitySystemPrompt, strings.Join(alignments, ", ")))},
+			{Role: "user", Content: describeCharacter(data) + describePersonalityTables(data)},
+		},
+		Character: data,
+	}
+	output, err := completeJSON(ctx, s, request, func(personality *character.Personality) error {
+		return personality.Validate()
+	})
+	if err != nil {
+		return character.Personality{}, err
+	}
+	return *output, nil
+}
+
+// GenerateCharacter drafts a level 1 character of the user from their description, picking from the
+// options in rules. The draft is checked against the rules before it is returned, and a draft that
+// breaks them is sent back to the LLM to repair.
+func (s *LLMService) GenerateCharacter(ctx context.Context, userId int, description string, rules *character.Registry, version character.RulesetVersion) (*models.Character, error) {
+	stats := make([]string, len(character.StatNames))
+	for i, stat := range character.StatNames {
+		stats[i] = strconv.Quote(string(stat))
+	}
+	scores := make([]string, len(character.StandardArray))
+	for i, score := range character.StandardArray {
+		scores[i] = strconv.Itoa(score)
+	}
+	request := ChatRequest{
+		Task: LLMTaskCharacter,
+		Messages: []ChatMessage{
+			{Role: "system", Content: s.systemPrompt(fmt.Sprintf(characterSystemPrompt, strings.Join(stats, ", "), strings.Join(scores, ", ")))},
+			{Role: "user", Content: fmt.Sprintf("Description: %s\n%s", description, describeCharacterOptions(rules, version))},
+		},
+		Character:   &models.Character{OwnerId: userId, Ruleset: string(version)},
+		Description: description,
+		Rules:       rules,
+	}
+	var data *models.Character
+	_, err := completeJSON(ctx, s, request, func(draft *characterDraft) error {
+		var err error
+		data, err = draft.toCharacter(rules, version, userId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// describeCharacterOptions lists the races, classes and backgrounds in rules with the choices each
+// of them comes with.
+func describeCharacterOptions(rules *character.Registry, version character.RulesetVersion) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\nRules: %s\n", version)
+
+	sb.WriteString("\nRaces:\n")
+	for _, name := range rules.RaceNames() {
+		fmt.Fprintf(&sb, "- %s", name)
+		if subraces := rules.RaceSubraceNames(name); len(subraces) > 0 {
+			fmt.Fprintf(&sb, " (subraces: %s)", joinNames(subraces))
+		}
+		if version == character.Ruleset2014 {
+			race := character.Race{Type: name, Ruleset: version}
+			if count := race.GetStatChoiceCount(); count > 0 {
+				fmt.Fprintf(&sb, ", %d different ability score choices", count)
+			}
+		}
+		sb.WriteString("\n")
+	}
+	if version == character.Ruleset2014 {
+		sb.WriteString("Races without ability score choices take an empty ability_score_choices.\n")
+	}
+
+	sb.WriteString("\nClasses:\n")
+	for _, name := range playerClassNames(rules) {
+		definition, _ := rules.Class(name)
+		choices := definition.GetSkillChoices()
+		fmt.Fprintf(&sb, "- %s: picks %d class skills from %s\n", name, choices.Count, joinNames(choices.Options))
+	}
+
+	sb.WriteString("\nBackgrounds:\n")
+	for _, name := range rules.BackgroundNames() {
+		definition, _ := rules.Background(name)
+		fmt.Fprintf(&sb, "- %s", name)
+		if len(definition.Skills) > 0 {
+			fmt.Fprintf(&sb, ": grants %s", joinNames(definition.Skills))
+		}
+		if version == character.Ruleset2024 {
+			abilities := "any ability"
+			if len(definition.Abilities) > 0 {
+				abilities = joinNames(definition.Abilities)
+			}
+			fmt.Fprintf(&sb, "; ability score choices from %s", abilities)
+		}
+		sb.WriteString("\n")
+	}
+	if version == character.Ruleset2024 {
+		sb.WriteString("The background gives 3 ability score choices, which can name the same ability twice.\n")
+	}
+
+	fmt.Fprintf(&sb, "\nDraconic ancestries: %s\n", joinNames(character.DraconicAncestries))
+	return sb.String()
+}
+
+// describePersonalityTables lists the personality tables of the character's background, if it has any.
+func describePersonalityTables(data *models.Character) string {
+	background := data.ToCharacterSheet().Background
+	tables, ok := background.GetPersonalityTables()
+	if !ok {
+		return ""
+	}
+	var sb strings.Builder
+	table := func(label string, entries []string) {
+		fmt.Fprintf(&sb, "\n%s %s:\n", data.Background, label)
+		for _, entry := range entries {
+			fmt.Fprintf(&sb, "- %s\n", entry)
+		}
+	}
+	ideals := make([]string, len(tables.Ideals))
+	for i, ideal := range tables.Ideals {
+		ideals[i] = fmt.Sprintf("%s (%s)", ideal.Text, ideal.Alignment)
+	}
+	table("personality traits", tables.Traits)
+	table("ideals", ideals)
+	table("bonds", tables.Bonds)
+	table("flaws", tables.Flaws)
+	return sb.String()
+}
+
+// describeCharacter lists the parts of the character the LLM is given as context, skipping what
+// hasn't been picked yet.
+func describeCharacter(data *models.Character) string {
+	sheet := data.ToCharacterSheet()
+	ruleset := sheet.GetRuleset()
+	var sb strings.Builder
+	line := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "%s: %s\n", label, value)
+		}
+	}
+
+	line("Name", data.Name)
+	line("Rules", string(ruleset.Version()))
+	line(ruleset.RaceLabel(), data.RaceType)
+	if data.SubraceType.String != string(character.SubraceNone) {
+		line(ruleset.SubraceLabel(), data.SubraceType.String)
+	}
+	if data.Class != "" {
+		line("Class", fmt.Sprintf("%s %d", data.Class, max(data.Level, 1)))
+	}
+	line("Background", data.Background)
+	scores := make([]string, 0, len(character.StatNames))
+	for _, stat := range character.StatNames {
+		if sheet.GetBaseScore(stat) > 0 {
+			scores = append(scores, fmt.Sprintf("%s %d", stat, sheet.GetEffectiveScore(stat)))
+		}
+	}
+	line("Ability scores", strings.Join(scores, ", "))
+	line("Player notes", strings.TrimSpace(data.Bio))
+	return sb.String()
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"dndcc/internal"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ollamaChatPath is appended to LLM_URL when it is the server's base URL.
+const ollamaChatPath = "/api/chat"
+
+type ollamaChatRequest struct {
+	Model    string        `json:"model"`
+	Messages []ChatMessage `json:"messages"`
+	// Stream is always sent, since Ollama streams unless told not to.
+	Stream bool   `json:"stream"`
+	Format string `json:"format,omitempty"`
+}
+
+// ollamaChatResponse is the whole reply, or one line of a streamed one.
+type ollamaChatResponse struct {
+	Message ChatMessage `json:"message"`
+	Done    bool        `json:"done"`
+	Error   *llmError   `json:"error"`
+}
+
+// ollamaProvider talks to the chat API of a local Ollama server.
+type ollamaProvider struct {
+	config *internal.LLMConfig
+	client *http.Client
+}
+
+func newOllamaProvider(config *internal.LLMConfig) *ollamaProvider {
+	return &ollamaProvider{config: config, client: &http.Client{Timeout: config.Timeout}}
+}
+
+// endpoint returns the chat URL, accepting either the server's base URL or the full endpoint.
+func (p *ollamaProvider) endpoint() string {
+	endpoint := strings.TrimSuffix(p.config.URL, "/")
+	if strings.HasSuffix(endpoint, ollamaChatPath) {
+		return endpoint
+	}
+	return endpoint + ollamaChatPath
+}
+
+func (p *ollamaProvider) request(request ChatRequest, stream bool) ollamaChatRequest {
+	output := ollamaChatRequest{Model: p.config.Model, Messages: request.Messages, Stream: stream}
+	if request.JSON {
+		output.Format = "json"
+	}
+	return output
+}
+
+func (p *ollamaProvider) Complete(ctx context.Context, request ChatRequest) (string, error) {
+	resp, err := postLLMRequest(ctx, p.client, p.endpoint(), p.config.ApiKey, p.request(request, false))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var output ollamaChatResponse
+	if err := decodeLLMResponse(resp.Body, &output); err != nil {
+		return "", err
+	}
+	if output.Error != nil && output.Error.Message != "" {
+		return "", fmt.Errorf("%w: %s", ErrLLMRequest, output.Error.Message)
+	}
+	return output.Message.Content, nil
+}
+
+func (p *ollamaProvider) Stream(ctx context.Context, request ChatRequest, onToken func(token string) error) error {
+	resp, err := postLLMRequest(ctx, p.client, p.endpoint(), p.config.ApiKey, p.request(request, true))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLLMResponseSize)
+	for scanner.Scan() {
+		// Each line is a JSON object with a piece of the reply, until one that is done.
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var chunk ollamaChatResponse
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return fmt.Errorf("%w: failed to parse a streamed chunk: %v", ErrLLMRequest, err)
+		}
+		if chunk.Error != nil && chunk.Error.Message != "" {
+			return fmt.Errorf("%w: %s", ErrLLMRequest, chunk.Error.Message)
+		}
+		if chunk.Message.Content != "" {
+			if err := onToken(chunk.Message.Content); err != nil {
+				return err
+			}
+		}
+		if chunk.Done {
+			break
+		}
+	}
+	return scanError(ctx, scanner.Err())
+}
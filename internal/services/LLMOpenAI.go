@@ -0,0 +1,215 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"dndcc/internal"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// chatCompletionsPath is appended to LLM_URL when it is the API's base URL.
+const chatCompletionsPath = "/chat/completions"
+
+// streamDone is the data of the event that ends a streamed chat completion.
+const streamDone = "[DONE]"
+
+type chatCompletionRequest struct {
+	Model          string          `json:"model,omitempty"`
+	Messages       []ChatMessage   `json:"messages"`
+	Stream         bool            `json:"stream,omitempty"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+}
+
+// responseFormat asks the API for a JSON object instead of free text.
+type responseFormat struct {
+	Type string `json:"type"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message ChatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// chatCompletionChunk is one server-sent event of a streamed chat completion.
+type chatCompletionChunk struct {
+	Choices []struct {
+		Delta ChatMessage `json:"delta"`
+	} `json:"choices"`
+	Error *llmError `json:"error"`
+}
+
+// llmError is the error an API sends back in place of a reply. OpenAI-compatible APIs send an
+// object with a message and Ollama sends the message by itself.
+type llmError struct {
+	Message string
+}
+
+func (e *llmError) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.Message); err == nil {
+		return nil
+	}
+	var output struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return err
+	}
+	e.Message = output.Message
+	return nil
+}
+
+// openAIProvider talks to an OpenAI-compatible chat completions API.
+type openAIProvider struct {
+	config *internal.LLMConfig
+	client *http.Client
+}
+
+func newOpenAIProvider(config *internal.LLMConfig) *openAIProvider {
+	return &openAIProvider{config: config, client: &http.Client{Timeout: config.Timeout}}
+}
+
+// endpoint returns the chat completions URL, accepting either the API's base URL or the full endpoint.
+func (p *openAIProvider) endpoint() string {
+	endpoint := strings.TrimSuffix(p.config.URL, "/")
+	if strings.HasSuffix(endpoint, chatCompletionsPath) {
+		return endpoint
+	}
+	return endpoint + chatCompletionsPath
+}
+
+func (p *openAIProvider) request(request ChatRequest, stream bool) chatCompletionRequest {
+	output := chatCompletionRequest{Model: p.config.Model, Messages: request.Messages, Stream: stream}
+	if request.JSON {
+		output.ResponseFormat = &responseFormat{Type: "json_object"}
+	}
+	return output
+}
+
+func (p *openAIProvider) Complete(ctx context.Context, request ChatRequest) (string, error) {
+	resp, err := postLLMRequest(ctx, p.client, p.endpoint(), p.config.ApiKey, p.request(request, false))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var output chatCompletionResponse
+	if err := decodeLLMResponse(resp.Body, &output); err != nil {
+		return "", err
+	}
+	if len(output.Choices) == 0 {
+		return "", ErrLLMEmptyResponse
+	}
+	return output.Choices[0].Message.Content, nil
+}
+
+func (p *openAIProvider) Stream(ctx context.Context, request ChatRequest, onToken func(token string) error) error {
+	resp, err := postLLMRequest(ctx, p.client, p.endpoint(), p.config.ApiKey, p.request(request, true))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLLMResponseSize)
+	for scanner.Scan() {
+		// Each event is a "data:" line with a chunk of the reply, until a final "data: [DONE]".
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == streamDone {
+			break
+		}
+		var chunk chatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("%w: failed to parse a streamed chunk: %v", ErrLLMRequest, err)
+		}
+		if chunk.Error != nil && chunk.Error.Message != "" {
+			return fmt.Errorf("%w: %s", ErrLLMRequest, chunk.Error.Message)
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		if err := onToken(chunk.Choices[0].Delta.Content); err != nil {
+			return err
+		}
+	}
+	return scanError(ctx, scanner.Err())
+}
+
+// postLLMRequest posts body as JSON to endpoint, returning the response when the API accepted it.
+// The caller closes the response body.
+func postLLMRequest(ctx context.Context, client *http.Client, endpoint, apiKey string, body any) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the llm request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the llm request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		var output struct {
+			Error *llmError `json:"error"`
+		}
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxLLMResponseSize))
+		if err := json.Unmarshal(respBody, &output); err == nil && output.Error != nil && output.Error.Message != "" {
+			return nil, fmt.Errorf("%w: %s: %s", ErrLLMRequest, resp.Status, output.Error.Message)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrLLMRequest, resp.Status)
+	}
+	return resp, nil
+}
+
+// decodeLLMResponse reads a whole JSON response into output.
+func decodeLLMResponse(body io.Reader, output any) error {
+	respBody, err := io.ReadAll(io.LimitReader(body, maxLLMResponseSize))
+	if err != nil {
+		return fmt.Errorf("%w: failed to read the response: %v", ErrLLMRequest, err)
+	}
+	if err := json.Unmarshal(respBody, output); err != nil {
+		return fmt.Errorf("%w: failed to parse the response: %v", ErrLLMRequest, err)
+	}
+	return nil
+}
+
+// scanError turns the error that ended reading a stream into the one passed back, which is the
+// context's when it was cancelled.
+func scanError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return requestError(err)
+}
+
+// requestError wraps a failed request in ErrLLMRequest. The URL is left out of the error since it
+// is shown to users.
+func requestError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	return fmt.Errorf("%w: %v", ErrLLMRequest, err)
+}
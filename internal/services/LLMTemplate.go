@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+var templateBio = template.Must(template.New("bio").Parse(
+	`{{.Name}} is {{.Who}}{{with .Background}}, who grew up as {{.}}{{end}}.
+{{- with .Best}} Their {{.}} has carried them through more trouble than they care to admit{{with $.Worst}}, though their {{.}} has let them down just as often{{end}}.{{end}}
+{{- with .Notes}}
+
+{{.}}{{end}}
+
+{{.Name}} has taken up the adventuring life to make a name for themself, and the road ahead is still unwritten.`))
+
+// templateStrengths describe a character by their best and worst ability scores.
+var templateStrengths = map[character.StatName]string{
+	character.StatStrength:     "strength",
+	character.StatDexterity:    "agility",
+	character.StatConstitution: "toughness",
+	character.StatIntelligence: "wit",
+	character.StatWisdom:       "intuition",
+	character.StatCharisma:     "charm",
+}
+
+// templatePersonality is written for a background without personality tables.
+var templatePersonality = character.Personality{
+	Traits:    []string{"I keep my word, even when it costs me.", "I am always the first to volunteer."},
+	Ideal:     "Freedom. Everyone should be free to choose their own path.",
+	Bond:      "I would do anything for the people I travel with.",
+	Flaw:      "I am slow to trust strangers.",
+	Alignment: character.AlignmentNeutral,
+}
+
+// templateNames are given to characters created from a description, picked by its length.
+var templateNames = []string{"Alden", "Brenna", "Corin", "Dagny", "Emrys", "Fenna", "Garrick", "Hesper"}
+
+// templatePriorities orders the ability scores a created character puts its best scores in, after
+// the one its class relies on most.
+var templatePriorities = []character.StatName{
+	character.StatConstitution,
+	character.StatDexterity,
+	character.StatWisdom,
+	character.StatStrength,
+	character.StatIntelligence,
+	character.StatCharisma,
+}
+
+// templateProvider writes from fixed templates filled in with the character, without an LLM. The
+// same character always gets the same reply, which makes it useful offline and in tests.
+type templateProvider struct{}
+
+func newTemplateProvider() *templateProvider {
+	return &templateProvider{}
+}
+
+func (p *templateProvider) Complete(ctx context.Context, request ChatRequest) (string, error) {
+	data := request.Character
+	if data == nil {
+		data = &models.Character{}
+	}
+	switch request.Task {
+	case LLMTaskBio:
+		return p.bio(data)
+	case LLMTaskPersonality:
+		return p.personality(data)
+	case LLMTaskCharacter:
+		return p.character(request.Description, request.Rules, data)
+	}
+	return "", fmt.Errorf("%w: the template provider has no template for %s", ErrLLMRequest, request.Task)
+}
+
+// Stream sends the templated reply a word at a time.
+func (p *templateProvider) Stream(ctx context.Context, request ChatRequest, onToken func(token string) error) error {
+	content, err := p.Complete(ctx, request)
+	if err != nil {
+		return err
+	}
+	for _, word := range strings.SplitAfter(content, " ") {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := onToken(word); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *templateProvider) bio(data *models.Character) (string, error) {
+	output := struct {
+		Name, Who, Background, Best, Worst, Notes string
+	}{
+		Name:  data.Name,
+		Who:   describeWho(data),
+		Notes: strings.TrimSpace(data.Bio),
+	}
+	if output.Name == "" {
+		output.Name = "This adventurer"
+	}
+	if data.Background != "" {
+		output.Background = withArticle(data.Background)
+	}
+	best, worst := bestAndWorstStats(data)
+	output.Best, output.Worst = templateStrengths[best], templateStrengths[worst]
+
+	var sb strings.Builder
+	if err := templateBio.Execute(&sb, output); err != nil {
+		return "", fmt.Errorf("failed to render the bio template: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// personality takes the first entries of the background's tables, with the first alignment the
+// ideal allows.
+func (p *templateProvider) personality(data *models.Character) (string, error) {
+	personality := templatePersonality
+	background := data.ToCharacterSheet().Background
+	if tables, ok := background.GetPersonalityTables(); ok {
+		personality.Traits = tables.Traits[:character.PersonalityTraitCount]
+		personality.Ideal = tables.Ideals[0].Text
+		personality.Bond = tables.Bonds[0]
+		personality.Flaw = tables.Flaws[0]
+		for _, alignment := range character.Alignments {
+			if tables.Ideals[0].Alignment.Allows(alignment) {
+				personality.Alignment = alignment
+				break
+			}
+		}
+	}
+	clothes := "a traveller"
+	if data.Background != "" {
+		clothes = withArticle(data.Background)
+	}
+	who := describeWho(data)
+	personality.Appearance = fmt.Sprintf("%s%s, dressed in the worn clothes of %s.", strings.ToUpper(who[:1]), who[1:], clothes)
+
+	output, err := json.Marshal(map[string]any{
+		"traits":     personality.Traits,
+		"ideal":      personality.Ideal,
+		"bond":       personality.Bond,
+		"flaw":       personality.Flaw,
+		"appearance": personality.Appearance,
+		"alignment":  personality.Alignment,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the personality template: %w", err)
+	}
+	return string(output), nil
+}
+
+// character picks the race, class and background the description names, or the first of each
+// when it doesn't name one, and makes the choices they come with in a fixed order.
+func (p *templateProvider) character(description string, rules *character.Registry, data *models.Character) (string, error) {
+	if rules == nil {
+		return "", fmt.Errorf("%w: no rules to create the character from", ErrLLMRequest)
+	}
+	named := strings.ToLower(description)
+	race := namedIn(named, rules.RaceNames())
+	class := namedIn(named, playerClassNames(rules))
+	background := namedIn(named, rules.BackgroundNames())
+	subrace := namedIn(named, rules.RaceSubraceNames(race))
+
+	draft := &models.Character{
+		Name:        templateNames[len(description)%len(templateNames)],
+		RaceType:    string(race),
+		SubraceType: sql.NullString{String: string(subrace), Valid: subrace != ""},
+		Class:       string(class),
+		Background:  string(background),
+		Ruleset:     data.Ruleset,
+		Bio:         description,
+	}
+	if race == character.RaceDragonborn {
+		draft.DraconicAncestry = string(character.DraconicAncestries[0])
+	}
+
+	priorities := templatePriorities
+	if definition, ok := rules.Class(class); ok {
+		if definition.SpellcastingAbility != "" {
+			priorities = append([]character.StatName{definition.SpellcastingAbility}, priorities...)
+		} else if len(definition.SavingThrows) > 0 {
+			priorities = append([]character.StatName{definition.SavingThrows[0]}, priorities...)
+		}
+	}
+	scores := map[string]int{}
+	for _, stat := range priorities {
+		if _, ok := scores[string(stat)]; !ok {
+			scores[string(stat)] = character.StandardArray[len(scores)]
+		}
+	}
+	sheet := draft.ToCharacterSheet()
+	options := sheet.GetRuleset().GetOriginStatOptions(sheet)
+	choices := []string{}
+	for _, stat := range priorities {
+		if len(choices) < sheet.GetRuleset().GetOriginStatChoiceCount(sheet) && slices.Contains(options, stat) && !slices.Contains(choices, string(stat)) {
+			choices = append(choices, string(stat))
+		}
+	}
+	classDefinition, _ := rules.Class(class)
+	skillChoices := classDefinition.GetSkillChoices()
+	granted := sheet.Background.GetProficiencies()
+	skills := []string{}
+	for _, skill := range skillChoices.Options {
+		if len(skills) < skillChoices.Count && !slices.Contains(granted, skill) {
+			skills = append(skills, string(skill))
+		}
+	}
+
+	// The bio is written from the finished character, with the description as its notes.
+	draft.Strength = scores[string(character.StatStrength)]
+	draft.Dexterity = scores[string(character.StatDexterity)]
+	draft.Constitution = scores[string(character.StatConstitution)]
+	draft.Intelligence = scores[string(character.StatIntelligence)]
+	draft.Wisdom = scores[string(character.StatWisdom)]
+	draft.Charisma = scores[string(character.StatCharisma)]
+	draft.RaceStatChoices = choices
+	bio, err := p.bio(draft)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := json.Marshal(characterDraft{
+		Name:                draft.Name,
+		Race:                string(race),
+		Subrace:             string(subrace),
+		Class:               string(class),
+		Background:          string(background),
+		AbilityScores:       scores,
+		AbilityScoreChoices: choices,
+		ClassSkills:         skills,
+		DraconicAncestry:    draft.DraconicAncestry,
+		Bio:                 bio,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the character template: %w", err)
+	}
+	return string(output), nil
+}
+
+// namedIn returns the first name the lowercase description mentions, or failing that the first
+// name whose first four letters it mentions, so "dwarven" finds Dwarf. Without either it returns
+// the first name, or "" when there are none.
+func namedIn[T ~string](description string, names []T) T {
+	for _, name := range names {
+		if strings.Contains(description, strings.ToLower(string(name))) {
+			return name
+		}
+	}
+	for _, name := range names {
+		if len(name) > 4 && strings.Contains(description, strings.ToLower(string(name[:4]))) {
+			return name
+		}
+	}
+	if len(names) == 0 {
+		var empty T
+		return empty
+	}
+	return names[0]
+}
+
+// describeWho names the character's race, or subrace when there is one, and class, like "a Hill
+// Dwarf Cleric".
+func describeWho(data *models.Character) string {
+	parts := []string{}
+	if data.SubraceType.String != "" && data.SubraceType.String != string(character.SubraceNone) {
+		parts = append(parts, data.SubraceType.String)
+	} else if data.RaceType != "" {
+		parts = append(parts, data.RaceType)
+	}
+	if data.Class != "" {
+		parts = append(parts, data.Class)
+	}
+	if len(parts) == 0 {
+		return "an adventurer"
+	}
+	return withArticle(strings.Join(parts, " "))
+}
+
+// bestAndWorstStats returns the character's highest and lowest effective ability scores. Both are
+// empty when the scores haven't been picked or are all the same.
+func bestAndWorstStats(data *models.Character) (character.StatName, character.StatName) {
+	sheet := data.ToCharacterSheet()
+	var best, worst character.StatName
+	for _, stat := range character.StatNames {
+		if sheet.GetBaseScore(stat) == 0 {
+			return "", ""
+		}
+		if best == "" || sheet.GetEffectiveScore(stat) > sheet.GetEffectiveScore(best) {
+			best = stat
+		}
+		if worst == "" || sheet.GetEffectiveScore(stat) < sheet.GetEffectiveScore(worst) {
+			worst = stat
+		}
+	}
+	if sheet.GetEffectiveScore(best) == sheet.GetEffectiveScore(worst) {
+		return "", ""
+	}
+	return best, worst
+}
+
+func withArticle(noun string) string {
+	if strings.ContainsRune("AEIOUaeiou", rune(noun[0])) {
+		return "an " + noun
+	}
+	return "a " + noun
+}
@@ -0,0 +1,460 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"dndcc/internal"
+	"dndcc/internal/character"
+	"dndcc/internal/dice"
+	"dndcc/internal/models"
+	"dndcc/internal/services"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeLLM serves chat completions with reply, or each of replies in turn, recording the last
+// request it got.
+type fakeLLM struct {
+	status  int
+	reply   string
+	replies []string
+	calls   int
+	delay   time.Duration
+	auth    string
+	path    string
+	request struct {
+		Model          string                 `json:"model"`
+		Messages       []services.ChatMessage `json:"messages"`
+		Stream         bool                   `json:"stream"`
+		ResponseFormat *struct {
+			Type string `json:"type"`
+		} `json:"response_format"`
+	}
+	// hang keeps a stream open after its tokens until the client goes away, which closes cancelled.
+	hang      bool
+	cancelled chan struct{}
+}
+
+func (f *fakeLLM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.auth = r.Header.Get("Authorization")
+	f.path = r.URL.Path
+	f.request.ResponseFormat = nil
+	json.NewDecoder(r.Body).Decode(&f.request)
+	reply := f.reply
+	if len(f.replies) > 0 {
+		reply = f.replies[min(f.calls, len(f.replies)-1)]
+	}
+	f.calls++
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if f.status != 0 && f.status != http.StatusOK {
+		w.WriteHeader(f.status)
+		w.Write([]byte(`{"error": {"message": "model overloaded"}}`))
+		return
+	}
+	if f.request.Stream {
+		f.stream(w, r)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": reply}}},
+	})
+}
+
+// stream sends the reply a word at a time as server-sent events.
+func (f *fakeLLM) stream(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, word := range strings.SplitAfter(f.reply, " ") {
+		chunk, _ := json.Marshal(map[string]any{"choices": []map[string]any{{"delta": map[string]string{"content": word}}}})
+		fmt.Fprintf(w, "data: %s\n\n", chunk)
+		w.(http.Flusher).Flush()
+	}
+	if f.hang {
+		<-r.Context().Done()
+		close(f.cancelled)
+		return
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+}
+
+func newLLMService(t *testing.T, fake *fakeLLM, timeout time.Duration) *services.LLMService {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return services.NewLLMService(&internal.LLMConfig{
+		Provider:               internal.LLMProviderOpenAI,
+		URL:                    server.URL + "/v1",
+		ApiKey:                 "secret",
+		Model:                  "test-model",
+		AdditionalSystemPrompt: "Keep it family friendly.",
+		Timeout:                timeout,
+	})
+}
+
+func newBioCharacter() *models.Character {
+	return &models.Character{
+		Name:         "Tordek",
+		Bio:          "Used to be a sailor.",
+		Background:   "Acolyte",
+		Class:        "Cleric",
+		Level:        1,
+		RaceType:     "Dwarf",
+		SubraceType:  sql.NullString{String: "Hill Dwarf", Valid: true},
+		Strength:     14,
+		Dexterity:    8,
+		Constitution: 15,
+		Intelligence: 10,
+		Wisdom:       13,
+		Charisma:     12,
+		Ruleset:      "2014",
+	}
+}
+
+func TestLLMGenerateBio(t *testing.T) {
+	fake := &fakeLLM{reply: "  Tordek sailed the Sword Coast.  "}
+	bio, err := newLLMService(t, fake, time.Second).GenerateBio(context.Background(), newBioCharacter())
+	if err != nil {
+		t.Fatalf("unexpected error generating a bio: %v", err)
+	}
+	if bio != "Tordek sailed the Sword Coast." {
+		t.Fatalf("got bio %q; want the trimmed reply", bio)
+	}
+	if fake.path != "/v1/chat/completions" || fake.auth != "Bearer secret" || fake.request.Model != "test-model" {
+		t.Fatalf("got path %s, auth %s and model %s; want the chat completions endpoint with the configured key and model", fake.path, fake.auth, fake.request.Model)
+	}
+	if len(fake.request.Messages) != 2 || !strings.Contains(fake.request.Messages[0].Content, "Keep it family friendly.") {
+		t.Fatalf("got messages %v; want a system prompt with the additional prompt and a user message", fake.request.Messages)
+	}
+	for _, want := range []string{"Race: Dwarf", "Subrace: Hill Dwarf", "Class: Cleric 1", "Background: Acolyte", "Constitution 17", "Used to be a sailor."} {
+		if !strings.Contains(fake.request.Messages[1].Content, want) {
+			t.Fatalf("got user message %q; want it to contain %q", fake.request.Messages[1].Content, want)
+		}
+	}
+}
+
+func TestLLMErrors(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Fake      *fakeLLM
+		ExpectErr error
+	}{
+		{"server error", &fakeLLM{status: http.StatusServiceUnavailable}, services.ErrLLMRequest},
+		{"empty reply", &fakeLLM{reply: " "}, services.ErrLLMEmptyResponse},
+		{"timeout", &fakeLLM{reply: "late", delay: time.Second}, services.ErrLLMRequest},
+	}
+	for _, test := range tests {
+		_, err := newLLMService(t, test.Fake, 50*time.Millisecond).GenerateBio(context.Background(), newBioCharacter())
+		if !errors.Is(err, test.ExpectErr) {
+			t.Fatalf("%s: got error %v; want %v", test.Name, err, test.ExpectErr)
+		}
+		if test.Fake.status != 0 && !strings.Contains(err.Error(), "model overloaded") {
+			t.Fatalf("%s: got error %v; want the API's error message", test.Name, err)
+		}
+	}
+}
+
+func TestLLMStreamBio(t *testing.T) {
+	fake := &fakeLLM{reply: "Tordek sailed the Sword Coast."}
+	var tokens []string
+	err := newLLMService(t, fake, time.Second).StreamBio(context.Background(), newBioCharacter(), func(token string) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error streaming a bio: %v", err)
+	}
+	if !fake.request.Stream || len(tokens) != 5 || strings.Join(tokens, "") != fake.reply {
+		t.Fatalf("got tokens %q; want the reply a word at a time", tokens)
+	}
+}
+
+func TestLLMStreamCancel(t *testing.T) {
+	fake := &fakeLLM{reply: "Tordek sailed", hang: true, cancelled: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := newLLMService(t, fake, 5*time.Second).StreamBio(ctx, newBioCharacter(), func(token string) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v; want context.Canceled", err)
+	}
+	select {
+	case <-fake.cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("the llm request was still open after cancelling the stream")
+	}
+}
+
+const personalityReply = `{
+	"traits": ["I hum sea shanties during prayers."],
+	"ideal": "Charity. I share what little I have.",
+	"bond": "The ship that carried me to the temple.",
+	"flaw": "I drink more than a priest should.",
+	"appearance": "A stocky dwarf with a braided beard and tar-stained hands.",
+	"alignment": "Neutral Good"
+}`
+
+func TestLLMGeneratePersonality(t *testing.T) {
+	// The first reply isn't JSON and the second has an alignment that doesn't exist, so the service
+	// asks twice for a repair before it gets a personality it can use.
+	fake := &fakeLLM{replies: []string{
+		"Tordek is a kindly soul.",
+		`{"traits": ["Grumpy."], "ideal": "Faith.", "bond": "The sea.", "flaw": "Greed.", "appearance": "Short.", "alignment": "Grumpy Good"}`,
+		"```json\n" + personalityReply + "\n```",
+	}}
+	personality, err := newLLMService(t, fake, time.Second).GeneratePersonality(context.Background(), newBioCharacter())
+	if err != nil {
+		t.Fatalf("unexpected error generating a personality: %v", err)
+	}
+	if fake.calls != 3 || personality.Alignment != character.AlignmentNeutralGood || len(personality.Traits) != 1 {
+		t.Fatalf("got personality %+v after %d calls; want the third reply", personality, fake.calls)
+	}
+	if fake.request.ResponseFormat == nil || fake.request.ResponseFormat.Type != "json_object" {
+		t.Fatalf("got response format %v; want json_object", fake.request.ResponseFormat)
+	}
+	messages := fake.request.Messages
+	if len(messages) != 6 || !strings.Contains(messages[5].Content, "alignment is invalid") {
+		t.Fatalf("got messages %v; want both failed replies sent back with their errors", messages)
+	}
+	for _, want := range []string{"Background: Acolyte", "Acolyte personality traits:", "(Lawful)"} {
+		if !strings.Contains(messages[1].Content, want) {
+			t.Fatalf("got user message %q; want it to contain %q", messages[1].Content, want)
+		}
+	}
+
+	fake = &fakeLLM{reply: "not json"}
+	_, err = newLLMService(t, fake, time.Second).GeneratePersonality(context.Background(), newBioCharacter())
+	if !errors.Is(err, services.ErrLLMInvalidResponse) || fake.calls != 3 {
+		t.Fatalf("got error %v after %d calls; want %v after 3", err, fake.calls, services.ErrLLMInvalidResponse)
+	}
+}
+
+func TestLLMGenerateCharacter(t *testing.T) {
+	// The first reply picks Religion, which the Acolyte background already grants, so the service
+	// asks for a repair before it gets a character that fits the rules.
+	fake := &fakeLLM{replies: []string{
+		`{"name": "Tordek", "race": "Dwarf", "subrace": "", "class": "Cleric", "background": "Acolyte",
+			"ability_scores": {"Strength": 14, "Dexterity": 8, "Constitution": 13, "Intelligence": 10, "Wisdom": 15, "Charisma": 12},
+			"ability_score_choices": ["Wisdom", "Wisdom", "Intelligence"], "class_skills": ["Religion", "Medicine"], "bio": "Once a sailor."}`,
+		`{"name": "Tordek", "race": "dwarf", "subrace": "", "class": "cleric", "background": "acolyte",
+			"ability_scores": {"strength": 14, "dexterity": 8, "constitution": 13, "intelligence": 10, "wisdom": 15, "charisma": 12},
+			"ability_score_choices": ["Wisdom", "Wisdom", "Intelligence"], "class_skills": ["History", "Medicine"],
+			"draconic_ancestry": "Red", "bio": "Once a sailor."}`,
+	}}
+	rules := character.RulesFor(character.Ruleset2024)
+	data, err := newLLMService(t, fake, time.Second).GenerateCharacter(context.Background(), 1, "a grumpy dwarven cleric who used to be a sailor", rules, character.Ruleset2024)
+	if err != nil {
+		t.Fatalf("unexpected error generating a character: %v", err)
+	}
+	if fake.calls != 2 {
+		t.Fatalf("got %d calls; want the second reply", fake.calls)
+	}
+	if data.RaceType != "Dwarf" || data.SubraceType.String != string(character.SubraceNone) || data.Class != "Cleric" || data.Background != "Acolyte" {
+		t.Fatalf("got %s %s %s %s; want a Dwarf Cleric with the Acolyte background", data.RaceType, data.SubraceType.String, data.Class, data.Background)
+	}
+	if data.Level != 1 || data.Wisdom != 15 || data.Ruleset != string(character.Ruleset2024) || data.DraconicAncestry != "" {
+		t.Fatalf("got character %+v; want a level 1 2024 character with Wisdom 15 and no ancestry", data)
+	}
+	if skills := data.GetClassSkills(); !slices.Equal(skills, []string{"History", "Medicine"}) {
+		t.Fatalf("got class skills %v; want History and Medicine", skills)
+	}
+	messages := fake.request.Messages
+	if len(messages) != 4 || !strings.Contains(messages[3].Content, "Religion") {
+		t.Fatalf("got messages %v; want the failed reply sent back with its error", messages)
+	}
+	for _, want := range []string{"Description: a grumpy dwarven cleric who used to be a sailor", "Cleric: picks 2 class skills", "Acolyte: grants Insight, Religion"} {
+		if !strings.Contains(messages[1].Content, want) {
+			t.Fatalf("got user message %q; want it to contain %q", messages[1].Content, want)
+		}
+	}
+
+	fake = &fakeLLM{reply: `{"name": "Tordek", "race": "Dwarf", "class": "Sailor", "background": "Acolyte"}`}
+	_, err = newLLMService(t, fake, time.Second).GenerateCharacter(context.Background(), 1, "a sailor", rules, character.Ruleset2024)
+	if !errors.Is(err, services.ErrLLMInvalidResponse) || fake.calls != 3 {
+		t.Fatalf("got error %v after %d calls; want %v after 3", err, fake.calls, services.ErrLLMInvalidResponse)
+	}
+}
+
+func TestPersonalityServiceFallback(t *testing.T) {
+	service := services.NewPersonalityService(services.NewLLMService(&internal.LLMConfig{}), dice.NewRoller(nil))
+	data := newBioCharacter()
+	data.Appearance = "A stocky dwarf."
+	personality, err := service.Generate(context.Background(), data)
+	if err != nil {
+		t.Fatalf("unexpected error rolling a personality: %v", err)
+	}
+	tables, _ := data.ToCharacterSheet().Background.GetPersonalityTables()
+	if !slices.Contains(tables.Bonds, personality.Bond) || personality.Appearance != data.Appearance {
+		t.Fatalf("got personality %+v; want one rolled on the Acolyte tables that keeps the appearance", personality)
+	}
+	if err := personality.Validate(); err != nil {
+		t.Fatalf("unexpected error validating a rolled personality: %v", err)
+	}
+
+	data.Background = "Lighthouse Keeper"
+	if _, err := service.Generate(context.Background(), data); !errors.Is(err, character.ErrNoPersonalityTables) {
+		t.Fatalf("got error %v for a custom background; want %v", err, character.ErrNoPersonalityTables)
+	}
+}
+
+// fakeOllama serves Ollama's chat API with reply, streamed a word a line when asked.
+type fakeOllama struct {
+	reply   string
+	path    string
+	request struct {
+		Model  string `json:"model"`
+		Stream bool   `json:"stream"`
+		Format string `json:"format"`
+	}
+}
+
+func (f *fakeOllama) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.path = r.URL.Path
+	json.NewDecoder(r.Body).Decode(&f.request)
+	if f.request.Model != "llama3" {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "model not found"}`))
+		return
+	}
+	encoder := json.NewEncoder(w)
+	if !f.request.Stream {
+		encoder.Encode(map[string]any{"message": map[string]string{"role": "assistant", "content": f.reply}, "done": true})
+		return
+	}
+	for _, word := range strings.SplitAfter(f.reply, " ") {
+		encoder.Encode(map[string]any{"message": map[string]string{"role": "assistant", "content": word}, "done": false})
+		w.(http.Flusher).Flush()
+	}
+	encoder.Encode(map[string]any{"message": map[string]string{"role": "assistant", "content": ""}, "done": true})
+}
+
+func newOllamaService(t *testing.T, fake *fakeOllama, model string) *services.LLMService {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return services.NewLLMService(&internal.LLMConfig{
+		Provider: internal.LLMProviderOllama,
+		URL:      server.URL,
+		Model:    model,
+		Timeout:  time.Second,
+	})
+}
+
+func TestOllamaProvider(t *testing.T) {
+	fake := &fakeOllama{reply: "Tordek sailed the Sword Coast."}
+	bio, err := newOllamaService(t, fake, "llama3").GenerateBio(context.Background(), newBioCharacter())
+	if err != nil || bio != fake.reply {
+		t.Fatalf("got bio %q and error %v; want the reply", bio, err)
+	}
+	if fake.path != "/api/chat" || fake.request.Stream {
+		t.Fatalf("got path %s and stream %t; want an unstreamed request to /api/chat", fake.path, fake.request.Stream)
+	}
+
+	var tokens []string
+	err = newOllamaService(t, fake, "llama3").StreamBio(context.Background(), newBioCharacter(), func(token string) error {
+		tokens = append(tokens, token)
+		return nil
+	})
+	if err != nil || len(tokens) != 5 || strings.Join(tokens, "") != fake.reply {
+		t.Fatalf("got tokens %q and error %v; want the reply a word at a time", tokens, err)
+	}
+
+	fake.reply = personalityReply
+	if _, err := newOllamaService(t, fake, "llama3").GeneratePersonality(context.Background(), newBioCharacter()); err != nil {
+		t.Fatalf("unexpected error generating a personality: %v", err)
+	}
+	if fake.request.Format != "json" {
+		t.Fatalf("got format %q; want json", fake.request.Format)
+	}
+
+	_, err = newOllamaService(t, fake, "mistral").GenerateBio(context.Background(), newBioCharacter())
+	if !errors.Is(err, services.ErrLLMRequest) || !strings.Contains(err.Error(), "model not found") {
+		t.Fatalf("got error %v; want %v with the server's message", err, services.ErrLLMRequest)
+	}
+}
+
+func TestTemplateProvider(t *testing.T) {
+	service := services.NewLLMService(&internal.LLMConfig{Provider: internal.LLMProviderTemplate})
+	bio, err := service.GenerateBio(context.Background(), newBioCharacter())
+	if err != nil {
+		t.Fatalf("unexpected error generating a bio: %v", err)
+	}
+	for _, want := range []string{"Tordek is a Hill Dwarf Cleric, who grew up as an Acolyte.", "Their toughness", "their agility", "Used to be a sailor."} {
+		if !strings.Contains(bio, want) {
+			t.Fatalf("got bio %q; want it to contain %q", bio, want)
+		}
+	}
+	again, _ := service.GenerateBio(context.Background(), newBioCharacter())
+	if again != bio {
+		t.Fatalf("got bio %q the second time; want the same bio %q", again, bio)
+	}
+
+	var streamed strings.Builder
+	err = service.StreamBio(context.Background(), newBioCharacter(), func(token string) error {
+		streamed.WriteString(token)
+		return nil
+	})
+	if err != nil || streamed.String() != bio {
+		t.Fatalf("got streamed bio %q and error %v; want %q", streamed.String(), err, bio)
+	}
+
+	personality, err := service.GeneratePersonality(context.Background(), newBioCharacter())
+	if err != nil {
+		t.Fatalf("unexpected error generating a personality: %v", err)
+	}
+	if !strings.HasPrefix(personality.Ideal, "Tradition.") || personality.Alignment != character.AlignmentLawfulGood {
+		t.Fatalf("got personality %+v; want the first Acolyte ideal with Lawful Good", personality)
+	}
+
+	data := newBioCharacter()
+	data.Background = "Lighthouse Keeper"
+	if personality, err = service.GeneratePersonality(context.Background(), data); err != nil || personality.Alignment != character.AlignmentNeutral {
+		t.Fatalf("got personality %+v and error %v for a custom background; want the default personality", personality, err)
+	}
+}
+
+func TestTemplateProviderCharacter(t *testing.T) {
+	service := services.NewLLMService(&internal.LLMConfig{Provider: internal.LLMProviderTemplate})
+	for _, version := range character.RulesetVersions {
+		data, err := service.GenerateCharacter(context.Background(), 1, "A grumpy dwarven cleric who used to be a sailor", character.RulesFor(version), version)
+		if err != nil {
+			t.Fatalf("unexpected error creating a %s character: %v", version, err)
+		}
+		if data.RaceType != "Dwarf" || data.Class != "Cleric" || data.Wisdom != 15 {
+			t.Fatalf("got %s %s with Wisdom %d under %s; want a Dwarf Cleric with Wisdom 15", data.RaceType, data.Class, data.Wisdom, version)
+		}
+		if !strings.Contains(data.Bio, "A grumpy dwarven cleric who used to be a sailor") {
+			t.Fatalf("got bio %q; want it to contain the description", data.Bio)
+		}
+	}
+
+	data, _ := service.GenerateCharacter(context.Background(), 1, "a dwarven sailor", character.RulesFor(character.Ruleset2014), character.Ruleset2014)
+	if data.SubraceType.String != "Hill Dwarf" || data.Background != "Sailor" {
+		t.Fatalf("got %s with the %s background; want a Hill Dwarf Sailor", data.SubraceType.String, data.Background)
+	}
+}
+
+func TestLLMNotConfigured(t *testing.T) {
+	service := services.NewLLMService(&internal.LLMConfig{})
+	if service.IsConfigured() {
+		t.Fatalf("got a configured llm without a provider")
+	}
+	if _, err := service.GenerateBio(context.Background(), newBioCharacter()); !errors.Is(err, services.ErrLLMNotConfigured) {
+		t.Fatalf("got error %v generating a bio; want %v", err, services.ErrLLMNotConfigured)
+	}
+	err := service.StreamBio(context.Background(), newBioCharacter(), func(string) error { return nil })
+	if !errors.Is(err, services.ErrLLMNotConfigured) {
+		t.Fatalf("got error %v streaming a bio; want %v", err, services.ErrLLMNotConfigured)
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/dice"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+	"fmt"
+)
+
+type LevelService struct {
+	repo          *repositories.LevelRepository
+	characterRepo *repositories.CharacterRepository
+	roller        *dice.Roller
+}
+
+func NewLevelService(repo *repositories.LevelRepository, characterRepo *repositories.CharacterRepository, roller *dice.Roller) *LevelService {
+	return &LevelService{repo: repo, characterRepo: characterRepo, roller: roller}
+}
+
+func (s *LevelService) AddExperience(characterId, userId, amount int) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if err := sheet.AddExperience(amount); err != nil {
+		return err
+	}
+	return s.repo.SetExperience(characterId, userId, sheet.Experience)
+}
+
+// LevelUp advances the character one level in the class, or their starting class when class is
+// empty. When rolled is set the hit die is rolled on the server, otherwise the fixed average is taken.
+func (s *LevelService) LevelUp(characterId, userId int, class character.ClassName, rolled bool) (*character.LevelHitPoints, error) {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if class == "" {
+		class = sheet.GetStartingClass()
+	}
+	hitDie := sheet.GetClassDefinition(class).GetHitDie()
+	hitDieRoll := hitDie.GetAverage()
+	if rolled {
+		result, err := s.roller.RollString(fmt.Sprintf("1d%d", hitDie))
+		if err != nil {
+			return nil, err
+		}
+		hitDieRoll = result.Total
+	}
+
+	entry, err := sheet.LevelUp(class, hitDieRoll, rolled)
+	if err != nil {
+		return nil, err
+	}
+	hitPoints := models.CharacterHitPointsFromLevel(characterId, entry)
+	classes := models.CharacterClassesFromSheet(characterId, sheet.Classes)
+	if err := s.repo.AddLevel(hitPoints, classes, userId, sheet.CurrentHealthPoints); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
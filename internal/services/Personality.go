@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"dndcc/internal/character"
+	"dndcc/internal/dice"
+	"dndcc/internal/models"
+	"fmt"
+)
+
+// PersonalityService fills in the personality of a character. The LLM writes it when one is
+// configured; otherwise it is rolled on the background's tables.
+type PersonalityService struct {
+	llm    *LLMService
+	roller *dice.Roller
+}
+
+func NewPersonalityService(llm *LLMService, roller *dice.Roller) *PersonalityService {
+	return &PersonalityService{llm: llm, roller: roller}
+}
+
+// Generate returns a new personality for the character as filled in on the form so far. A rolled
+// personality keeps the appearance the player already wrote, since there is no table for it.
+func (s *PersonalityService) Generate(ctx context.Context, data *models.Character) (character.Personality, error) {
+	if s.llm.IsConfigured() {
+		return s.llm.GeneratePersonality(ctx, data)
+	}
+
+	background := data.ToCharacterSheet().Background
+	tables, ok := background.GetPersonalityTables()
+	if !ok {
+		return character.Personality{}, fmt.Errorf("%w: %s", character.ErrNoPersonalityTables, data.Background)
+	}
+	personality, err := character.RollPersonality(tables, s.roller)
+	if err != nil {
+		return character.Personality{}, err
+	}
+	personality.Appearance = data.Appearance
+	return personality, nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+	"fmt"
+)
+
+type ProficiencyService struct {
+	repo          *repositories.ProficiencyRepository
+	characterRepo *repositories.CharacterRepository
+}
+
+func NewProficiencyService(repo *repositories.ProficiencyRepository, characterRepo *repositories.CharacterRepository) *ProficiencyService {
+	return &ProficiencyService{repo: repo, characterRepo: characterRepo}
+}
+
+// GrantProficiency records a skill, tool, language, armor or weapon proficiency chosen for the character.
+func (s *ProficiencyService) GrantProficiency(characterId, userId int, kind character.ProficiencyKind, name string, source character.ProficiencySource) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if err := sheet.GrantProficiency(kind, name, source); err != nil {
+		return fmt.Errorf("failed to grant proficiency: %w", err)
+	}
+	granted := sheet.Proficiencies[len(sheet.Proficiencies)-1]
+	return s.repo.Create(models.CharacterProficiencyFromSheet(characterId, granted), userId)
+}
+
+func (s *ProficiencyService) RevokeProficiency(characterId, userId int, kind character.ProficiencyKind, name string, source character.ProficiencySource) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := data.ToCharacterSheet().RevokeProficiency(kind, name, source); err != nil {
+		return fmt.Errorf("failed to revoke proficiency: %w", err)
+	}
+	revoked := character.Proficiency{Kind: kind, Name: name, Level: character.ProficiencyProficient, Source: source}
+	return s.repo.Delete(models.CharacterProficiencyFromSheet(characterId, revoked), userId)
+}
+
+// ChooseExpertise uses one of the character's expertise choices on a skill or tool.
+func (s *ProficiencyService) ChooseExpertise(characterId, userId int, kind character.ProficiencyKind, name string) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if err := sheet.ChooseExpertise(kind, name); err != nil {
+		return fmt.Errorf("failed to choose expertise: %w", err)
+	}
+	chosen := sheet.Proficiencies[len(sheet.Proficiencies)-1]
+	return s.repo.Create(models.CharacterProficiencyFromSheet(characterId, chosen), userId)
+}
+
+func (s *ProficiencyService) RemoveExpertise(characterId, userId int, kind character.ProficiencyKind, name string) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	if err := data.ToCharacterSheet().RemoveExpertise(kind, name); err != nil {
+		return fmt.Errorf("failed to remove expertise: %w", err)
+	}
+	removed := character.Proficiency{Kind: kind, Name: name, Level: character.ProficiencyExpertise, Source: character.SourceClass}
+	return s.repo.Delete(models.CharacterProficiencyFromSheet(characterId, removed), userId)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/dice"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+	"fmt"
+)
+
+type RestService struct {
+	repo          *repositories.RestRepository
+	characterRepo *repositories.CharacterRepository
+	roller        *dice.Roller
+}
+
+func NewRestService(repo *repositories.RestRepository, characterRepo *repositories.CharacterRepository, roller *dice.Roller) *RestService {
+	return &RestService{repo: repo, characterRepo: characterRepo, roller: roller}
+}
+
+// ShortRest spends the requested number of hit dice of each size, rolling them on the server and
+// recording the rolls in the roll log, then regains short rest resources. It returns the hit
+// points regained.
+func (s *RestService) ShortRest(characterId, userId int, hitDice map[character.HitDie]int) (int, error) {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	sheet := data.ToCharacterSheet()
+	for die, count := range hitDice {
+		if count < 0 {
+			return 0, fmt.Errorf("%w: %d d%d", character.ErrInvalidHitDiceCount, count, die)
+		}
+		if count > 0 && !sheet.HasHitDie(die) {
+			return 0, fmt.Errorf("failed to spend hit dice: %w: d%d", character.ErrUndefinedHitDie, die)
+		}
+	}
+
+	rolls := []models.CharacterRoll{}
+	healed := 0
+	for _, pool := range sheet.GetHitDice() {
+		count := hitDice[pool.Die]
+		expression := fmt.Sprintf("1d%d", pool.Die)
+		if constitution := sheet.GetAbilityScore(character.StatConstitution); constitution != 0 {
+			expression += fmt.Sprintf("%+d", constitution)
+		}
+		for range count {
+			result, err := s.roller.RollString(expression)
+			if err != nil {
+				return 0, err
+			}
+			regained, err := sheet.SpendHitDie(pool.Die, result.Groups[0].Subtotal)
+			if err != nil {
+				return 0, fmt.Errorf("failed to spend hit dice: %w", err)
+			}
+			healed += regained
+			rolls = append(rolls, models.CharacterRoll{
+				CharacterId: characterId,
+				Label:       fmt.Sprintf("Hit Die (d%d)", pool.Die),
+				Expression:  result.Expression,
+				Breakdown:   result.Breakdown(),
+				Total:       result.Total,
+			})
+		}
+	}
+
+	sheet.ShortRest()
+	if err := s.repo.Rest(models.CharacterRestFromSheet(characterId, sheet, rolls), userId); err != nil {
+		return 0, err
+	}
+	return healed, nil
+}
+
+// LongRest regains all hit points, spell slots and feature uses, and half the character's hit dice.
+// It fails for a character who is dead or at 0 hit points.
+func (s *RestService) LongRest(characterId, userId int) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if err := sheet.LongRest(); err != nil {
+		return fmt.Errorf("failed to take a long rest: %w", err)
+	}
+	return s.repo.Rest(models.CharacterRestFromSheet(characterId, sheet, []models.CharacterRoll{}), userId)
+}
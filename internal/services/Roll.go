@@ -0,0 +1,87 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/dice"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+	"fmt"
+)
+
+// rollLogLength is how many rolls are shown in a character's roll log.
+const rollLogLength = 20
+
+type RollService struct {
+	repo          *repositories.RollRepository
+	characterRepo *repositories.CharacterRepository
+	roller        *dice.Roller
+}
+
+func NewRollService(repo *repositories.RollRepository, characterRepo *repositories.CharacterRepository, roller *dice.Roller) *RollService {
+	return &RollService{repo: repo, characterRepo: characterRepo, roller: roller}
+}
+
+// Roll evaluates the expression and records it in the character's roll log.
+func (s *RollService) Roll(characterId, userId int, label, expression string) (*models.CharacterRoll, error) {
+	result, err := s.roller.RollString(expression)
+	if err != nil {
+		return nil, err
+	}
+	if label == "" {
+		label = result.Expression
+	}
+
+	return s.repo.Create(&models.CharacterRoll{
+		CharacterId: characterId,
+		Label:       label,
+		Expression:  result.Expression,
+		Breakdown:   result.Breakdown(),
+		Total:       result.Total,
+	}, userId)
+}
+
+// RollSkill rolls a d20 ability check using the character's bonus for the skill, with advantage or
+// disadvantage from their conditions and exhaustion.
+func (s *RollService) RollSkill(characterId, userId int, skill character.SkillName) (*models.CharacterRoll, error) {
+	sheet, err := s.getSheet(characterId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if !sheet.IsDefinedSkill(skill) {
+		return nil, fmt.Errorf("%w: %s", character.ErrUndefinedSkill, skill)
+	}
+	return s.Roll(characterId, userId, fmt.Sprintf("%s check", skill), withRollMode(fmt.Sprintf("1d20%+d", sheet.GetSkill(skill)), sheet.GetAbilityCheckMode()))
+}
+
+// RollSavingThrow rolls a d20 saving throw using the character's bonus for the stat, with advantage
+// or disadvantage from their conditions and exhaustion.
+func (s *RollService) RollSavingThrow(characterId, userId int, stat character.StatName) (*models.CharacterRoll, error) {
+	if !stat.IsValid() || stat == character.StatYourChoice {
+		return nil, fmt.Errorf("%w: %s", character.ErrUndefinedStat, stat)
+	}
+	sheet, err := s.getSheet(characterId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return s.Roll(characterId, userId, fmt.Sprintf("%s saving throw", stat), withRollMode(fmt.Sprintf("1d20%+d", sheet.GetSavingThrow(stat)), sheet.GetSavingThrowMode(stat)))
+}
+
+func (s *RollService) List(characterId, userId int) ([]models.CharacterRoll, error) {
+	return s.repo.GetRecent(characterId, userId, rollLogLength)
+}
+
+// withRollMode adds the advantage or disadvantage suffix the dice parser reads to an expression.
+func withRollMode(expression string, mode dice.RollMode) string {
+	if mode == dice.RollNormal {
+		return expression
+	}
+	return expression + " " + string(mode)
+}
+
+func (s *RollService) getSheet(characterId, userId int) (*character.Character, error) {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return data.ToCharacterSheet(), nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"dndcc/internal/character"
+	"dndcc/internal/models"
+	"dndcc/internal/repositories"
+	"fmt"
+)
+
+type SpellService struct {
+	repo          *repositories.SpellRepository
+	characterRepo *repositories.CharacterRepository
+}
+
+func NewSpellService(repo *repositories.SpellRepository, characterRepo *repositories.CharacterRepository) *SpellService {
+	return &SpellService{repo: repo, characterRepo: characterRepo}
+}
+
+func (s *SpellService) Create(data *models.CharacterSpell, characterId, userId int) (*models.CharacterSpell, error) {
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	data.CharacterId = characterId
+	if data.Prepared {
+		if err := s.checkPrepared(characterId, userId, func(sheet *character.Character) {
+			sheet.Spells = append(sheet.Spells, data.ToKnownSpell())
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.Create(data, userId)
+}
+
+// SetPrepared prepares or unprepares one of the character's spells. Preparing fails once the
+// character has as many spells prepared as their class allows.
+func (s *SpellService) SetPrepared(id, characterId, userId int, prepared bool) error {
+	if prepared {
+		if err := s.checkPrepared(characterId, userId, func(sheet *character.Character) {
+			for i := range sheet.Spells {
+				if sheet.Spells[i].ID == id {
+					sheet.Spells[i].Prepared = true
+				}
+			}
+		}); err != nil {
+			return err
+		}
+	}
+	return s.repo.SetPrepared(id, characterId, userId, prepared)
+}
+
+// checkPrepared applies change to the character's sheet and checks the spells they would have
+// prepared afterwards.
+func (s *SpellService) checkPrepared(characterId, userId int, change func(sheet *character.Character)) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+	sheet := data.ToCharacterSheet()
+	change(sheet)
+	return sheet.ValidatePreparedSpells()
+}
+
+func (s *SpellService) Delete(id, characterId, userId int) error {
+	return s.repo.Delete(id, characterId, userId)
+}
+
+// ExpendSlot uses one spell slot of the given level, failing if none remain.
+func (s *SpellService) ExpendSlot(characterId, userId, level int, pact bool) error {
+	return s.updateSlots(characterId, userId, level, pact, true)
+}
+
+// RestoreSlot regains one expended spell slot of the given level.
+func (s *SpellService) RestoreSlot(characterId, userId, level int, pact bool) error {
+	return s.updateSlots(characterId, userId, level, pact, false)
+}
+
+func (s *SpellService) updateSlots(characterId, userId, level int, pact, expend bool) error {
+	data, err := s.characterRepo.Get(characterId, userId)
+	if err != nil {
+		return err
+	}
+
+	sheet := data.ToCharacterSheet()
+	if expend {
+		err = sheet.ExpendSpellSlot(level, pact)
+	} else {
+		err = sheet.RestoreSpellSlot(level, pact)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update spell slots: %w", err)
+	}
+
+	expended := sheet.ExpendedPactSlots
+	if !pact {
+		expended = sheet.ExpendedSpellSlots[level-1]
+	}
+
+	return s.repo.SetExpendedSlots(&models.CharacterSpellSlots{
+		CharacterId: characterId,
+		SlotLevel:   level,
+		Pact:        pact,
+		Expended:    expended,
+	}, userId)
+}